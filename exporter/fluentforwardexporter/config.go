@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"errors"
+	"net"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Defaults for not specified configuration settings.
+const (
+	DefaultEndpoint     = "localhost:24224"
+	DefaultTag          = "otel"
+	DefaultTagAttribute = "fluent.tag"
+)
+
+// Config defines configuration for the Fluent Forward exporter.
+type Config struct {
+	config.ExporterSettings        `mapstructure:",squash"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// Endpoint is the host and port of the Fluentd or Fluent Bit forward
+	// input. The default value is defined by the DefaultEndpoint constant.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// TLSSetting configures the TLS connection. TLS is disabled by default.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Tag is the tag used for log records that don't carry the TagAttribute.
+	// The default value is defined by the DefaultTag constant.
+	Tag string `mapstructure:"tag"`
+
+	// TagAttribute is the log record attribute the tag is read from. The
+	// attribute is not sent as part of the record. An empty value always
+	// uses Tag. The default value matches the attribute set by the
+	// fluentforward receiver.
+	TagAttribute string `mapstructure:"tag_attribute"`
+
+	// CompressGzip sends records in CompressedPackedForward mode.
+	CompressGzip bool `mapstructure:"compress_gzip"`
+
+	// RequireAck requests an acknowledgement from the server for every
+	// message and fails the export if it doesn't arrive.
+	RequireAck bool `mapstructure:"require_ack"`
+
+	// SharedKey enables the handshake phase of the protocol and
+	// authenticates the exporter against the server.
+	SharedKey string `mapstructure:"shared_key"`
+
+	// SelfHostname is the hostname sent during the handshake. It defaults
+	// to the hostname of the machine.
+	SelfHostname string `mapstructure:"self_hostname"`
+
+	// Username and Password are sent during the handshake when the server
+	// requires user authentication.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+func (cfg *Config) Validate() error {
+	if _, _, err := net.SplitHostPort(cfg.Endpoint); err != nil {
+		return errors.New("\"endpoint\" must be in the form of <hostname>:<port>")
+	}
+	if cfg.Tag == "" {
+		return errors.New("\"tag\" must not be empty")
+	}
+	if cfg.SharedKey == "" && (cfg.SelfHostname != "" || cfg.Username != "" || cfg.Password != "") {
+		return errors.New("\"self_hostname\", \"username\" and \"password\" require \"shared_key\"")
+	}
+	return nil
+}
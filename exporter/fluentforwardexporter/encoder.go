@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// bodyKey is the record key holding the log body. Fluent Bit uses "log",
+// and the fluentforward receiver maps it back to the body.
+const bodyKey = "log"
+
+// eventTime is the EventTime extension of the Forward protocol, which
+// carries timestamps with nanosecond precision.
+type eventTime time.Time
+
+func (*eventTime) ExtensionType() int8 {
+	return 0x00
+}
+
+func (*eventTime) Len() int {
+	return 8
+}
+
+func (t *eventTime) MarshalBinaryTo(b []byte) error {
+	binary.BigEndian.PutUint32(b[0:], uint32(time.Time(*t).Unix()))
+	binary.BigEndian.PutUint32(b[4:], uint32(time.Time(*t).Nanosecond()))
+	return nil
+}
+
+func (t *eventTime) UnmarshalBinary(b []byte) error {
+	if len(b) != 8 {
+		return errors.New("data should be exactly 8 bytes")
+	}
+	secs := int64(binary.BigEndian.Uint32(b[0:]))
+	nanos := int64(binary.BigEndian.Uint32(b[4:]))
+	*t = eventTime(time.Unix(secs, nanos))
+	return nil
+}
+
+// message is an encoded Forward protocol message holding all the records
+// of a single tag.
+type message struct {
+	tag     string
+	records int
+	// chunk is the value of the "chunk" option, empty if no acknowledgement
+	// is requested.
+	chunk   string
+	payload []byte
+}
+
+// encoder converts logs into Forward or CompressedPackedForward messages.
+type encoder struct {
+	tag          string
+	tagAttribute string
+	compressGzip bool
+	requireAck   bool
+}
+
+func newEncoder(cfg *Config) *encoder {
+	return &encoder{
+		tag:          cfg.Tag,
+		tagAttribute: cfg.TagAttribute,
+		compressGzip: cfg.CompressGzip,
+		requireAck:   cfg.RequireAck,
+	}
+}
+
+// encode groups the log records by tag and returns one message per tag, in
+// the order the tags first appear.
+func (e *encoder) encode(ld plog.Logs) ([]message, error) {
+	var tags []string
+	entries := map[string][]byte{}
+	counts := map[string]int{}
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resAttrs := rl.Resource().Attributes()
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				tag := e.tagOf(lr)
+				if _, ok := entries[tag]; !ok {
+					tags = append(tags, tag)
+				}
+				b, err := e.appendEntry(entries[tag], resAttrs, lr)
+				if err != nil {
+					return nil, err
+				}
+				entries[tag] = b
+				counts[tag]++
+			}
+		}
+	}
+
+	msgs := make([]message, 0, len(tags))
+	for _, tag := range tags {
+		msg, err := e.newMessage(tag, entries[tag], counts[tag])
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+func (e *encoder) tagOf(lr plog.LogRecord) string {
+	if e.tagAttribute == "" {
+		return e.tag
+	}
+	if v, ok := lr.Attributes().Get(e.tagAttribute); ok && v.Type() == pcommon.ValueTypeStr && v.Str() != "" {
+		return v.Str()
+	}
+	return e.tag
+}
+
+func (e *encoder) newMessage(tag string, entries []byte, count int) (message, error) {
+	msg := message{tag: tag, records: count}
+	if e.requireAck {
+		chunk, err := newChunkID()
+		if err != nil {
+			return message{}, err
+		}
+		msg.chunk = chunk
+	}
+
+	b := msgp.AppendArrayHeader(nil, 3)
+	b = msgp.AppendString(b, tag)
+	if e.compressGzip {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(entries); err != nil {
+			return message{}, err
+		}
+		if err := zw.Close(); err != nil {
+			return message{}, err
+		}
+		b = msgp.AppendBytes(b, buf.Bytes())
+	} else {
+		b = msgp.AppendArrayHeader(b, uint32(count))
+		b = append(b, entries...)
+	}
+
+	options := uint32(1)
+	if e.compressGzip {
+		options++
+	}
+	if msg.chunk != "" {
+		options++
+	}
+	b = msgp.AppendMapHeader(b, options)
+	b = msgp.AppendString(b, "size")
+	b = msgp.AppendInt(b, count)
+	if e.compressGzip {
+		b = msgp.AppendString(b, "compressed")
+		b = msgp.AppendString(b, "gzip")
+	}
+	if msg.chunk != "" {
+		b = msgp.AppendString(b, "chunk")
+		b = msgp.AppendString(b, msg.chunk)
+	}
+
+	msg.payload = b
+	return msg, nil
+}
+
+// appendEntry appends the [time, record] entry of lr to b. The record holds
+// the resource attributes, the log record attributes and the body, later
+// ones taking precedence on conflicting keys.
+func (e *encoder) appendEntry(b []byte, resAttrs pcommon.Map, lr plog.LogRecord) ([]byte, error) {
+	ts := lr.Timestamp().AsTime()
+	if lr.Timestamp() == 0 {
+		ts = lr.ObservedTimestamp().AsTime()
+		if lr.ObservedTimestamp() == 0 {
+			ts = time.Now()
+		}
+	}
+
+	b = msgp.AppendArrayHeader(b, 2)
+	et := eventTime(ts)
+	b, err := msgp.AppendExtension(b, &et)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := lr.Attributes()
+	hasBody := lr.Body().Type() != pcommon.ValueTypeEmpty
+	includeAttr := func(k string) bool {
+		return !(hasBody && k == bodyKey) && (e.tagAttribute == "" || k != e.tagAttribute)
+	}
+	includeResAttr := func(k string) bool {
+		_, shadowed := attrs.Get(k)
+		return !shadowed && includeAttr(k)
+	}
+
+	size := uint32(0)
+	resAttrs.Range(func(k string, _ pcommon.Value) bool {
+		if includeResAttr(k) {
+			size++
+		}
+		return true
+	})
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		if includeAttr(k) {
+			size++
+		}
+		return true
+	})
+	if hasBody {
+		size++
+	}
+
+	b = msgp.AppendMapHeader(b, size)
+	resAttrs.Range(func(k string, v pcommon.Value) bool {
+		if includeResAttr(k) {
+			b = msgp.AppendString(b, k)
+			b = appendValue(b, v)
+		}
+		return true
+	})
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if includeAttr(k) {
+			b = msgp.AppendString(b, k)
+			b = appendValue(b, v)
+		}
+		return true
+	})
+	if hasBody {
+		b = msgp.AppendString(b, bodyKey)
+		b = appendValue(b, lr.Body())
+	}
+	return b, nil
+}
+
+func appendValue(b []byte, v pcommon.Value) []byte {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		return msgp.AppendString(b, v.Str())
+	case pcommon.ValueTypeInt:
+		return msgp.AppendInt64(b, v.Int())
+	case pcommon.ValueTypeDouble:
+		return msgp.AppendFloat64(b, v.Double())
+	case pcommon.ValueTypeBool:
+		return msgp.AppendBool(b, v.Bool())
+	case pcommon.ValueTypeBytes:
+		return msgp.AppendBytes(b, v.Bytes().AsRaw())
+	case pcommon.ValueTypeMap:
+		m := v.Map()
+		b = msgp.AppendMapHeader(b, uint32(m.Len()))
+		m.Range(func(k string, v pcommon.Value) bool {
+			b = msgp.AppendString(b, k)
+			b = appendValue(b, v)
+			return true
+		})
+		return b
+	case pcommon.ValueTypeSlice:
+		s := v.Slice()
+		b = msgp.AppendArrayHeader(b, uint32(s.Len()))
+		for i := 0; i < s.Len(); i++ {
+			b = appendValue(b, s.At(i))
+		}
+		return b
+	default:
+		return msgp.AppendNil(b)
+	}
+}
+
+// newChunkID returns a random identifier for the "chunk" option.
+func newChunkID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(id), nil
+}
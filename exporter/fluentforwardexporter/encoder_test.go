@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type decodedEntry struct {
+	time   time.Time
+	record map[string]interface{}
+}
+
+type decodedMessage struct {
+	tag     string
+	entries []decodedEntry
+	options map[string]interface{}
+}
+
+// decodeMessage decodes a Forward or CompressedPackedForward message.
+func decodeMessage(t *testing.T, r *msgp.Reader) decodedMessage {
+	n, err := r.ReadArrayHeader()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, n)
+
+	var msg decodedMessage
+	msg.tag, err = r.ReadString()
+	require.NoError(t, err)
+
+	typ, err := r.NextType()
+	require.NoError(t, err)
+	if typ == msgp.BinType {
+		packed, err := r.ReadBytes(nil)
+		require.NoError(t, err)
+		zr, err := gzip.NewReader(bytes.NewReader(packed))
+		require.NoError(t, err)
+		entries := msgp.NewReader(zr)
+		for {
+			if _, err = entries.NextType(); err == io.EOF {
+				break
+			}
+			msg.entries = append(msg.entries, decodeEntry(t, entries))
+		}
+	} else {
+		n, err = r.ReadArrayHeader()
+		require.NoError(t, err)
+		for i := uint32(0); i < n; i++ {
+			msg.entries = append(msg.entries, decodeEntry(t, r))
+		}
+	}
+
+	msg.options = map[string]interface{}{}
+	require.NoError(t, r.ReadMapStrIntf(msg.options))
+	return msg
+}
+
+func decodeEntry(t *testing.T, r *msgp.Reader) decodedEntry {
+	n, err := r.ReadArrayHeader()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, n)
+
+	var et eventTime
+	require.NoError(t, r.ReadExtension(&et))
+	record := map[string]interface{}{}
+	require.NoError(t, r.ReadMapStrIntf(record))
+	return decodedEntry{time: time.Time(et), record: record}
+}
+
+func decodePayload(t *testing.T, msg message) decodedMessage {
+	r := msgp.NewReader(bytes.NewReader(msg.payload))
+	decoded := decodeMessage(t, r)
+	_, err := r.NextType()
+	require.ErrorIs(t, err, io.EOF)
+	return decoded
+}
+
+func testLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "web-1")
+	rl.Resource().Attributes().PutStr("env", "prod")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+
+	lr := lrs.AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1667000000, 123456789)))
+	lr.Body().SetStr("first")
+	lr.Attributes().PutStr("fluent.tag", "app.access")
+	lr.Attributes().PutStr("env", "staging")
+	lr.Attributes().PutInt("status", 200)
+
+	lr = lrs.AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(1667000001, 0)))
+	body := lr.Body().SetEmptyMap()
+	body.PutStr("msg", "second")
+	body.PutEmptySlice("values").AppendEmpty().SetDouble(1.5)
+	lr.Attributes().PutBool("ok", true)
+	lr.Attributes().PutEmptyBytes("raw").FromRaw([]byte{1, 2})
+
+	lr = lrs.AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1667000002, 0)))
+	lr.Attributes().PutStr("fluent.tag", "app.access")
+	lr.Attributes().PutStr("log", "attribute")
+	return ld
+}
+
+func TestEncode(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	msgs, err := newEncoder(cfg).encode(testLogs())
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	assert.Equal(t, "app.access", msgs[0].tag)
+	assert.Equal(t, 2, msgs[0].records)
+	assert.Empty(t, msgs[0].chunk)
+	access := decodePayload(t, msgs[0])
+	assert.Equal(t, "app.access", access.tag)
+	assert.Equal(t, map[string]interface{}{"size": int64(2)}, access.options)
+	require.Len(t, access.entries, 2)
+	assert.Equal(t, time.Unix(1667000000, 123456789), access.entries[0].time)
+	assert.Equal(t, map[string]interface{}{
+		"host.name": "web-1",
+		"env":       "staging",
+		"status":    int64(200),
+		"log":       "first",
+	}, access.entries[0].record)
+	assert.Equal(t, time.Unix(1667000002, 0), access.entries[1].time)
+	assert.Equal(t, map[string]interface{}{
+		"host.name": "web-1",
+		"env":       "prod",
+		"log":       "attribute",
+	}, access.entries[1].record)
+
+	assert.Equal(t, "otel", msgs[1].tag)
+	other := decodePayload(t, msgs[1])
+	assert.Equal(t, "otel", other.tag)
+	require.Len(t, other.entries, 1)
+	assert.Equal(t, time.Unix(1667000001, 0), other.entries[0].time)
+	assert.Equal(t, map[string]interface{}{
+		"host.name": "web-1",
+		"env":       "prod",
+		"ok":        true,
+		"raw":       []byte{1, 2},
+		"log": map[string]interface{}{
+			"msg":    "second",
+			"values": []interface{}{1.5},
+		},
+	}, other.entries[0].record)
+}
+
+func TestEncodeStaticTag(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Tag = "static"
+	cfg.TagAttribute = ""
+	msgs, err := newEncoder(cfg).encode(testLogs())
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+
+	decoded := decodePayload(t, msgs[0])
+	assert.Equal(t, "static", decoded.tag)
+	require.Len(t, decoded.entries, 3)
+	assert.Equal(t, "app.access", decoded.entries[0].record["fluent.tag"])
+}
+
+func TestEncodeCompressedWithAck(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CompressGzip = true
+	cfg.RequireAck = true
+	msgs, err := newEncoder(cfg).encode(testLogs())
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.NotEqual(t, msgs[0].chunk, msgs[1].chunk)
+
+	for _, msg := range msgs {
+		require.NotEmpty(t, msg.chunk)
+		decoded := decodePayload(t, msg)
+		assert.Equal(t, msg.tag, decoded.tag)
+		assert.Len(t, decoded.entries, msg.records)
+		assert.Equal(t, map[string]interface{}{
+			"size":       int64(msg.records),
+			"compressed": "gzip",
+			"chunk":      msg.chunk,
+		}, decoded.options)
+	}
+}
+
+func TestEncodeEmpty(t *testing.T) {
+	msgs, err := newEncoder(createDefaultConfig().(*Config)).encode(plog.NewLogs())
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+}
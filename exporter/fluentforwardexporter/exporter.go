@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+type fluentForwardExporter struct {
+	config    *Config
+	logger    *zap.Logger
+	encoder   *encoder
+	tlsConfig *tls.Config
+	hostname  string
+
+	// mu serializes the use of conn, the connection is established on the
+	// first push and reused until an error occurs.
+	mu   sync.Mutex
+	conn *connection
+}
+
+// connection is a connection to a Forward protocol server.
+type connection struct {
+	net.Conn
+	reader *msgp.Reader
+	// keepalive is false if the server asked to close the connection after
+	// each push during the handshake.
+	keepalive bool
+}
+
+func newFluentForwardExporter(cfg *Config, set component.TelemetrySettings) *fluentForwardExporter {
+	return &fluentForwardExporter{
+		config:  cfg,
+		logger:  set.Logger,
+		encoder: newEncoder(cfg),
+	}
+}
+
+func (e *fluentForwardExporter) start(_ context.Context, _ component.Host) error {
+	tlsConfig, err := e.config.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return err
+	}
+	e.tlsConfig = tlsConfig
+
+	e.hostname = e.config.SelfHostname
+	if e.hostname == "" && e.config.SharedKey != "" {
+		if e.hostname, err = os.Hostname(); err != nil {
+			return fmt.Errorf("failed to get hostname: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *fluentForwardExporter) shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.closeConn()
+}
+
+func (e *fluentForwardExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	msgs, err := e.encoder.encode(ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, msg := range msgs {
+		if err = e.send(ctx, msg); err != nil {
+			_ = e.closeConn()
+			return fmt.Errorf("failed to send %d records with tag %q: %w", msg.records, msg.tag, err)
+		}
+	}
+	if e.conn != nil && !e.conn.keepalive {
+		return e.closeConn()
+	}
+	return nil
+}
+
+func (e *fluentForwardExporter) send(ctx context.Context, msg message) error {
+	if e.conn == nil {
+		conn, err := e.connect(ctx)
+		if err != nil {
+			return err
+		}
+		e.conn = conn
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := e.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	if _, err := e.conn.Write(msg.payload); err != nil {
+		return err
+	}
+	if msg.chunk == "" {
+		return nil
+	}
+	return e.conn.readAck(msg.chunk)
+}
+
+// connect dials the server and runs the handshake if a shared key is
+// configured.
+func (e *fluentForwardExporter) connect(ctx context.Context) (*connection, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+	dialer := &net.Dialer{}
+	if e.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: e.tlsConfig}).DialContext(ctx, "tcp", e.config.Endpoint)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", e.config.Endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.logger.Debug("Connected to Fluent Forward server", zap.String("endpoint", e.config.Endpoint))
+
+	c := &connection{Conn: conn, reader: msgp.NewReader(conn), keepalive: true}
+	if e.config.SharedKey == "" {
+		return c, nil
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err = c.SetDeadline(deadline); err == nil {
+		err = c.handshake(e.config.SharedKey, e.hostname, e.config.Username, e.config.Password)
+	}
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (e *fluentForwardExporter) closeConn() error {
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
+
+// readAck waits for the server to acknowledge chunk.
+func (c *connection) readAck(chunk string) error {
+	n, err := c.reader.ReadMapHeader()
+	if err != nil {
+		return fmt.Errorf("failed to read ack: %w", err)
+	}
+	ack := ""
+	for ; n > 0; n-- {
+		key, err := c.reader.ReadString()
+		if err != nil {
+			return fmt.Errorf("failed to read ack: %w", err)
+		}
+		if key != "ack" {
+			if err = c.reader.Skip(); err != nil {
+				return fmt.Errorf("failed to read ack: %w", err)
+			}
+			continue
+		}
+		if ack, err = c.reader.ReadString(); err != nil {
+			return fmt.Errorf("failed to read ack: %w", err)
+		}
+	}
+	if ack != chunk {
+		return fmt.Errorf("unexpected ack %q for chunk %q", ack, chunk)
+	}
+	return nil
+}
@@ -0,0 +1,399 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/fluentforwardreceiver"
+)
+
+func newTestExporter(t *testing.T, cfg *Config) *fluentForwardExporter {
+	exp := newFluentForwardExporter(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	})
+	return exp
+}
+
+func pushContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		compressGzip bool
+		requireAck   bool
+	}{
+		{name: "forward"},
+		{name: "forward with ack", requireAck: true},
+		{name: "compressed", compressGzip: true},
+		{name: "compressed with ack", compressGzip: true, requireAck: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := testutil.GetAvailableLocalAddress(t)
+			sink := new(consumertest.LogsSink)
+			rcvFactory := fluentforwardreceiver.NewFactory()
+			rcvCfg := rcvFactory.CreateDefaultConfig().(*fluentforwardreceiver.Config)
+			rcvCfg.ListenAddress = endpoint
+			rcv, err := rcvFactory.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), rcvCfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				assert.NoError(t, rcv.Shutdown(context.Background()))
+			}()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = endpoint
+			cfg.CompressGzip = tt.compressGzip
+			cfg.RequireAck = tt.requireAck
+			exp := newTestExporter(t, cfg)
+			require.NoError(t, exp.pushLogs(pushContext(t), testLogs()))
+			require.NoError(t, exp.pushLogs(pushContext(t), testLogs()))
+
+			require.Eventually(t, func() bool {
+				return sink.LogRecordCount() == 6
+			}, 5*time.Second, 10*time.Millisecond)
+
+			var records []plog.LogRecord
+			for _, ld := range sink.AllLogs() {
+				lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+				for i := 0; i < lrs.Len(); i++ {
+					records = append(records, lrs.At(i))
+				}
+			}
+
+			lr := records[0]
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1667000000, 123456789)), lr.Timestamp())
+			assert.Equal(t, "first", lr.Body().Str())
+			assert.Equal(t, map[string]interface{}{
+				"fluent.tag": "app.access",
+				"host.name":  "web-1",
+				"env":        "staging",
+				"status":     int64(200),
+			}, lr.Attributes().AsRaw())
+
+			lr = records[2]
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1667000001, 0)), lr.Timestamp())
+			assert.Equal(t, map[string]interface{}{
+				"msg":    "second",
+				"values": []interface{}{1.5},
+			}, lr.Body().Map().AsRaw())
+			assert.Equal(t, "otel", lr.Attributes().AsRaw()["fluent.tag"])
+		})
+	}
+}
+
+// serve runs handler for every connection accepted by ln until the test ends.
+func serve(t *testing.T, ln net.Listener, handler func(net.Conn, *msgp.Reader)) string {
+	t.Cleanup(func() {
+		ln.Close()
+	})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handler(conn, msgp.NewReader(conn))
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func listen(t *testing.T) net.Listener {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	return ln
+}
+
+type fakeServer struct {
+	sharedKey   string
+	hostname    string
+	keepalive   bool
+	authSalt    string
+	users       map[string]string
+	badHostname bool
+}
+
+// handshake runs the server side of the handshake and returns whether the
+// client was authenticated.
+func (s *fakeServer) handshake(t *testing.T, conn net.Conn, r *msgp.Reader) bool {
+	nonce := "server-nonce"
+	b := msgp.AppendArrayHeader(nil, 2)
+	b = msgp.AppendString(b, "HELO")
+	b = msgp.AppendMapHeader(b, 3)
+	b = msgp.AppendString(b, "nonce")
+	b = msgp.AppendBytes(b, []byte(nonce))
+	b = msgp.AppendString(b, "auth")
+	b = msgp.AppendBytes(b, []byte(s.authSalt))
+	b = msgp.AppendString(b, "keepalive")
+	b = msgp.AppendBool(b, s.keepalive)
+	_, err := conn.Write(b)
+	require.NoError(t, err)
+
+	v, err := r.ReadIntf()
+	require.NoError(t, err)
+	ping := v.([]interface{})
+	require.Len(t, ping, 6)
+	require.Equal(t, "PING", ping[0])
+	clientHostname := ping[1].(string)
+	salt := ping[2].(string)
+
+	reason := ""
+	if ping[3] != hexDigest(salt, clientHostname, nonce, s.sharedKey) {
+		reason = "shared_key mismatch"
+	} else if s.authSalt != "" {
+		password, ok := s.users[ping[4].(string)]
+		if !ok || ping[5] != hexDigest(s.authSalt, ping[4].(string), password) {
+			reason = "username/password mismatch"
+		}
+	}
+
+	serverDigest := hexDigest(salt, s.hostname, nonce, s.sharedKey)
+	if s.badHostname {
+		serverDigest = hexDigest(salt, "other", nonce, s.sharedKey)
+	}
+	b = msgp.AppendArrayHeader(nil, 5)
+	b = msgp.AppendString(b, "PONG")
+	b = msgp.AppendBool(b, reason == "")
+	b = msgp.AppendString(b, reason)
+	b = msgp.AppendString(b, s.hostname)
+	b = msgp.AppendString(b, serverDigest)
+	_, err = conn.Write(b)
+	require.NoError(t, err)
+	return reason == ""
+}
+
+func TestHandshake(t *testing.T) {
+	tests := []struct {
+		name      string
+		server    fakeServer
+		sharedKey string
+		username  string
+		password  string
+		err       string
+	}{
+		{
+			name:      "shared key",
+			server:    fakeServer{sharedKey: "secret", hostname: "fluentd", keepalive: true},
+			sharedKey: "secret",
+		},
+		{
+			name:      "no keepalive",
+			server:    fakeServer{sharedKey: "secret", hostname: "fluentd"},
+			sharedKey: "secret",
+		},
+		{
+			name: "user auth",
+			server: fakeServer{
+				sharedKey: "secret",
+				hostname:  "fluentd",
+				keepalive: true,
+				authSalt:  "auth-salt",
+				users:     map[string]string{"user": "pass"},
+			},
+			sharedKey: "secret",
+			username:  "user",
+			password:  "pass",
+		},
+		{
+			name:      "wrong shared key",
+			server:    fakeServer{sharedKey: "secret", hostname: "fluentd", keepalive: true},
+			sharedKey: "wrong",
+			err:       "handshake: authentication failed: shared_key mismatch",
+		},
+		{
+			name: "wrong password",
+			server: fakeServer{
+				sharedKey: "secret",
+				hostname:  "fluentd",
+				keepalive: true,
+				authSalt:  "auth-salt",
+				users:     map[string]string{"user": "pass"},
+			},
+			sharedKey: "secret",
+			username:  "user",
+			password:  "wrong",
+			err:       "handshake: authentication failed: username/password mismatch",
+		},
+		{
+			name:      "server digest mismatch",
+			server:    fakeServer{sharedKey: "secret", hostname: "fluentd", keepalive: true, badHostname: true},
+			sharedKey: "secret",
+			err:       "handshake: server shared key mismatch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received := make(chan decodedMessage, 2)
+			endpoint := serve(t, listen(t), func(conn net.Conn, r *msgp.Reader) {
+				if !tt.server.handshake(t, conn, r) {
+					return
+				}
+				for {
+					if _, err := r.NextType(); err != nil {
+						return
+					}
+					received <- decodeMessage(t, r)
+				}
+			})
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = endpoint
+			cfg.TagAttribute = ""
+			cfg.SharedKey = tt.sharedKey
+			cfg.SelfHostname = "collector"
+			cfg.Username = tt.username
+			cfg.Password = tt.password
+			exp := newTestExporter(t, cfg)
+
+			err := exp.pushLogs(pushContext(t), testLogs())
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				assert.Nil(t, exp.conn)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.server.keepalive, exp.conn != nil)
+
+			require.NoError(t, exp.pushLogs(pushContext(t), testLogs()))
+			for i := 0; i < 2; i++ {
+				select {
+				case msg := <-received:
+					assert.Equal(t, "otel", msg.tag)
+					assert.Len(t, msg.entries, 3)
+				case <-time.After(5 * time.Second):
+					t.Fatal("timed out waiting for message")
+				}
+			}
+		})
+	}
+}
+
+func TestAckMismatch(t *testing.T) {
+	endpoint := serve(t, listen(t), func(conn net.Conn, r *msgp.Reader) {
+		if _, err := r.NextType(); err != nil {
+			return
+		}
+		decodeMessage(t, r)
+		b := msgp.AppendMapHeader(nil, 1)
+		b = msgp.AppendString(b, "ack")
+		b = msgp.AppendString(b, "other")
+		_, _ = conn.Write(b)
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.TagAttribute = ""
+	cfg.RequireAck = true
+	exp := newTestExporter(t, cfg)
+
+	err := exp.pushLogs(pushContext(t), testLogs())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unexpected ack "other"`)
+	assert.Nil(t, exp.conn)
+}
+
+func TestAckTimeout(t *testing.T) {
+	endpoint := serve(t, listen(t), func(conn net.Conn, r *msgp.Reader) {
+		_, _ = r.NextType()
+		decodeMessage(t, r)
+		_, _ = r.NextType()
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.RequireAck = true
+	exp := newTestExporter(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := exp.pushLogs(ctx, testLogs())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read ack")
+}
+
+func TestTLS(t *testing.T) {
+	cert, err := tls.LoadX509KeyPair(filepath.Join("testdata", "server.crt"), filepath.Join("testdata", "server.key"))
+	require.NoError(t, err)
+	ln, err := tls.Listen("tcp", "localhost:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+
+	received := make(chan decodedMessage, 1)
+	endpoint := serve(t, ln, func(conn net.Conn, r *msgp.Reader) {
+		if _, err := r.NextType(); err != nil {
+			return
+		}
+		received <- decodeMessage(t, r)
+	})
+	_, port, err := net.SplitHostPort(endpoint)
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = net.JoinHostPort("localhost", port)
+	cfg.TagAttribute = ""
+	cfg.TLSSetting = configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{
+			CAFile: filepath.Join("testdata", "server.crt"),
+		},
+	}
+	exp := newTestExporter(t, cfg)
+	require.NoError(t, exp.pushLogs(pushContext(t), testLogs()))
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, "otel", msg.tag)
+		assert.Len(t, msg.entries, 3)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+}
+
+func TestConnectFailure(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	exp := newTestExporter(t, cfg)
+
+	err := exp.pushLogs(pushContext(t), testLogs())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send 2 records with tag \"app.access\"")
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// handshake runs the handshake phase of the Forward protocol: the server
+// sends HELO, the client answers with PING and the server confirms with
+// PONG. Both sides prove they know the shared key by hashing it with the
+// salt and nonce.
+func (c *connection) handshake(sharedKey, hostname, username, password string) error {
+	helo, err := c.readCommand("HELO", 2)
+	if err != nil {
+		return err
+	}
+	options, ok := helo[1].(map[string]interface{})
+	if !ok {
+		return errors.New("handshake: malformed HELO options")
+	}
+	nonce := toBytes(options["nonce"])
+	authSalt := toBytes(options["auth"])
+	if keepalive, ok := options["keepalive"].(bool); ok {
+		c.keepalive = keepalive
+	}
+
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return err
+	}
+	sharedKeySalt := hex.EncodeToString(salt)
+
+	passwordDigest := ""
+	if len(authSalt) > 0 {
+		passwordDigest = hexDigest(string(authSalt), username, password)
+	} else {
+		username = ""
+	}
+
+	b := msgp.AppendArrayHeader(nil, 6)
+	b = msgp.AppendString(b, "PING")
+	b = msgp.AppendString(b, hostname)
+	b = msgp.AppendString(b, sharedKeySalt)
+	b = msgp.AppendString(b, hexDigest(sharedKeySalt, hostname, string(nonce), sharedKey))
+	b = msgp.AppendString(b, username)
+	b = msgp.AppendString(b, passwordDigest)
+	if _, err = c.Write(b); err != nil {
+		return err
+	}
+
+	pong, err := c.readCommand("PONG", 5)
+	if err != nil {
+		return err
+	}
+	if authenticated, _ := pong[1].(bool); !authenticated {
+		return fmt.Errorf("handshake: authentication failed: %s", toBytes(pong[2]))
+	}
+	serverHostname := string(toBytes(pong[3]))
+	if string(toBytes(pong[4])) != hexDigest(sharedKeySalt, serverHostname, string(nonce), sharedKey) {
+		return errors.New("handshake: server shared key mismatch")
+	}
+	return nil
+}
+
+// readCommand reads a handshake message and checks its type and length.
+func (c *connection) readCommand(command string, length int) ([]interface{}, error) {
+	v, err := c.reader.ReadIntf()
+	if err != nil {
+		return nil, fmt.Errorf("handshake: read %s: %w", command, err)
+	}
+	msg, ok := v.([]interface{})
+	if !ok || len(msg) < length || string(toBytes(msg[0])) != command {
+		return nil, fmt.Errorf("handshake: expected %s, got %v", command, v)
+	}
+	return msg, nil
+}
+
+// toBytes returns the contents of a msgpack str or bin value.
+func toBytes(v interface{}) []byte {
+	switch v := v.(type) {
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	default:
+		return nil
+	}
+}
+
+func hexDigest(parts ...string) string {
+	h := sha512.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/v10/parquet/compress"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+	compressionBrotli = "brotli"
+)
+
+var codecs = map[string]compress.Compression{
+	compressionNone:   compress.Codecs.Uncompressed,
+	compressionSnappy: compress.Codecs.Snappy,
+	compressionGzip:   compress.Codecs.Gzip,
+	compressionZstd:   compress.Codecs.Zstd,
+	compressionBrotli: compress.Codecs.Brotli,
+}
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the root directory that the partitioned Parquet files are written to.
+	// Files are written to <path>/signal=<signal>/date=<YYYY-MM-DD>/hour=<HH>/.
+	Path string `mapstructure:"path"`
+
+	// Compression is the codec used to compress the column chunks.
+	// Options: none, snappy[default], gzip, zstd, brotli.
+	Compression string `mapstructure:"compression"`
+
+	// RowGroupSize is the maximum number of rows that are buffered
+	// in memory before they are written to the file as a row group.
+	RowGroupSize int64 `mapstructure:"row_group_size"`
+
+	// Rotation defines when the current file is closed and a new file is started.
+	Rotation Rotation `mapstructure:"rotation"`
+}
+
+// Rotation defines the limits of a single Parquet file.
+// Files are always rotated when the hour partition changes.
+type Rotation struct {
+	// MaxMegabytes is the maximum size in megabytes of the file before it gets
+	// rotated. It defaults to 128 megabytes.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// Interval is the maximum amount of time a file is kept open
+	// before it gets rotated. It defaults to 15 minutes.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if _, ok := codecs[cfg.Compression]; !ok {
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	if cfg.RowGroupSize <= 0 {
+		return errors.New("row_group_size must be positive")
+	}
+	if cfg.Rotation.MaxMegabytes <= 0 {
+		return errors.New("rotation max_megabytes must be positive")
+	}
+	if cfg.Rotation.Interval <= 0 {
+		return errors.New("rotation interval must be positive")
+	}
+	return nil
+}
@@ -17,32 +17,69 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"context"
 
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/memory"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
 )
 
 type parquetExporter struct {
-	path string
+	writer *fileWriter
+	mem    memory.Allocator
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func newParquetExporter(cfg *Config, signal string, logger *zap.Logger) *parquetExporter {
+	schemas := map[string]*arrow.Schema{
+		signalTraces:  tracesSchema,
+		signalMetrics: metricsSchema,
+		signalLogs:    logsSchema,
+	}
+	return &parquetExporter{
+		writer: newFileWriter(cfg, signal, schemas[signal], logger),
+		mem:    memory.DefaultAllocator,
+	}
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
+func (e *parquetExporter) start(_ context.Context, _ component.Host) error {
 	return nil
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+func (e *parquetExporter) shutdown(_ context.Context) error {
+	return e.writer.close()
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+func (e *parquetExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if md.DataPointCount() == 0 {
+		return nil
+	}
+	rec := metricsToRecord(e.mem, md)
+	defer rec.Release()
+	return e.writer.write(rec)
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	if td.SpanCount() == 0 {
+		return nil
+	}
+	rec := tracesToRecord(e.mem, td)
+	defer rec.Release()
+	return e.writer.write(rec)
+}
+
+func (e *parquetExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	if ld.LogRecordCount() == 0 {
+		return nil
+	}
+	rec := logsToRecord(e.mem, ld)
+	defer rec.Release()
+	return e.writer.write(rec)
 }
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/apache/arrow/go/v10/parquet/file"
+	"github.com/apache/arrow/go/v10/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newTestConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	return cfg
+}
+
+// readTable reads all the Parquet files written for the signal into a single table.
+func readTable(t *testing.T, root, signal string) arrow.Table {
+	paths, err := filepath.Glob(filepath.Join(root, "signal="+signal, "date=*", "hour=*", "*"+fileExtension))
+	require.NoError(t, err)
+	require.Len(t, paths, 1, "Must have written a single file")
+
+	rdr, err := file.OpenParquetFile(paths[0], false)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, rdr.Close()) })
+
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	tbl, err := fr.ReadTable(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(tbl.Release)
+	return tbl
+}
+
+func column(t *testing.T, tbl arrow.Table, name string) arrow.Array {
+	indices := tbl.Schema().FieldIndices(name)
+	require.Len(t, indices, 1, "Must have column %q", name)
+	chunks := tbl.Column(indices[0]).Data().Chunks()
+	require.Len(t, chunks, 1)
+	return chunks[0]
+}
+
+func TestExportTraces(t *testing.T) {
+	cfg := newTestConfig(t)
+	exp := newParquetExporter(cfg, signalTraces, zap.NewNop())
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("otelhttp")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(10, 0)))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Unix(12, 0)))
+	span.Attributes().PutInt("http.status_code", 500)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.message", "boom")
+	link := span.Links().AppendEmpty()
+	link.SetTraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+
+	ss.Spans().AppendEmpty().SetName("SELECT")
+
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.consumeTraces(context.Background(), td))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	tbl := readTable(t, cfg.Path, signalTraces)
+	assert.EqualValues(t, 2, tbl.NumRows())
+
+	names := column(t, tbl, "name").(*array.String)
+	assert.Equal(t, "GET /cart", names.Value(0))
+	assert.Equal(t, "SELECT", names.Value(1))
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", column(t, tbl, "trace_id").(*array.String).Value(0))
+	assert.Equal(t, "SPAN_KIND_SERVER", column(t, tbl, "kind").(*array.String).Value(0))
+	assert.EqualValues(t, 2*time.Second, column(t, tbl, "duration_ns").(*array.Int64).Value(0))
+	assert.True(t, column(t, tbl, "parent_span_id").IsNull(0), "Must store empty ids as null")
+
+	attrs := column(t, tbl, "attributes").(*array.Map)
+	assert.Equal(t, "http.status_code", attrs.Keys().(*array.String).Value(0))
+	assert.Equal(t, "500", attrs.Items().(*array.String).Value(0))
+
+	resAttrs := column(t, tbl, "resource_attributes").(*array.Map)
+	assert.Equal(t, "checkout", resAttrs.Items().(*array.String).Value(0))
+	assert.Equal(t, "otelhttp", column(t, tbl, "scope_name").(*array.String).Value(1))
+
+	events := column(t, tbl, "events").(*array.List)
+	start, end := events.ValueOffsets(0)
+	assert.EqualValues(t, 1, end-start, "Must store the span events")
+	eventNames := events.ListValues().(*array.Struct).Field(1).(*array.String)
+	assert.Equal(t, "exception", eventNames.Value(0))
+	start, end = events.ValueOffsets(1)
+	assert.EqualValues(t, 0, end-start, "Must store spans without events")
+
+	links := column(t, tbl, "links").(*array.List)
+	linkTraceIDs := links.ListValues().(*array.Struct).Field(0).(*array.String)
+	assert.Equal(t, "100f0e0d0c0b0a090807060504030201", linkTraceIDs.Value(0))
+}
+
+func TestExportMetrics(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Compression = compressionZstd
+	exp := newParquetExporter(cfg, signalMetrics, zap.NewNop())
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().DataPoints().AppendEmpty().SetIntValue(42)
+
+	hist := sm.Metrics().AppendEmpty()
+	hist.SetName("latency")
+	hist.SetUnit("ms")
+	dp := hist.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(3)
+	dp.SetSum(12.5)
+	dp.BucketCounts().FromRaw([]uint64{1, 2, 0})
+	dp.ExplicitBounds().FromRaw([]float64{5, 10})
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("gc")
+	q := summary.SetEmptySummary().DataPoints().AppendEmpty().QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(7)
+
+	require.NoError(t, exp.consumeMetrics(context.Background(), md))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	tbl := readTable(t, cfg.Path, signalMetrics)
+	assert.EqualValues(t, 3, tbl.NumRows())
+
+	types := column(t, tbl, "metric_type").(*array.String)
+	assert.Equal(t, "Sum", types.Value(0))
+	assert.Equal(t, "Histogram", types.Value(1))
+	assert.Equal(t, "Summary", types.Value(2))
+
+	assert.EqualValues(t, 42, column(t, tbl, "value_int").(*array.Int64).Value(0))
+	assert.True(t, column(t, tbl, "value_double").IsNull(0))
+	assert.True(t, column(t, tbl, "is_monotonic").(*array.Boolean).Value(0))
+	assert.Equal(t, "Cumulative", column(t, tbl, "aggregation_temporality").(*array.String).Value(0))
+
+	assert.EqualValues(t, 3, column(t, tbl, "count").(*array.Uint64).Value(1))
+	assert.Equal(t, 12.5, column(t, tbl, "sum").(*array.Float64).Value(1))
+	buckets := column(t, tbl, "bucket_counts").(*array.List)
+	assert.True(t, buckets.IsNull(0))
+	start, end := buckets.ValueOffsets(1)
+	assert.Equal(t, []uint64{1, 2, 0}, buckets.ListValues().(*array.Uint64).Uint64Values()[start:end])
+
+	quantiles := column(t, tbl, "quantile_values").(*array.List)
+	assert.Equal(t, 0.99, quantiles.ListValues().(*array.Struct).Field(0).(*array.Float64).Value(0))
+}
+
+func TestExportLogs(t *testing.T) {
+	cfg := newTestConfig(t)
+	exp := newParquetExporter(cfg, signalLogs, zap.NewNop())
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(100, 0)))
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText("ERROR")
+	lr.Body().SetStr("connection refused")
+	lr.Attributes().PutBool("retry", true)
+
+	require.NoError(t, exp.consumeLogs(context.Background(), ld))
+	require.NoError(t, exp.consumeLogs(context.Background(), plog.NewLogs()))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	tbl := readTable(t, cfg.Path, signalLogs)
+	assert.EqualValues(t, 1, tbl.NumRows())
+	assert.Equal(t, "connection refused", column(t, tbl, "body").(*array.String).Value(0))
+	assert.EqualValues(t, plog.SeverityNumberError, column(t, tbl, "severity_number").(*array.Int32).Value(0))
+	assert.Equal(t, arrow.Timestamp(100*time.Second), column(t, tbl, "time").(*array.Timestamp).Value(0))
+	assert.True(t, column(t, tbl, "trace_id").IsNull(0))
+}
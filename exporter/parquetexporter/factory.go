@@ -16,6 +16,7 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -27,14 +28,12 @@ const (
 	typeStr = "parquet"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelInDevelopment
-)
 
-type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
-	Path                    string `mapstructure:"path"`
-}
+	defaultRowGroupSize = 64 * 1024
+	defaultMaxMegabytes = 128
+	defaultInterval     = 15 * time.Minute
+)
 
-// NewFactory creates a factory for the Parquet exporter.
 func NewFactory() component.ExporterFactory {
 	return component.NewExporterFactory(
 		typeStr,
@@ -47,6 +46,12 @@ func NewFactory() component.ExporterFactory {
 func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		Compression:      compressionSnappy,
+		RowGroupSize:     defaultRowGroupSize,
+		Rotation: Rotation{
+			MaxMegabytes: defaultMaxMegabytes,
+			Interval:     defaultInterval,
+		},
 	}
 }
 
@@ -55,7 +60,7 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), signalTraces, set.Logger)
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -71,7 +76,7 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), signalMetrics, set.Logger)
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -87,7 +92,7 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), signalLogs, set.Logger)
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
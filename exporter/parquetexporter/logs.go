@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var logsSchema = arrow.NewSchema(append([]arrow.Field{
+	{Name: "time", Type: timestampType, Nullable: true},
+	{Name: "observed_time", Type: timestampType, Nullable: true},
+	{Name: "severity_number", Type: arrow.PrimitiveTypes.Int32},
+	{Name: "severity_text", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "body", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "attributes", Type: attributesType},
+	{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+	{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+	{Name: "trace_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+}, resourceFields...), nil)
+
+// logsToRecord converts the logs into a record with a row per log record.
+func logsToRecord(mem memory.Allocator, ld plog.Logs) arrow.Record {
+	b := array.NewRecordBuilder(mem, logsSchema)
+	defer b.Release()
+
+	var (
+		rb             = newRecordBuilder(b, logsSchema)
+		resource       = newResourceColumns(rb)
+		ts             = rb.timestamp("time")
+		observed       = rb.timestamp("observed_time")
+		severityNumber = rb.field("severity_number").(*array.Int32Builder)
+		severityText   = rb.str("severity_text")
+		body           = rb.str("body")
+		attributes     = rb.attributes("attributes")
+		droppedAttrs   = rb.field("dropped_attributes_count").(*array.Uint32Builder)
+		flags          = rb.field("flags").(*array.Uint32Builder)
+		traceID        = rb.str("trace_id")
+		spanID         = rb.str("span_id")
+	)
+
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+
+				resource.append(rl.Resource(), rl.SchemaUrl(), sl.Scope())
+				appendTimestamp(ts, lr.Timestamp())
+				appendTimestamp(observed, lr.ObservedTimestamp())
+				severityNumber.Append(int32(lr.SeverityNumber()))
+				appendString(severityText, lr.SeverityText())
+				appendString(body, lr.Body().AsString())
+				appendAttributes(attributes, lr.Attributes())
+				droppedAttrs.Append(lr.DroppedAttributesCount())
+				flags.Append(uint32(lr.Flags()))
+				appendString(traceID, lr.TraceID().HexString())
+				appendString(spanID, lr.SpanID().HexString())
+			}
+		}
+	}
+
+	return b.NewRecord()
+}
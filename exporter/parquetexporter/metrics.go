@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var (
+	quantileType = arrow.StructOf(
+		arrow.Field{Name: "quantile", Type: arrow.PrimitiveTypes.Float64},
+		arrow.Field{Name: "value", Type: arrow.PrimitiveTypes.Float64},
+	)
+
+	// metricsSchema contains a row per data point, the columns
+	// that do not apply to the metric type are left empty.
+	metricsSchema = arrow.NewSchema(append([]arrow.Field{
+		{Name: "metric_name", Type: arrow.BinaryTypes.String},
+		{Name: "metric_description", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "metric_unit", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "metric_type", Type: arrow.BinaryTypes.String},
+		{Name: "aggregation_temporality", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "is_monotonic", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "attributes", Type: attributesType},
+		{Name: "start_time", Type: timestampType, Nullable: true},
+		{Name: "time", Type: timestampType, Nullable: true},
+		{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+		{Name: "value_double", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "value_int", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		{Name: "sum", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "min", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "max", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+		{Name: "explicit_bounds", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64), Nullable: true},
+		{Name: "scale", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "zero_count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		{Name: "positive_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "positive_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+		{Name: "negative_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "negative_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+		{Name: "quantile_values", Type: arrow.ListOf(quantileType), Nullable: true},
+	}, resourceFields...), nil)
+)
+
+// metricsColumns contains the builders for all the metric columns.
+type metricsColumns struct {
+	resource             resourceColumns
+	name                 *array.StringBuilder
+	description          *array.StringBuilder
+	unit                 *array.StringBuilder
+	metricType           *array.StringBuilder
+	temporality          *array.StringBuilder
+	monotonic            *array.BooleanBuilder
+	attributes           *array.MapBuilder
+	startTime            *array.TimestampBuilder
+	time                 *array.TimestampBuilder
+	flags                *array.Uint32Builder
+	valueDouble          *array.Float64Builder
+	valueInt             *array.Int64Builder
+	count                *array.Uint64Builder
+	sum                  *array.Float64Builder
+	min                  *array.Float64Builder
+	max                  *array.Float64Builder
+	bucketCounts         *array.ListBuilder
+	explicitBounds       *array.ListBuilder
+	scale                *array.Int32Builder
+	zeroCount            *array.Uint64Builder
+	positiveOffset       *array.Int32Builder
+	positiveBucketCounts *array.ListBuilder
+	negativeOffset       *array.Int32Builder
+	negativeBucketCounts *array.ListBuilder
+	quantileValues       *array.ListBuilder
+}
+
+func newMetricsColumns(rb recordBuilder) *metricsColumns {
+	return &metricsColumns{
+		resource:             newResourceColumns(rb),
+		name:                 rb.str("metric_name"),
+		description:          rb.str("metric_description"),
+		unit:                 rb.str("metric_unit"),
+		metricType:           rb.str("metric_type"),
+		temporality:          rb.str("aggregation_temporality"),
+		monotonic:            rb.field("is_monotonic").(*array.BooleanBuilder),
+		attributes:           rb.attributes("attributes"),
+		startTime:            rb.timestamp("start_time"),
+		time:                 rb.timestamp("time"),
+		flags:                rb.field("flags").(*array.Uint32Builder),
+		valueDouble:          rb.field("value_double").(*array.Float64Builder),
+		valueInt:             rb.field("value_int").(*array.Int64Builder),
+		count:                rb.field("count").(*array.Uint64Builder),
+		sum:                  rb.field("sum").(*array.Float64Builder),
+		min:                  rb.field("min").(*array.Float64Builder),
+		max:                  rb.field("max").(*array.Float64Builder),
+		bucketCounts:         rb.field("bucket_counts").(*array.ListBuilder),
+		explicitBounds:       rb.field("explicit_bounds").(*array.ListBuilder),
+		scale:                rb.field("scale").(*array.Int32Builder),
+		zeroCount:            rb.field("zero_count").(*array.Uint64Builder),
+		positiveOffset:       rb.field("positive_offset").(*array.Int32Builder),
+		positiveBucketCounts: rb.field("positive_bucket_counts").(*array.ListBuilder),
+		negativeOffset:       rb.field("negative_offset").(*array.Int32Builder),
+		negativeBucketCounts: rb.field("negative_bucket_counts").(*array.ListBuilder),
+		quantileValues:       rb.field("quantile_values").(*array.ListBuilder),
+	}
+}
+
+// metricsToRecord converts the metrics into a record with a row per data point.
+func metricsToRecord(mem memory.Allocator, md pmetric.Metrics) arrow.Record {
+	b := array.NewRecordBuilder(mem, metricsSchema)
+	defer b.Release()
+
+	cols := newMetricsColumns(newRecordBuilder(b, metricsSchema))
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				cols.appendMetric(rm, sm.Scope(), sm.Metrics().At(k))
+			}
+		}
+	}
+
+	return b.NewRecord()
+}
+
+func (mc *metricsColumns) appendMetric(rm pmetric.ResourceMetrics, scope pcommon.InstrumentationScope, metric pmetric.Metric) {
+	// common appends the columns that are shared by all data points
+	// and leaves the columns of other metric types empty.
+	common := func(attrs pcommon.Map, start, ts pcommon.Timestamp, flags pmetric.DataPointFlags) {
+		mc.resource.append(rm.Resource(), rm.SchemaUrl(), scope)
+		mc.name.Append(metric.Name())
+		appendString(mc.description, metric.Description())
+		appendString(mc.unit, metric.Unit())
+		mc.metricType.Append(metric.Type().String())
+		appendAttributes(mc.attributes, attrs)
+		appendTimestamp(mc.startTime, start)
+		appendTimestamp(mc.time, ts)
+		mc.flags.Append(uint32(flags))
+	}
+
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			mc.temporality.AppendNull()
+			mc.monotonic.AppendNull()
+			mc.appendNumberValue(dp)
+			mc.appendEmptyHistogram()
+			mc.appendEmptyExponentialHistogram()
+			mc.appendEmptySummary()
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			mc.temporality.Append(metric.Sum().AggregationTemporality().String())
+			mc.monotonic.Append(metric.Sum().IsMonotonic())
+			mc.appendNumberValue(dp)
+			mc.appendEmptyHistogram()
+			mc.appendEmptyExponentialHistogram()
+			mc.appendEmptySummary()
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			mc.temporality.Append(metric.Histogram().AggregationTemporality().String())
+			mc.monotonic.AppendNull()
+			mc.appendEmptyNumberValue()
+			mc.appendHistogram(dp.Count(), dp.Sum(), dp.HasSum(), dp.Min(), dp.HasMin(), dp.Max(), dp.HasMax())
+			appendUint64List(mc.bucketCounts, dp.BucketCounts().AsRaw())
+			appendFloat64List(mc.explicitBounds, dp.ExplicitBounds().AsRaw())
+			mc.appendEmptyExponentialHistogram()
+			mc.appendEmptySummary()
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			mc.temporality.Append(metric.ExponentialHistogram().AggregationTemporality().String())
+			mc.monotonic.AppendNull()
+			mc.appendEmptyNumberValue()
+			mc.appendHistogram(dp.Count(), dp.Sum(), dp.HasSum(), dp.Min(), dp.HasMin(), dp.Max(), dp.HasMax())
+			mc.bucketCounts.AppendNull()
+			mc.explicitBounds.AppendNull()
+			mc.scale.Append(dp.Scale())
+			mc.zeroCount.Append(dp.ZeroCount())
+			mc.positiveOffset.Append(dp.Positive().Offset())
+			appendUint64List(mc.positiveBucketCounts, dp.Positive().BucketCounts().AsRaw())
+			mc.negativeOffset.Append(dp.Negative().Offset())
+			appendUint64List(mc.negativeBucketCounts, dp.Negative().BucketCounts().AsRaw())
+			mc.appendEmptySummary()
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+			mc.temporality.AppendNull()
+			mc.monotonic.AppendNull()
+			mc.appendEmptyNumberValue()
+			mc.appendHistogram(dp.Count(), dp.Sum(), true, 0, false, 0, false)
+			mc.bucketCounts.AppendNull()
+			mc.explicitBounds.AppendNull()
+			mc.appendEmptyExponentialHistogram()
+			mc.appendQuantiles(dp.QuantileValues())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+func (mc *metricsColumns) appendNumberValue(dp pmetric.NumberDataPoint) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		mc.valueDouble.Append(dp.DoubleValue())
+		mc.valueInt.AppendNull()
+	case pmetric.NumberDataPointValueTypeInt:
+		mc.valueDouble.AppendNull()
+		mc.valueInt.Append(dp.IntValue())
+	default:
+		mc.appendEmptyNumberValue()
+	}
+	mc.count.AppendNull()
+	mc.sum.AppendNull()
+	mc.min.AppendNull()
+	mc.max.AppendNull()
+}
+
+func (mc *metricsColumns) appendEmptyNumberValue() {
+	mc.valueDouble.AppendNull()
+	mc.valueInt.AppendNull()
+}
+
+func (mc *metricsColumns) appendHistogram(count uint64, sum float64, hasSum bool, min float64, hasMin bool, max float64, hasMax bool) {
+	mc.count.Append(count)
+	appendOptionalFloat64(mc.sum, sum, hasSum)
+	appendOptionalFloat64(mc.min, min, hasMin)
+	appendOptionalFloat64(mc.max, max, hasMax)
+}
+
+func (mc *metricsColumns) appendEmptyHistogram() {
+	mc.bucketCounts.AppendNull()
+	mc.explicitBounds.AppendNull()
+}
+
+func (mc *metricsColumns) appendEmptyExponentialHistogram() {
+	mc.scale.AppendNull()
+	mc.zeroCount.AppendNull()
+	mc.positiveOffset.AppendNull()
+	mc.positiveBucketCounts.AppendNull()
+	mc.negativeOffset.AppendNull()
+	mc.negativeBucketCounts.AppendNull()
+}
+
+func (mc *metricsColumns) appendEmptySummary() {
+	mc.quantileValues.AppendNull()
+}
+
+func (mc *metricsColumns) appendQuantiles(quantiles pmetric.SummaryDataPointValueAtQuantileSlice) {
+	mc.quantileValues.Append(true)
+	var (
+		vb       = mc.quantileValues.ValueBuilder().(*array.StructBuilder)
+		quantile = vb.FieldBuilder(0).(*array.Float64Builder)
+		value    = vb.FieldBuilder(1).(*array.Float64Builder)
+	)
+	for i := 0; i < quantiles.Len(); i++ {
+		vb.Append(true)
+		quantile.Append(quantiles.At(i).Quantile())
+		value.Append(quantiles.At(i).Value())
+	}
+}
+
+func appendOptionalFloat64(b *array.Float64Builder, v float64, ok bool) {
+	if !ok {
+		b.AppendNull()
+		return
+	}
+	b.Append(v)
+}
+
+func appendUint64List(b *array.ListBuilder, values []uint64) {
+	b.Append(true)
+	b.ValueBuilder().(*array.Uint64Builder).AppendValues(values, nil)
+}
+
+func appendFloat64List(b *array.ListBuilder, values []float64) {
+	b.Append(true)
+	b.ValueBuilder().(*array.Float64Builder).AppendValues(values, nil)
+}
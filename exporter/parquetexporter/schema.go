@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var (
+	// attributesType stores attributes as a map of strings, values that
+	// are not strings are converted using their string representation.
+	attributesType = arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)
+	timestampType  = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+)
+
+// resourceFields are shared by all signals so that every row
+// contains the resource and scope it was produced by.
+var resourceFields = []arrow.Field{
+	{Name: "resource_attributes", Type: attributesType},
+	{Name: "resource_schema_url", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "scope_name", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "scope_version", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "scope_attributes", Type: attributesType},
+}
+
+// recordBuilder wraps the arrow record builder to allow
+// looking up the field builders by their column name.
+type recordBuilder struct {
+	*array.RecordBuilder
+	schema *arrow.Schema
+}
+
+func newRecordBuilder(b *array.RecordBuilder, schema *arrow.Schema) recordBuilder {
+	for _, fb := range b.Fields() {
+		reserve(fb)
+	}
+	return recordBuilder{RecordBuilder: b, schema: schema}
+}
+
+// reserve allocates the buffers of the builder and all of its nested builders
+// since the parquet writer is unable to write a nested column that does not
+// have allocated buffers, which happens when every list in a batch is empty.
+func reserve(b array.Builder) {
+	b.Reserve(1)
+	switch b := b.(type) {
+	case *array.ListBuilder:
+		reserve(b.ValueBuilder())
+	case *array.MapBuilder:
+		reserve(b.KeyBuilder())
+		reserve(b.ItemBuilder())
+	case *array.StructBuilder:
+		for i := 0; i < b.NumField(); i++ {
+			reserve(b.FieldBuilder(i))
+		}
+	}
+}
+
+func (rb recordBuilder) field(name string) array.Builder {
+	return rb.Field(rb.schema.FieldIndices(name)[0])
+}
+
+func (rb recordBuilder) str(name string) *array.StringBuilder {
+	return rb.field(name).(*array.StringBuilder)
+}
+
+func (rb recordBuilder) timestamp(name string) *array.TimestampBuilder {
+	return rb.field(name).(*array.TimestampBuilder)
+}
+
+func (rb recordBuilder) attributes(name string) *array.MapBuilder {
+	return rb.field(name).(*array.MapBuilder)
+}
+
+// resourceColumns contains the builders for the resource and scope columns.
+type resourceColumns struct {
+	attributes      *array.MapBuilder
+	schemaURL       *array.StringBuilder
+	scopeName       *array.StringBuilder
+	scopeVersion    *array.StringBuilder
+	scopeAttributes *array.MapBuilder
+}
+
+func newResourceColumns(rb recordBuilder) resourceColumns {
+	return resourceColumns{
+		attributes:      rb.attributes("resource_attributes"),
+		schemaURL:       rb.str("resource_schema_url"),
+		scopeName:       rb.str("scope_name"),
+		scopeVersion:    rb.str("scope_version"),
+		scopeAttributes: rb.attributes("scope_attributes"),
+	}
+}
+
+func (rc resourceColumns) append(res pcommon.Resource, schemaURL string, scope pcommon.InstrumentationScope) {
+	appendAttributes(rc.attributes, res.Attributes())
+	appendString(rc.schemaURL, schemaURL)
+	appendString(rc.scopeName, scope.Name())
+	appendString(rc.scopeVersion, scope.Version())
+	appendAttributes(rc.scopeAttributes, scope.Attributes())
+}
+
+func appendAttributes(b *array.MapBuilder, attrs pcommon.Map) {
+	b.Append(true)
+	keys := b.KeyBuilder().(*array.StringBuilder)
+	items := b.ItemBuilder().(*array.StringBuilder)
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		keys.Append(k)
+		items.Append(v.AsString())
+		return true
+	})
+}
+
+// appendString appends a null value for empty strings.
+func appendString(b *array.StringBuilder, s string) {
+	if s == "" {
+		b.AppendNull()
+		return
+	}
+	b.Append(s)
+}
+
+// appendTimestamp appends a null value for unset timestamps.
+func appendTimestamp(b *array.TimestampBuilder, ts pcommon.Timestamp) {
+	if ts == 0 {
+		b.AppendNull()
+		return
+	}
+	b.Append(arrow.Timestamp(ts))
+}
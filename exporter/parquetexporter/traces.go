@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	spanEventType = arrow.StructOf(
+		arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "attributes", Type: attributesType},
+	)
+	spanLinkType = arrow.StructOf(
+		arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "attributes", Type: attributesType},
+	)
+
+	tracesSchema = arrow.NewSchema(append([]arrow.Field{
+		{Name: "trace_id", Type: arrow.BinaryTypes.String},
+		{Name: "span_id", Type: arrow.BinaryTypes.String},
+		{Name: "parent_span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "trace_state", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "kind", Type: arrow.BinaryTypes.String},
+		{Name: "start_time", Type: timestampType, Nullable: true},
+		{Name: "end_time", Type: timestampType, Nullable: true},
+		{Name: "duration_ns", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "status_code", Type: arrow.BinaryTypes.String},
+		{Name: "status_message", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "attributes", Type: attributesType},
+		{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+		{Name: "events", Type: arrow.ListOf(spanEventType)},
+		{Name: "dropped_events_count", Type: arrow.PrimitiveTypes.Uint32},
+		{Name: "links", Type: arrow.ListOf(spanLinkType)},
+		{Name: "dropped_links_count", Type: arrow.PrimitiveTypes.Uint32},
+	}, resourceFields...), nil)
+)
+
+// tracesToRecord converts the traces into a record with a row per span.
+func tracesToRecord(mem memory.Allocator, td ptrace.Traces) arrow.Record {
+	b := array.NewRecordBuilder(mem, tracesSchema)
+	defer b.Release()
+
+	var (
+		rb            = newRecordBuilder(b, tracesSchema)
+		resource      = newResourceColumns(rb)
+		traceID       = rb.str("trace_id")
+		spanID        = rb.str("span_id")
+		parentSpanID  = rb.str("parent_span_id")
+		traceState    = rb.str("trace_state")
+		name          = rb.str("name")
+		kind          = rb.str("kind")
+		startTime     = rb.timestamp("start_time")
+		endTime       = rb.timestamp("end_time")
+		duration      = rb.field("duration_ns").(*array.Int64Builder)
+		statusCode    = rb.str("status_code")
+		statusMessage = rb.str("status_message")
+		attributes    = rb.attributes("attributes")
+		droppedAttrs  = rb.field("dropped_attributes_count").(*array.Uint32Builder)
+		events        = rb.field("events").(*array.ListBuilder)
+		droppedEvents = rb.field("dropped_events_count").(*array.Uint32Builder)
+		links         = rb.field("links").(*array.ListBuilder)
+		droppedLinks  = rb.field("dropped_links_count").(*array.Uint32Builder)
+	)
+
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+
+				resource.append(rs.Resource(), rs.SchemaUrl(), ss.Scope())
+				traceID.Append(span.TraceID().HexString())
+				spanID.Append(span.SpanID().HexString())
+				appendString(parentSpanID, span.ParentSpanID().HexString())
+				appendString(traceState, span.TraceState().AsRaw())
+				name.Append(span.Name())
+				kind.Append(span.Kind().String())
+				appendTimestamp(startTime, span.StartTimestamp())
+				appendTimestamp(endTime, span.EndTimestamp())
+				duration.Append(int64(span.EndTimestamp()) - int64(span.StartTimestamp()))
+				statusCode.Append(span.Status().Code().String())
+				appendString(statusMessage, span.Status().Message())
+				appendAttributes(attributes, span.Attributes())
+				droppedAttrs.Append(span.DroppedAttributesCount())
+				appendSpanEvents(events, span.Events())
+				droppedEvents.Append(span.DroppedEventsCount())
+				appendSpanLinks(links, span.Links())
+				droppedLinks.Append(span.DroppedLinksCount())
+			}
+		}
+	}
+
+	return b.NewRecord()
+}
+
+func appendSpanEvents(b *array.ListBuilder, events ptrace.SpanEventSlice) {
+	b.Append(true)
+	var (
+		vb         = b.ValueBuilder().(*array.StructBuilder)
+		ts         = vb.FieldBuilder(0).(*array.TimestampBuilder)
+		name       = vb.FieldBuilder(1).(*array.StringBuilder)
+		attributes = vb.FieldBuilder(2).(*array.MapBuilder)
+	)
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		vb.Append(true)
+		appendTimestamp(ts, event.Timestamp())
+		name.Append(event.Name())
+		appendAttributes(attributes, event.Attributes())
+	}
+}
+
+func appendSpanLinks(b *array.ListBuilder, links ptrace.SpanLinkSlice) {
+	b.Append(true)
+	var (
+		vb         = b.ValueBuilder().(*array.StructBuilder)
+		traceID    = vb.FieldBuilder(0).(*array.StringBuilder)
+		spanID     = vb.FieldBuilder(1).(*array.StringBuilder)
+		traceState = vb.FieldBuilder(2).(*array.StringBuilder)
+		attributes = vb.FieldBuilder(3).(*array.MapBuilder)
+	)
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		vb.Append(true)
+		traceID.Append(link.TraceID().HexString())
+		spanID.Append(link.SpanID().HexString())
+		appendString(traceState, link.TraceState().AsRaw())
+		appendAttributes(attributes, link.Attributes())
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/parquet"
+	"github.com/apache/arrow/go/v10/parquet/pqarrow"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	fileExtension = ".parquet"
+	// inProgressPrefix hides the files that are still being written from
+	// query engines such as Spark and DuckDB since they ignore hidden files.
+	inProgressPrefix = "."
+	inProgressSuffix = ".inprogress"
+)
+
+// countingWriter tracks the number of bytes that have been flushed to the file.
+type countingWriter struct {
+	file    *os.File
+	written int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.file.Write(p)
+	cw.written += int64(n)
+	return n, err
+}
+
+// partFile is a single Parquet file within a partition that is still being written.
+type partFile struct {
+	counter  *countingWriter
+	writer   *pqarrow.FileWriter
+	tmpPath  string
+	path     string
+	openedAt time.Time
+}
+
+// size returns the approximate size of the file including
+// the compressed data that is buffered for the current row group.
+func (pf *partFile) size() int64 {
+	return pf.counter.written + pf.writer.RowGroupTotalCompressedBytes()
+}
+
+func (pf *partFile) close() error {
+	// The counting writer does not implement io.Closer
+	// so the file is left open by the parquet writer.
+	err := multierr.Append(pf.writer.Close(), pf.counter.file.Close())
+	if err != nil {
+		return err
+	}
+	return os.Rename(pf.tmpPath, pf.path)
+}
+
+// fileWriter writes arrow records for a single signal into
+// a partitioned directory layout, rotating the files based on
+// their size, the time they have been open and the hour partition.
+type fileWriter struct {
+	root     string
+	signal   string
+	schema   *arrow.Schema
+	props    *parquet.WriterProperties
+	maxBytes int64
+	interval time.Duration
+	logger   *zap.Logger
+	now      func() time.Time
+
+	mu      sync.Mutex
+	current *partFile
+	timer   *time.Timer
+}
+
+func newFileWriter(cfg *Config, signal string, schema *arrow.Schema, logger *zap.Logger) *fileWriter {
+	return &fileWriter{
+		root:   cfg.Path,
+		signal: signal,
+		schema: schema,
+		props: parquet.NewWriterProperties(
+			parquet.WithCompression(codecs[cfg.Compression]),
+			parquet.WithMaxRowGroupLength(cfg.RowGroupSize),
+			parquet.WithCreatedBy("opentelemetry-collector-contrib"),
+		),
+		maxBytes: int64(cfg.Rotation.MaxMegabytes) << 20,
+		interval: cfg.Rotation.Interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// write appends the record to the current file, and rotates
+// the file once it has reached the configured size.
+func (fw *fileWriter) write(rec arrow.Record) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	now := fw.now().UTC()
+	if fw.current != nil && !sameHour(fw.current.openedAt, now) {
+		if err := fw.rotate(); err != nil {
+			return err
+		}
+	}
+	if fw.current == nil {
+		if err := fw.open(now); err != nil {
+			return err
+		}
+	}
+	if err := fw.current.writer.WriteBuffered(rec); err != nil {
+		return err
+	}
+	if fw.current.size() >= fw.maxBytes {
+		return fw.rotate()
+	}
+	return nil
+}
+
+// close flushes and closes the current file.
+func (fw *fileWriter) close() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return fw.rotate()
+}
+
+func (fw *fileWriter) open(now time.Time) error {
+	dir := filepath.Join(
+		fw.root,
+		"signal="+fw.signal,
+		"date="+now.Format("2006-01-02"),
+		"hour="+now.Format("15"),
+	)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("part-%d-%s%s", now.UnixNano(), hex.EncodeToString(suffix), fileExtension)
+	pf := &partFile{
+		tmpPath:  filepath.Join(dir, inProgressPrefix+name+inProgressSuffix),
+		path:     filepath.Join(dir, name),
+		openedAt: now,
+	}
+	f, err := os.OpenFile(pf.tmpPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	pf.counter = &countingWriter{file: f}
+	pf.writer, err = pqarrow.NewFileWriter(fw.schema, pf.counter, fw.props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return multierr.Append(err, multierr.Append(f.Close(), os.Remove(pf.tmpPath)))
+	}
+	fw.current = pf
+
+	// The file is closed once the interval has passed or when
+	// the hour partition ends, whichever happens first, so that
+	// idle pipelines still make the data available to readers.
+	wait := fw.interval
+	if untilNextHour := now.Truncate(time.Hour).Add(time.Hour).Sub(now); untilNextHour < wait {
+		wait = untilNextHour
+	}
+	fw.timer = time.AfterFunc(wait, func() {
+		fw.mu.Lock()
+		defer fw.mu.Unlock()
+		if fw.current != pf {
+			return
+		}
+		if err := fw.rotate(); err != nil {
+			fw.logger.Error("Failed to rotate parquet file", zap.String("path", pf.path), zap.Error(err))
+		}
+	})
+	return nil
+}
+
+// rotate closes the current file, if any, so that the next write opens a new file.
+// The caller must hold the lock.
+func (fw *fileWriter) rotate() error {
+	if fw.timer != nil {
+		fw.timer.Stop()
+		fw.timer = nil
+	}
+	if fw.current == nil {
+		return nil
+	}
+	pf := fw.current
+	fw.current = nil
+	return pf.close()
+}
+
+func sameHour(a, b time.Time) bool {
+	return a.Truncate(time.Hour).Equal(b.Truncate(time.Hour))
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func newTestLogs() plog.Logs {
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("hello world")
+	return ld
+}
+
+func writeTestLogs(t *testing.T, fw *fileWriter) {
+	rec := logsToRecord(memory.DefaultAllocator, newTestLogs())
+	defer rec.Release()
+	require.NoError(t, fw.write(rec))
+}
+
+func listFiles(t *testing.T, pattern string) []string {
+	paths, err := filepath.Glob(pattern)
+	require.NoError(t, err)
+	return paths
+}
+
+func TestFileWriterPartitions(t *testing.T) {
+	cfg := newTestConfig(t)
+	fw := newFileWriter(cfg, signalLogs, logsSchema, zap.NewNop())
+
+	now := time.Date(2022, 11, 7, 13, 59, 0, 0, time.UTC)
+	fw.now = func() time.Time { return now }
+
+	writeTestLogs(t, fw)
+	inProgress := listFiles(t, filepath.Join(cfg.Path, "signal=logs", "date=2022-11-07", "hour=13", inProgressPrefix+"*"+inProgressSuffix))
+	assert.Len(t, inProgress, 1, "Must write to a hidden file while the file is open")
+
+	now = now.Add(2 * time.Minute)
+	writeTestLogs(t, fw)
+	require.NoError(t, fw.close())
+
+	assert.Len(t, listFiles(t, filepath.Join(cfg.Path, "signal=logs", "date=2022-11-07", "hour=13", "*"+fileExtension)), 1)
+	assert.Len(t, listFiles(t, filepath.Join(cfg.Path, "signal=logs", "date=2022-11-07", "hour=14", "*"+fileExtension)), 1)
+	assert.Empty(t, listFiles(t, filepath.Join(cfg.Path, "signal=logs", "*", "*", inProgressPrefix+"*")), "Must not leave in progress files")
+}
+
+func TestFileWriterRotateOnSize(t *testing.T) {
+	cfg := newTestConfig(t)
+	fw := newFileWriter(cfg, signalLogs, logsSchema, zap.NewNop())
+	fw.maxBytes = 1
+
+	writeTestLogs(t, fw)
+	writeTestLogs(t, fw)
+	require.NoError(t, fw.close())
+
+	files := listFiles(t, filepath.Join(cfg.Path, "signal=logs", "*", "*", "*"+fileExtension))
+	assert.Len(t, files, 2, "Must rotate the file once it reaches the max size")
+	for _, f := range files {
+		info, err := os.Stat(f)
+		require.NoError(t, err)
+		assert.NotZero(t, info.Size())
+	}
+}
+
+func TestFileWriterRotateOnInterval(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Rotation.Interval = 10 * time.Millisecond
+	fw := newFileWriter(cfg, signalLogs, logsSchema, zap.NewNop())
+
+	writeTestLogs(t, fw)
+	assert.Eventually(t, func() bool {
+		return len(listFiles(t, filepath.Join(cfg.Path, "signal=logs", "*", "*", "*"+fileExtension))) == 1
+	}, time.Second, 10*time.Millisecond, "Must close the file once the interval has passed")
+
+	writeTestLogs(t, fw)
+	require.NoError(t, fw.close())
+	assert.Len(t, listFiles(t, filepath.Join(cfg.Path, "signal=logs", "*", "*", "*"+fileExtension)), 2)
+}
@@ -19,6 +19,7 @@ import (
 	"sort"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -40,18 +41,20 @@ type collector struct {
 	accumulator accumulator
 	logger      *zap.Logger
 
-	sendTimestamps bool
-	namespace      string
-	constLabels    prometheus.Labels
+	sendTimestamps           bool
+	namespace                string
+	constLabels              prometheus.Labels
+	downconvertExpHistograms bool
 }
 
 func newCollector(config *Config, logger *zap.Logger) *collector {
 	return &collector{
-		accumulator:    newAccumulator(logger, config.MetricExpiration),
-		logger:         logger,
-		namespace:      prometheustranslator.CleanUpString(config.Namespace),
-		sendTimestamps: config.SendTimestamps,
-		constLabels:    config.ConstLabels,
+		accumulator:              newAccumulator(logger, config.MetricExpiration),
+		logger:                   logger,
+		namespace:                prometheustranslator.CleanUpString(config.Namespace),
+		sendTimestamps:           config.SendTimestamps,
+		constLabels:              config.ConstLabels,
+		downconvertExpHistograms: config.DownconvertExponentialHistograms,
 	}
 }
 
@@ -76,6 +79,8 @@ func (c *collector) convertMetric(metric pmetric.Metric, resourceAttrs pcommon.M
 		return c.convertSum(metric, resourceAttrs)
 	case pmetric.MetricTypeHistogram:
 		return c.convertDoubleHistogram(metric, resourceAttrs)
+	case pmetric.MetricTypeExponentialHistogram:
+		return c.convertExponentialHistogram(metric, resourceAttrs)
 	case pmetric.MetricTypeSummary:
 		return c.convertSummary(metric, resourceAttrs)
 	}
@@ -257,6 +262,74 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 	return m, nil
 }
 
+func (c *collector) convertExponentialHistogram(metric pmetric.Metric, resourceAttrs pcommon.Map) (prometheus.Metric, error) {
+	ip := metric.ExponentialHistogram().DataPoints().At(0)
+
+	if c.downconvertExpHistograms {
+		explicit := copyMetricMetadata(metric)
+		explicit.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		prometheustranslator.ExponentialToExplicitHistogram(ip, explicit.Histogram().DataPoints().AppendEmpty())
+		return c.convertDoubleHistogram(explicit, resourceAttrs)
+	}
+
+	h, err := prometheustranslator.ToNativeHistogram(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, attributes := c.getMetricMetadata(metric, ip.Attributes(), resourceAttrs)
+	m, err := prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), nil, attributes...)
+	if err != nil {
+		return nil, err
+	}
+
+	var nm prometheus.Metric = &nativeHistogramMetric{Metric: m, histogram: h}
+	if c.sendTimestamps {
+		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), nm), nil
+	}
+	return nm, nil
+}
+
+// nativeHistogramMetric adds the sparse buckets of a native histogram to a histogram without
+// classic buckets. They are only part of the protobuf exposition format; the text formats
+// expose the count and sum.
+type nativeHistogramMetric struct {
+	prometheus.Metric
+	histogram prometheustranslator.NativeHistogram
+}
+
+func (m *nativeHistogramMetric) Write(pb *dto.Metric) error {
+	if err := m.Metric.Write(pb); err != nil {
+		return err
+	}
+
+	h := m.histogram
+	pb.Histogram.Schema = &h.Schema
+	pb.Histogram.ZeroThreshold = &h.ZeroThreshold
+	pb.Histogram.ZeroCount = &h.ZeroCount
+	pb.Histogram.PositiveSpan = toDtoBucketSpans(h.PositiveSpans)
+	pb.Histogram.PositiveDelta = h.PositiveDeltas
+	pb.Histogram.NegativeSpan = toDtoBucketSpans(h.NegativeSpans)
+	pb.Histogram.NegativeDelta = h.NegativeDeltas
+	if len(h.PositiveSpans) == 0 && len(h.NegativeSpans) == 0 && h.ZeroCount == 0 && h.ZeroThreshold == 0 {
+		// Scrapers tell native histograms apart from classic ones by their buckets, so an
+		// empty native histogram carries an empty span, like in the Prometheus client libraries.
+		pb.Histogram.PositiveSpan = []*dto.BucketSpan{{Offset: new(int32), Length: new(uint32)}}
+	}
+	return nil
+}
+
+func toDtoBucketSpans(spans []prometheustranslator.BucketSpan) []*dto.BucketSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+	out := make([]*dto.BucketSpan, len(spans))
+	for i := range spans {
+		out[i] = &dto.BucketSpan{Offset: &spans[i].Offset, Length: &spans[i].Length}
+	}
+	return out
+}
+
 func (c *collector) createTargetInfoMetrics(resourceAttrs []pcommon.Map) ([]prometheus.Metric, error) {
 	var metrics []prometheus.Metric
 	var lastErr error
@@ -523,6 +523,82 @@ func TestAccumulateHistograms(t *testing.T) {
 	}
 }
 
+func TestAccumulateExponentialHistograms(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_metric")
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	metric.SetDescription("test description")
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetScale(0)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(-1)
+	dp.Positive().BucketCounts().FromRaw([]uint64{5, 2})
+	dp.Negative().BucketCounts().FromRaw([]uint64{3})
+	dp.SetCount(11)
+	dp.SetSum(42.42)
+	dp.Attributes().PutStr("label_1", "1")
+	dp.Attributes().PutStr("label_2", "2")
+
+	collect := func(t *testing.T, downconvert bool) *io_prometheus_client.Histogram {
+		c := collector{
+			accumulator: &mockAccumulator{
+				[]pmetric.Metric{metric},
+				pcommon.NewMap(),
+			},
+			downconvertExpHistograms: downconvert,
+			logger:                   zap.NewNop(),
+		}
+
+		ch := make(chan prometheus.Metric, 1)
+		go func() {
+			c.Collect(ch)
+			close(ch)
+		}()
+
+		var pbMetrics []io_prometheus_client.Metric
+		for m := range ch {
+			require.Contains(t, m.Desc().String(), "fqName: \"test_metric\"")
+			require.Contains(t, m.Desc().String(), "variableLabels: [label_1 label_2]")
+
+			pbMetric := io_prometheus_client.Metric{}
+			require.NoError(t, m.Write(&pbMetric))
+			pbMetrics = append(pbMetrics, pbMetric)
+		}
+		require.Len(t, pbMetrics, 1)
+		require.NotNil(t, pbMetrics[0].Histogram)
+		require.Equal(t, uint64(11), pbMetrics[0].Histogram.GetSampleCount())
+		require.Equal(t, 42.42, pbMetrics[0].Histogram.GetSampleSum())
+		return pbMetrics[0].Histogram
+	}
+
+	t.Run("Native", func(t *testing.T) {
+		h := collect(t, false)
+		require.Empty(t, h.Bucket)
+		require.Equal(t, int32(0), h.GetSchema())
+		require.Equal(t, uint64(1), h.GetZeroCount())
+		require.Len(t, h.PositiveSpan, 1)
+		require.Equal(t, int32(0), h.PositiveSpan[0].GetOffset())
+		require.Equal(t, uint32(2), h.PositiveSpan[0].GetLength())
+		require.Equal(t, []int64{5, -3}, h.PositiveDelta)
+		require.Len(t, h.NegativeSpan, 1)
+		require.Equal(t, int32(1), h.NegativeSpan[0].GetOffset())
+		require.Equal(t, uint32(1), h.NegativeSpan[0].GetLength())
+		require.Equal(t, []int64{3}, h.NegativeDelta)
+	})
+
+	t.Run("Downconvert", func(t *testing.T) {
+		h := collect(t, true)
+		require.Nil(t, h.Schema)
+		require.Empty(t, h.PositiveSpan)
+
+		points := map[float64]uint64{}
+		for _, b := range h.Bucket {
+			points[b.GetUpperBound()] = b.GetCumulativeCount()
+		}
+		require.Equal(t, map[float64]uint64{-1: 3, 0: 4, 1: 9, 2: 11}, points)
+	})
+}
+
 func TestAccumulateSummary(t *testing.T) {
 	fillQuantileValue := func(pN, value float64, dest pmetric.SummaryDataPointValueAtQuantile) {
 		dest.SetQuantile(pN)
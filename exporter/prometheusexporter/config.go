@@ -46,6 +46,10 @@ type Config struct {
 
 	// EnableOpenMetrics enables the use of the OpenMetrics encoding option for the prometheus exporter.
 	EnableOpenMetrics bool `mapstructure:"enable_open_metrics"`
+
+	// DownconvertExponentialHistograms exposes exponential histograms as explicit bucket histograms
+	// instead of native histograms.
+	DownconvertExponentialHistograms bool `mapstructure:"downconvert_exponential_histograms"`
 }
 
 var _ config.Exporter = (*Config)(nil)
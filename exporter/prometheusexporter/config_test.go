@@ -60,8 +60,9 @@ func TestLoadConfig(t *testing.T) {
 					"label1":        "value1",
 					"another label": "spaced value",
 				},
-				SendTimestamps:   true,
-				MetricExpiration: 60 * time.Minute,
+				SendTimestamps:                   true,
+				MetricExpiration:                 60 * time.Minute,
+				DownconvertExponentialHistograms: true,
 			},
 		},
 	}
@@ -51,6 +51,10 @@ type Config struct {
 
 	// TargetInfo allows customizing the target_info metric
 	TargetInfo *TargetInfo `mapstructure:"target_info,omitempty"`
+
+	// DownconvertExponentialHistograms exports exponential histograms as explicit bucket histograms
+	// instead of native histograms, for remote write endpoints without native histogram support.
+	DownconvertExponentialHistograms bool `mapstructure:"downconvert_exponential_histograms"`
 }
 
 type TargetInfo struct {
@@ -138,3 +138,17 @@ func TestDisabledTargetInfo(t *testing.T) {
 
 	assert.False(t, cfg.(*Config).TargetInfo.Enabled)
 }
+
+func TestDownconvertExponentialHistograms(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.False(t, cfg.(*Config).DownconvertExponentialHistograms)
+
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "downconvert_exponential_histograms").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalExporter(sub, cfg))
+
+	assert.True(t, cfg.(*Config).DownconvertExponentialHistograms)
+}
@@ -43,17 +43,18 @@ const maxBatchByteSize = 3000000
 
 // prwExporter converts OTLP metrics to Prometheus remote write TimeSeries and sends them to a remote endpoint.
 type prwExporter struct {
-	namespace         string
-	externalLabels    map[string]string
-	endpointURL       *url.URL
-	client            *http.Client
-	wg                *sync.WaitGroup
-	closeChan         chan struct{}
-	concurrency       int
-	userAgentHeader   string
-	clientSettings    *confighttp.HTTPClientSettings
-	settings          component.TelemetrySettings
-	disableTargetInfo bool
+	namespace                string
+	externalLabels           map[string]string
+	endpointURL              *url.URL
+	client                   *http.Client
+	wg                       *sync.WaitGroup
+	closeChan                chan struct{}
+	concurrency              int
+	userAgentHeader          string
+	clientSettings           *confighttp.HTTPClientSettings
+	settings                 component.TelemetrySettings
+	disableTargetInfo        bool
+	downconvertExpHistograms bool
 
 	wal *prweWAL
 }
@@ -73,16 +74,17 @@ func newPRWExporter(cfg *Config, set component.ExporterCreateSettings) (*prwExpo
 	userAgentHeader := fmt.Sprintf("%s/%s", strings.ReplaceAll(strings.ToLower(set.BuildInfo.Description), " ", "-"), set.BuildInfo.Version)
 
 	prwe := &prwExporter{
-		namespace:         cfg.Namespace,
-		externalLabels:    sanitizedLabels,
-		endpointURL:       endpointURL,
-		wg:                new(sync.WaitGroup),
-		closeChan:         make(chan struct{}),
-		userAgentHeader:   userAgentHeader,
-		concurrency:       cfg.RemoteWriteQueue.NumConsumers,
-		clientSettings:    &cfg.HTTPClientSettings,
-		settings:          set.TelemetrySettings,
-		disableTargetInfo: !cfg.TargetInfo.Enabled,
+		namespace:                cfg.Namespace,
+		externalLabels:           sanitizedLabels,
+		endpointURL:              endpointURL,
+		wg:                       new(sync.WaitGroup),
+		closeChan:                make(chan struct{}),
+		userAgentHeader:          userAgentHeader,
+		concurrency:              cfg.RemoteWriteQueue.NumConsumers,
+		clientSettings:           &cfg.HTTPClientSettings,
+		settings:                 set.TelemetrySettings,
+		disableTargetInfo:        !cfg.TargetInfo.Enabled,
+		downconvertExpHistograms: cfg.DownconvertExponentialHistograms,
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
@@ -135,7 +137,12 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
-		tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{Namespace: prwe.namespace, ExternalLabels: prwe.externalLabels, DisableTargetInfo: prwe.disableTargetInfo})
+		tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{
+			Namespace:                        prwe.namespace,
+			ExternalLabels:                   prwe.externalLabels,
+			DisableTargetInfo:                prwe.disableTargetInfo,
+			DownconvertExponentialHistograms: prwe.downconvertExpHistograms,
+		})
 		if err != nil {
 			err = consumererror.NewPermanent(err)
 		}
@@ -62,6 +62,9 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "batch",
 		},
+		{
+			processor: "deltatocumulative",
+		},
 		{
 			processor: "deltatorate",
 		},
@@ -74,6 +77,9 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "groupbytrace",
 		},
+		{
+			processor: "intervalaggregation",
+		},
 		{
 			processor:     "k8sattributes",
 			skipLifecycle: true, // Requires a k8s API to communicate with
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/histogramutils"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Downscale reduces the scale of exponential histogram buckets by the given amount, merging each group of
+// 2^by adjacent buckets. It returns the offset and counts of the downscaled buckets, and doesn't modify counts.
+func Downscale(offset int32, counts []uint64, by int32) (int32, []uint64) {
+	if by <= 0 || len(counts) == 0 {
+		return offset, counts
+	}
+	first := offset >> by
+	last := (offset + int32(len(counts)) - 1) >> by
+	downscaled := make([]uint64, last-first+1)
+	for i, count := range counts {
+		downscaled[((offset+int32(i))>>by)-first] += count
+	}
+	return first, downscaled
+}
+
+// MergeBuckets adds the counts of src to dest, after reducing the scale of dest and src by the given amounts.
+func MergeBuckets(dest, src pmetric.ExponentialHistogramDataPointBuckets, downscaleDest, downscaleSrc int32) {
+	destOffset, destCounts := Downscale(dest.Offset(), dest.BucketCounts().AsRaw(), downscaleDest)
+	srcOffset, srcCounts := Downscale(src.Offset(), src.BucketCounts().AsRaw(), downscaleSrc)
+
+	switch {
+	case len(srcCounts) == 0:
+	case len(destCounts) == 0:
+		destOffset, destCounts = srcOffset, srcCounts
+	default:
+		offset := destOffset
+		if srcOffset < offset {
+			offset = srcOffset
+		}
+		end := destOffset + int32(len(destCounts))
+		if srcEnd := srcOffset + int32(len(srcCounts)); srcEnd > end {
+			end = srcEnd
+		}
+		counts := make([]uint64, end-offset)
+		for i, count := range destCounts {
+			counts[destOffset-offset+int32(i)] += count
+		}
+		for i, count := range srcCounts {
+			counts[srcOffset-offset+int32(i)] += count
+		}
+		destOffset, destCounts = offset, counts
+	}
+
+	dest.SetOffset(destOffset)
+	dest.BucketCounts().FromRaw(destCounts)
+}
+
+// MergeExponentialHistograms adds the counts of src to dest. When their scales differ, the buckets
+// are merged to the lowest of them.
+func MergeExponentialHistograms(dest, src pmetric.ExponentialHistogramDataPoint) {
+	scale := dest.Scale()
+	if src.Scale() < scale {
+		scale = src.Scale()
+	}
+	MergeBuckets(dest.Positive(), src.Positive(), dest.Scale()-scale, src.Scale()-scale)
+	MergeBuckets(dest.Negative(), src.Negative(), dest.Scale()-scale, src.Scale()-scale)
+	dest.SetScale(scale)
+
+	dest.SetZeroCount(dest.ZeroCount() + src.ZeroCount())
+	MergeStats(dest, src)
+}
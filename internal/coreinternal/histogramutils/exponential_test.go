@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestDownscale(t *testing.T) {
+	tests := []struct {
+		name           string
+		offset         int32
+		counts         []uint64
+		by             int32
+		expectedOffset int32
+		expectedCounts []uint64
+	}{
+		{
+			name:           "unchanged",
+			offset:         3,
+			counts:         []uint64{1, 2},
+			by:             0,
+			expectedOffset: 3,
+			expectedCounts: []uint64{1, 2},
+		},
+		{
+			name:           "by_one",
+			offset:         1,
+			counts:         []uint64{1, 2, 3, 4},
+			by:             1,
+			expectedOffset: 0,
+			expectedCounts: []uint64{1, 5, 4},
+		},
+		{
+			name:           "negative_offset",
+			offset:         -3,
+			counts:         []uint64{1, 2, 3},
+			by:             2,
+			expectedOffset: -1,
+			expectedCounts: []uint64{6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, counts := Downscale(tt.offset, tt.counts, tt.by)
+			assert.Equal(t, tt.expectedOffset, offset)
+			assert.Equal(t, tt.expectedCounts, counts)
+		})
+	}
+}
+
+func TestMergeBuckets(t *testing.T) {
+	dest := pmetric.NewExponentialHistogramDataPoint().Positive()
+	dest.SetOffset(2)
+	dest.BucketCounts().FromRaw([]uint64{1, 1})
+
+	src := pmetric.NewExponentialHistogramDataPoint().Positive()
+	src.SetOffset(-1)
+	src.BucketCounts().FromRaw([]uint64{2, 0, 0, 3})
+
+	MergeBuckets(dest, src, 0, 0)
+	assert.Equal(t, int32(-1), dest.Offset())
+	assert.Equal(t, []uint64{2, 0, 0, 4, 1}, dest.BucketCounts().AsRaw())
+
+	// src was not modified
+	assert.Equal(t, []uint64{2, 0, 0, 3}, src.BucketCounts().AsRaw())
+
+	empty := pmetric.NewExponentialHistogramDataPoint().Positive()
+	MergeBuckets(empty, dest, 0, 1)
+	assert.Equal(t, int32(-1), empty.Offset())
+	assert.Equal(t, []uint64{2, 0, 5}, empty.BucketCounts().AsRaw())
+}
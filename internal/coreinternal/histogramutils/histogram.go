@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/histogramutils"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Point holds the fields shared by histogram and exponential histogram data points.
+type Point interface {
+	Count() uint64
+	SetCount(uint64)
+	HasSum() bool
+	Sum() float64
+	SetSum(float64)
+	HasMin() bool
+	Min() float64
+	SetMin(float64)
+	HasMax() bool
+	Max() float64
+	SetMax(float64)
+}
+
+var (
+	_ Point = pmetric.HistogramDataPoint{}
+	_ Point = pmetric.ExponentialHistogramDataPoint{}
+)
+
+// MergeStats adds the count and sum of src to dest, and extends the min and max of dest to cover the ones of src.
+func MergeStats(dest, src Point) {
+	dest.SetCount(dest.Count() + src.Count())
+	if src.HasSum() {
+		dest.SetSum(dest.Sum() + src.Sum())
+	}
+	if src.HasMin() && (!dest.HasMin() || src.Min() < dest.Min()) {
+		dest.SetMin(src.Min())
+	}
+	if src.HasMax() && (!dest.HasMax() || src.Max() > dest.Max()) {
+		dest.SetMax(src.Max())
+	}
+}
+
+// CopyStats sets the count, sum, min and max of dest to the ones of src. The optional fields of src
+// must be a superset of the ones of dest, as they can't be removed from dest.
+func CopyStats(dest, src Point) {
+	dest.SetCount(src.Count())
+	if src.HasSum() {
+		dest.SetSum(src.Sum())
+	}
+	if src.HasMin() {
+		dest.SetMin(src.Min())
+	}
+	if src.HasMax() {
+		dest.SetMax(src.Max())
+	}
+}
+
+// EqualBounds returns whether the explicit bounds of two histograms are the same.
+func EqualBounds(a, b pcommon.Float64Slice) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMergeStats(t *testing.T) {
+	dest := pmetric.NewHistogramDataPoint()
+	dest.SetCount(2)
+	dest.SetMin(1)
+
+	src := pmetric.NewHistogramDataPoint()
+	src.SetCount(3)
+	src.SetSum(10)
+	src.SetMin(0.5)
+	src.SetMax(4)
+
+	MergeStats(dest, src)
+	assert.Equal(t, uint64(5), dest.Count())
+	assert.Equal(t, 10.0, dest.Sum())
+	assert.Equal(t, 0.5, dest.Min())
+	assert.Equal(t, 4.0, dest.Max())
+
+	copied := pmetric.NewHistogramDataPoint()
+	CopyStats(copied, dest)
+	assert.Equal(t, uint64(5), copied.Count())
+	assert.Equal(t, 10.0, copied.Sum())
+	assert.Equal(t, 0.5, copied.Min())
+	assert.Equal(t, 4.0, copied.Max())
+}
+
+func TestEqualBounds(t *testing.T) {
+	a := pmetric.NewHistogramDataPoint().ExplicitBounds()
+	a.FromRaw([]float64{1, 2})
+	b := pmetric.NewHistogramDataPoint().ExplicitBounds()
+	b.FromRaw([]float64{1, 2})
+	assert.True(t, EqualBounds(a, b))
+
+	b.FromRaw([]float64{1, 3})
+	assert.False(t, EqualBounds(a, b))
+	b.FromRaw([]float64{1})
+	assert.False(t, EqualBounds(a, b))
+}
@@ -131,10 +131,47 @@ func (p *Parser[K]) newBooleanTermEvaluator(term *term) (boolExpressionEvaluator
 	return andFuncs(funcs), nil
 }
 
+// builds a function that negates the result of a boolExpressionEvaluator
+func notFunc[K any](f boolExpressionEvaluator[K]) boolExpressionEvaluator[K] {
+	return func(ctx K) (bool, error) {
+		result, err := f(ctx)
+		return !result, err
+	}
+}
+
+func (p *Parser[K]) newConverterEvaluator(converter invocation) (boolExpressionEvaluator[K], error) {
+	call, err := p.newFunctionCall(converter)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx K) (bool, error) {
+		result, err := call(ctx)
+		if err != nil {
+			return false, err
+		}
+		boolResult, ok := result.(bool)
+		if !ok {
+			return false, fmt.Errorf("value returned from %s must be a bool but got %T", converter.Function, result)
+		}
+		return boolResult, nil
+	}, nil
+}
+
 func (p *Parser[K]) newBooleanValueEvaluator(value *booleanValue) (boolExpressionEvaluator[K], error) {
 	if value == nil {
 		return alwaysTrue[K], nil
 	}
+	f, err := p.newBooleanOperandEvaluator(value)
+	if err != nil {
+		return nil, err
+	}
+	if value.Negation != nil {
+		return notFunc(f), nil
+	}
+	return f, nil
+}
+
+func (p *Parser[K]) newBooleanOperandEvaluator(value *booleanValue) (boolExpressionEvaluator[K], error) {
 	switch {
 	case value.Comparison != nil:
 		comparison, err := p.newComparisonEvaluator(value.Comparison)
@@ -147,6 +184,8 @@ func (p *Parser[K]) newBooleanValueEvaluator(value *booleanValue) (boolExpressio
 			return alwaysTrue[K], nil
 		}
 		return alwaysFalse[K], nil
+	case value.Converter != nil:
+		return p.newConverterEvaluator(*value.Converter)
 	case value.SubExpr != nil:
 		return p.newBooleanExpressionEvaluator(value.SubExpr)
 	}
@@ -85,7 +85,9 @@ func SetValue(value pcommon.Value, val interface{}) {
 		for _, b := range v {
 			value.Slice().AppendEmpty().SetEmptyBytes().FromRaw(b)
 		}
-	default:
-		// TODO(anuraaga): Support set of map type.
+	case pcommon.Map:
+		v.CopyTo(value.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(value.SetEmptySlice())
 	}
 }
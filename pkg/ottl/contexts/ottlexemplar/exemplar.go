@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlexemplar // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ottlcommon"
+)
+
+var _ ottlcommon.ResourceContext = TransformContext{}
+var _ ottlcommon.InstrumentationScopeContext = TransformContext{}
+var _ ottlcommon.MetricContext = TransformContext{}
+
+type TransformContext struct {
+	exemplar             pmetric.Exemplar
+	metric               pmetric.Metric
+	instrumentationScope pcommon.InstrumentationScope
+	resource             pcommon.Resource
+}
+
+func NewTransformContext(exemplar pmetric.Exemplar, metric pmetric.Metric, instrumentationScope pcommon.InstrumentationScope, resource pcommon.Resource) TransformContext {
+	return TransformContext{
+		exemplar:             exemplar,
+		metric:               metric,
+		instrumentationScope: instrumentationScope,
+		resource:             resource,
+	}
+}
+
+func (ctx TransformContext) GetExemplar() pmetric.Exemplar {
+	return ctx.exemplar
+}
+
+func (ctx TransformContext) GetMetric() pmetric.Metric {
+	return ctx.metric
+}
+
+func (ctx TransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return ctx.instrumentationScope
+}
+
+func (ctx TransformContext) GetResource() pcommon.Resource {
+	return ctx.resource
+}
+
+func NewParser(functions map[string]interface{}, telemetrySettings component.TelemetrySettings) ottl.Parser[TransformContext] {
+	return ottl.NewParser[TransformContext](functions, parsePath, parseEnum, telemetrySettings)
+}
+
+var symbolTable = ottlcommon.MetricSymbolTable
+
+func parseEnum(val *ottl.EnumSymbol) (*ottl.Enum, error) {
+	if val != nil {
+		if enum, ok := symbolTable[*val]; ok {
+			return &enum, nil
+		}
+		return nil, fmt.Errorf("enum symbol, %s, not found", *val)
+	}
+	return nil, fmt.Errorf("enum symbol not provided")
+}
+
+func parsePath(val *ottl.Path) (ottl.GetSetter[TransformContext], error) {
+	if val != nil && len(val.Fields) > 0 {
+		return newPathGetSetter(val.Fields)
+	}
+	return nil, fmt.Errorf("bad path %v", val)
+}
+
+func newPathGetSetter(path []ottl.Field) (ottl.GetSetter[TransformContext], error) {
+	switch path[0].Name {
+	case "resource":
+		return ottlcommon.ResourcePathGetSetter[TransformContext](path[1:])
+	case "instrumentation_scope":
+		return ottlcommon.ScopePathGetSetter[TransformContext](path[1:])
+	case "metric":
+		return ottlcommon.MetricPathGetSetter[TransformContext](path[1:])
+	case "time_unix_nano":
+		return accessTimeUnixNano(), nil
+	case "value_double":
+		return accessDoubleValue(), nil
+	case "value_int":
+		return accessIntValue(), nil
+	case "filtered_attributes":
+		mapKey := path[0].MapKey
+		if mapKey == nil {
+			return accessFilteredAttributes(), nil
+		}
+		return accessFilteredAttributesKey(mapKey), nil
+	case "trace_id":
+		if len(path) == 1 {
+			return accessTraceID(), nil
+		}
+		if path[1].Name == "string" {
+			return accessStringTraceID(), nil
+		}
+	case "span_id":
+		if len(path) == 1 {
+			return accessSpanID(), nil
+		}
+		if path[1].Name == "string" {
+			return accessStringSpanID(), nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid path expression, unrecognized field %v", path[0].Name)
+	}
+
+	return nil, fmt.Errorf("invalid exemplar path expression %v", path)
+}
+
+func accessTimeUnixNano() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx TransformContext) (interface{}, error) {
+			return ctx.GetExemplar().Timestamp().AsTime().UnixNano(), nil
+		},
+		Setter: func(ctx TransformContext, val interface{}) error {
+			if newTime, ok := val.(int64); ok {
+				ctx.GetExemplar().SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, newTime)))
+			}
+			return nil
+		},
+	}
+}
+
+func accessDoubleValue() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx TransformContext) (interface{}, error) {
+			return ctx.GetExemplar().DoubleValue(), nil
+		},
+		Setter: func(ctx TransformContext, val interface{}) error {
+			if newDouble, ok := val.(float64); ok {
+				ctx.GetExemplar().SetDoubleValue(newDouble)
+			}
+			return nil
+		},
+	}
+}
+
+func accessIntValue() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx TransformContext) (interface{}, error) {
+			return ctx.GetExemplar().IntValue(), nil
+		},
+		Setter: func(ctx TransformContext, val interface{}) error {
+			if newInt, ok := val.(int64); ok {
+				ctx.GetExemplar().SetIntValue(newInt)
+			}
+			return nil
+		},
+	}
+}
+
+func accessFilteredAttributes() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx TransformContext) (interface{}, error) {
+			return ctx.GetExemplar().FilteredAttributes(), nil
+		},
+		Setter: func(ctx TransformContext, val interface{}) error {
+			if attrs, ok := val.(pcommon.Map); ok {
+				attrs.CopyTo(ctx.GetExemplar().FilteredAttributes())
+			}
+			return nil
+		},
+	}
+}
+
+func accessFilteredAttributesKey(mapKey *string) ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx TransformContext) (interface{}, error) {
+			return ottlcommon.GetMapValue(ctx.GetExemplar().FilteredAttributes(), *mapKey), nil
+		},
+		Setter: func(ctx TransformContext, val interface{}) error {
+			ottlcommon.SetMapValue(ctx.GetExemplar().FilteredAttributes(), *mapKey, val)
+			return nil
+		},
+	}
+}
+
+func accessTraceID() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx TransformContext) (interface{}, error) {
+			return ctx.GetExemplar().TraceID(), nil
+		},
+		Setter: func(ctx TransformContext, val interface{}) error {
+			if newTraceID, ok := val.(pcommon.TraceID); ok {
+				ctx.GetExemplar().SetTraceID(newTraceID)
+			}
+			return nil
+		},
+	}
+}
+
+func accessStringTraceID() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx TransformContext) (interface{}, error) {
+			id := ctx.GetExemplar().TraceID()
+			return hex.EncodeToString(id[:]), nil
+		},
+		Setter: func(ctx TransformContext, val interface{}) error {
+			if str, ok := val.(string); ok {
+				id, err := ottlcommon.ParseTraceID(str)
+				if err != nil {
+					return err
+				}
+				ctx.GetExemplar().SetTraceID(id)
+			}
+			return nil
+		},
+	}
+}
+
+func accessSpanID() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx TransformContext) (interface{}, error) {
+			return ctx.GetExemplar().SpanID(), nil
+		},
+		Setter: func(ctx TransformContext, val interface{}) error {
+			if newSpanID, ok := val.(pcommon.SpanID); ok {
+				ctx.GetExemplar().SetSpanID(newSpanID)
+			}
+			return nil
+		},
+	}
+}
+
+func accessStringSpanID() ottl.StandardGetSetter[TransformContext] {
+	return ottl.StandardGetSetter[TransformContext]{
+		Getter: func(ctx TransformContext) (interface{}, error) {
+			id := ctx.GetExemplar().SpanID()
+			return hex.EncodeToString(id[:]), nil
+		},
+		Setter: func(ctx TransformContext, val interface{}) error {
+			if str, ok := val.(string); ok {
+				id, err := ottlcommon.ParseSpanID(str)
+				if err != nil {
+					return err
+				}
+				ctx.GetExemplar().SetSpanID(id)
+			}
+			return nil
+		},
+	}
+}
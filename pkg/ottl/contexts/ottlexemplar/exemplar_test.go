@@ -0,0 +1,342 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlexemplar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	traceID  = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	traceID2 = [16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	spanID   = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	spanID2  = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refExemplar, _, _, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     []ottl.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "time_unix_nano",
+			path: []ottl.Field{
+				{
+					Name: "time_unix_nano",
+				},
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				exemplar.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "value_double",
+			path: []ottl.Field{
+				{
+					Name: "value_double",
+				},
+			},
+			orig:   1.1,
+			newVal: 2.2,
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				exemplar.SetDoubleValue(2.2)
+			},
+		},
+		{
+			name: "value_int",
+			path: []ottl.Field{
+				{
+					Name: "value_int",
+				},
+			},
+			orig:   int64(0),
+			newVal: int64(5),
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				exemplar.SetIntValue(5)
+			},
+		},
+		{
+			name: "filtered_attributes",
+			path: []ottl.Field{
+				{
+					Name: "filtered_attributes",
+				},
+			},
+			orig:   refExemplar.FilteredAttributes(),
+			newVal: newAttrs,
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newAttrs.CopyTo(exemplar.FilteredAttributes())
+			},
+		},
+		{
+			name: "filtered_attributes string",
+			path: []ottl.Field{
+				{
+					Name:   "filtered_attributes",
+					MapKey: ottltest.Strp("user.id"),
+				},
+			},
+			orig:   "1234",
+			newVal: "redacted",
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				exemplar.FilteredAttributes().PutStr("user.id", "redacted")
+			},
+		},
+		{
+			name: "trace_id",
+			path: []ottl.Field{
+				{
+					Name: "trace_id",
+				},
+			},
+			orig:   pcommon.TraceID(traceID),
+			newVal: pcommon.TraceID(traceID2),
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				exemplar.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "trace_id string",
+			path: []ottl.Field{
+				{
+					Name: "trace_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   "0102030405060708090a0b0c0d0e0f10",
+			newVal: "100f0e0d0c0b0a090807060504030201",
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				exemplar.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "span_id",
+			path: []ottl.Field{
+				{
+					Name: "span_id",
+				},
+			},
+			orig:   pcommon.SpanID(spanID),
+			newVal: pcommon.SpanID(spanID2),
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				exemplar.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "span_id string",
+			path: []ottl.Field{
+				{
+					Name: "span_id",
+				},
+				{
+					Name: "string",
+				},
+			},
+			orig:   "0102030405060708",
+			newVal: "0807060504030201",
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				exemplar.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "metric name",
+			path: []ottl.Field{
+				{
+					Name: "metric",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "http.server.duration",
+			newVal: "duration",
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				metric.SetName("duration")
+			},
+		},
+		{
+			name: "instrumentation_scope name",
+			path: []ottl.Field{
+				{
+					Name: "instrumentation_scope",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "new library",
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("new library")
+			},
+		},
+		{
+			name: "resource attributes",
+			path: []ottl.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name:   "attributes",
+					MapKey: ottltest.Strp("service.name"),
+				},
+			},
+			orig:   "checkout",
+			newVal: "cart",
+			modified: func(exemplar pmetric.Exemplar, metric pmetric.Metric, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().PutStr("service.name", "cart")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			exemplar, metric, il, resource := createTelemetry()
+
+			got, err := accessor.Get(NewTransformContext(exemplar, metric, il, resource))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(NewTransformContext(exemplar, metric, il, resource), tt.newVal)
+			assert.Nil(t, err)
+
+			exExemplar, exMetric, exIl, exRes := createTelemetry()
+			tt.modified(exExemplar, exMetric, exIl, exRes)
+
+			assert.Equal(t, exExemplar, exemplar)
+			assert.Equal(t, exMetric, metric)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path []ottl.Field
+	}{
+		{
+			name: "unknown field",
+			path: []ottl.Field{{Name: "not_a_field"}},
+		},
+		{
+			name: "unknown trace_id subfield",
+			path: []ottl.Field{{Name: "trace_id"}, {Name: "bytes"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPathGetSetter(tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_StringTraceID_invalid(t *testing.T) {
+	exemplar, metric, il, resource := createTelemetry()
+	err := accessStringTraceID().Set(NewTransformContext(exemplar, metric, il, resource), "not a trace id")
+	assert.Error(t, err)
+}
+
+func createTelemetry() (pmetric.Exemplar, pmetric.Metric, pcommon.InstrumentationScope, pcommon.Resource) {
+	metric := pmetric.NewMetric()
+	metric.SetName("http.server.duration")
+	dataPoint := metric.SetEmptyHistogram().DataPoints().AppendEmpty()
+
+	exemplar := dataPoint.Exemplars().AppendEmpty()
+	exemplar.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	exemplar.SetDoubleValue(1.1)
+	exemplar.SetTraceID(traceID)
+	exemplar.SetSpanID(spanID)
+	exemplar.FilteredAttributes().PutStr("user.id", "1234")
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+
+	return exemplar, metric, il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		want ottl.Enum
+	}{
+		{
+			name: "AGGREGATION_TEMPORALITY_DELTA",
+			want: ottl.Enum(pmetric.AggregationTemporalityDelta),
+		},
+		{
+			name: "METRIC_DATA_TYPE_HISTOGRAM",
+			want: ottl.Enum(pmetric.MetricTypeHistogram),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp(tt.name)))
+			assert.NoError(t, err)
+			assert.Equal(t, *actual, tt.want)
+		})
+	}
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	tests := []struct {
+		name       string
+		enumSymbol *ottl.EnumSymbol
+	}{
+		{
+			name:       "unknown enum symbol",
+			enumSymbol: (*ottl.EnumSymbol)(ottltest.Strp("not an enum")),
+		},
+		{
+			name:       "nil enum symbol",
+			enumSymbol: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum(tt.enumSymbol)
+			assert.Error(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlspanevent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	refSpanEvent, _, _, _ := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     []ottl.Field
+		orig     interface{}
+		newVal   interface{}
+		modified func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource)
+	}{
+		{
+			name: "span event time",
+			path: []ottl.Field{
+				{
+					Name: "time_unix_nano",
+				},
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "name",
+			path: []ottl.Field{
+				{
+					Name: "name",
+				},
+			},
+			orig:   "bear",
+			newVal: "cat",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.SetName("cat")
+			},
+		},
+		{
+			name: "attributes",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+				},
+			},
+			orig:   refSpanEvent.Attributes(),
+			newVal: newAttrs,
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newAttrs.CopyTo(spanEvent.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: []ottl.Field{
+				{
+					Name:   "attributes",
+					MapKey: ottltest.Strp("str"),
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.Attributes().PutStr("str", "newVal")
+			},
+		},
+		{
+			name: "attributes int",
+			path: []ottl.Field{
+				{
+					Name:   "attributes",
+					MapKey: ottltest.Strp("int"),
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.Attributes().PutInt("int", 20)
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: []ottl.Field{
+				{
+					Name: "dropped_attributes_count",
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				spanEvent.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "span name",
+			path: []ottl.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "span",
+			newVal: "new span",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.SetName("new span")
+			},
+		},
+		{
+			name: "span attributes",
+			path: []ottl.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name:   "attributes",
+					MapKey: ottltest.Strp("http.method"),
+				},
+			},
+			orig:   "GET",
+			newVal: "POST",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.Attributes().PutStr("http.method", "POST")
+			},
+		},
+		{
+			name: "span status code",
+			path: []ottl.Field{
+				{
+					Name: "span",
+				},
+				{
+					Name: "status",
+				},
+				{
+					Name: "code",
+				},
+			},
+			orig:   int64(ptrace.StatusCodeError),
+			newVal: int64(ptrace.StatusCodeOk),
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				span.Status().SetCode(ptrace.StatusCodeOk)
+			},
+		},
+		{
+			name: "instrumentation_scope name",
+			path: []ottl.Field{
+				{
+					Name: "instrumentation_scope",
+				},
+				{
+					Name: "name",
+				},
+			},
+			orig:   "library",
+			newVal: "new library",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				il.SetName("new library")
+			},
+		},
+		{
+			name: "resource attributes",
+			path: []ottl.Field{
+				{
+					Name: "resource",
+				},
+				{
+					Name:   "attributes",
+					MapKey: ottltest.Strp("service.name"),
+				},
+			},
+			orig:   "checkout",
+			newVal: "cart",
+			modified: func(spanEvent ptrace.SpanEvent, span ptrace.Span, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				resource.Attributes().PutStr("service.name", "cart")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := newPathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			spanEvent, span, il, resource := createTelemetry()
+
+			got, err := accessor.Get(NewTransformContext(spanEvent, span, il, resource))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(NewTransformContext(spanEvent, span, il, resource), tt.newVal)
+			assert.Nil(t, err)
+
+			exSpanEvent, exSpan, exIl, exRes := createTelemetry()
+			tt.modified(exSpanEvent, exSpan, exIl, exRes)
+
+			assert.Equal(t, exSpanEvent, spanEvent)
+			assert.Equal(t, exSpan, span)
+			assert.Equal(t, exIl, il)
+			assert.Equal(t, exRes, resource)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalid(t *testing.T) {
+	_, err := newPathGetSetter([]ottl.Field{{Name: "not_a_field"}})
+	assert.Error(t, err)
+}
+
+func createTelemetry() (ptrace.SpanEvent, ptrace.Span, pcommon.InstrumentationScope, pcommon.Resource) {
+	span := ptrace.NewSpan()
+	span.SetName("span")
+	span.Attributes().PutStr("http.method", "GET")
+	span.Status().SetCode(ptrace.StatusCodeError)
+
+	spanEvent := span.Events().AppendEmpty()
+	spanEvent.SetName("bear")
+	spanEvent.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	spanEvent.SetDroppedAttributesCount(10)
+	spanEvent.Attributes().PutStr("str", "val")
+	spanEvent.Attributes().PutInt("int", 10)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+
+	return spanEvent, span, il, resource
+}
+
+func Test_ParseEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		want ottl.Enum
+	}{
+		{
+			name: "SPAN_KIND_SERVER",
+			want: ottl.Enum(ptrace.SpanKindServer),
+		},
+		{
+			name: "STATUS_CODE_ERROR",
+			want: ottl.Enum(ptrace.StatusCodeError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp(tt.name)))
+			assert.NoError(t, err)
+			assert.Equal(t, *actual, tt.want)
+		})
+	}
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	tests := []struct {
+		name       string
+		enumSymbol *ottl.EnumSymbol
+	}{
+		{
+			name:       "unknown enum symbol",
+			enumSymbol: (*ottl.EnumSymbol)(ottltest.Strp("not an enum")),
+		},
+		{
+			name:       "nil enum symbol",
+			enumSymbol: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum(tt.enumSymbol)
+			assert.Error(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
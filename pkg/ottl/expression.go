@@ -95,6 +95,10 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 		return p.pathParser(val.Path)
 	}
 
+	if val.MathExpression != nil {
+		return p.evaluateMathExpression(val.MathExpression)
+	}
+
 	if val.Invocation == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the OpenTelemetry Transformation Language")
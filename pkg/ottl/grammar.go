@@ -26,12 +26,20 @@ type parsedStatement struct {
 	WhereClause *booleanExpression `parser:"( 'where' @@ )?"`
 }
 
+// parsedCondition represents a parsed condition. It is the entry point into the condition DSL.
+type parsedCondition struct {
+	Expression *booleanExpression `parser:"@@"`
+}
+
 // booleanValue represents something that evaluates to a boolean --
-// either an equality or inequality, explicit true or false, or
-// a parenthesized subexpression.
+// either an equality or inequality, explicit true or false, a function
+// returning a boolean, or a parenthesized subexpression. It can be
+// negated with the `not` operator.
 type booleanValue struct {
+	Negation   *string            `parser:"@OpNot?"`
 	Comparison *comparison        `parser:"( @@"`
 	ConstExpr  *boolean           `parser:"| @Boolean"`
+	Converter  *invocation        `parser:"| @@"`
 	SubExpr    *booleanExpression `parser:"| '(' @@ ')' )"`
 }
 
@@ -127,20 +135,109 @@ type invocation struct {
 }
 
 // value represents a part of a parsed statement which is resolved to a value of some sort. This can be a telemetry path
-// expression, function call, or literal.
+// expression, function call, literal, or an arithmetic expression combining them.
+// Function calls, numbers and paths are only captured on their own when they aren't followed by
+// an arithmetic operator, otherwise they are part of a mathExpression. Enums are only captured when they
+// aren't the beginning of a function name.
 type value struct {
+	Invocation     *invocation     `parser:"( @@ (?! OpAddSub | OpMultDiv)"`
+	Bytes          *byteSlice      `parser:"| @Bytes"`
+	String         *string         `parser:"| @String"`
+	Float          *float64        `parser:"| @(OpAddSub? Float) (?! OpAddSub | OpMultDiv)"`
+	Int            *int64          `parser:"| @(OpAddSub? Int) (?! OpAddSub | OpMultDiv)"`
+	Bool           *boolean        `parser:"| @Boolean"`
+	IsNil          *isNil          `parser:"| @'nil'"`
+	Enum           *EnumSymbol     `parser:"| @Uppercase (?! Lowercase | '(')"`
+	List           *list           `parser:"| @@"`
+	Path           *Path           `parser:"| @@ (?! OpAddSub | OpMultDiv)"`
+	MathExpression *mathExpression `parser:"| @@ )"`
+}
+
+// mathExprLiteral represents a value that can be used as an operand of an arithmetic expression.
+type mathExprLiteral struct {
 	Invocation *invocation `parser:"( @@"`
-	Bytes      *byteSlice  `parser:"| @Bytes"`
-	String     *string     `parser:"| @String"`
-	Float      *float64    `parser:"| @Float"`
-	Int        *int64      `parser:"| @Int"`
-	Bool       *boolean    `parser:"| @Boolean"`
-	IsNil      *isNil      `parser:"| @'nil'"`
-	Enum       *EnumSymbol `parser:"| @Uppercase"`
-	List       *list       `parser:"| @@"`
+	Float      *float64    `parser:"| @(OpAddSub? Float)"`
+	Int        *int64      `parser:"| @(OpAddSub? Int)"`
 	Path       *Path       `parser:"| @@ )"`
 }
 
+// mathValue represents an operand of an arithmetic expression -- either
+// a literal or a parenthesized subexpression.
+type mathValue struct {
+	Literal       *mathExprLiteral `parser:"( @@"`
+	SubExpression *mathExpression  `parser:"| '(' @@ ')' )"`
+}
+
+// opMultDivValue represents the right side of a multiplication or division.
+type opMultDivValue struct {
+	Operator mathOp     `parser:"@OpMultDiv"`
+	Value    *mathValue `parser:"@@"`
+}
+
+// addSubTerm represents an arbitrary number of values joined by multiplications or divisions.
+type addSubTerm struct {
+	Left  *mathValue        `parser:"@@"`
+	Right []*opMultDivValue `parser:"@@*"`
+}
+
+// opAddSubTerm represents the right side of an addition or subtraction.
+type opAddSubTerm struct {
+	Operator mathOp      `parser:"@OpAddSub"`
+	Term     *addSubTerm `parser:"@@"`
+}
+
+// mathExpression represents an arithmetic expression made of an arbitrary
+// number of terms separated by additions or subtractions.
+type mathExpression struct {
+	Left  *addSubTerm     `parser:"@@"`
+	Right []*opAddSubTerm `parser:"@@*"`
+}
+
+// mathOp is the type of an arithmetic operator.
+type mathOp int
+
+// These are the allowed values of a mathOp
+const (
+	ADD mathOp = iota
+	SUB
+	MULT
+	DIV
+)
+
+// a fast way to get from a string to a mathOp
+var mathOpTable = map[string]mathOp{
+	"+": ADD,
+	"-": SUB,
+	"*": MULT,
+	"/": DIV,
+}
+
+// Capture is how the parser converts an operator string to a mathOp.
+func (m *mathOp) Capture(values []string) error {
+	op, ok := mathOpTable[values[0]]
+	if !ok {
+		return fmt.Errorf("'%s' is not a valid operator", values[0])
+	}
+	*m = op
+	return nil
+}
+
+// String() for mathOp gives us more legible test results and error messages.
+func (m *mathOp) String() string {
+	switch *m {
+	case ADD:
+		return "+"
+	case SUB:
+		return "-"
+	case MULT:
+		return "*"
+	case DIV:
+		return "/"
+	default:
+		return "UNKNOWN OP!"
+	}
+}
+
 // Path represents a telemetry path expression.
 type Path struct {
 	Fields []Field `parser:"@@ ( '.' @@ )*"`
@@ -193,12 +290,15 @@ type EnumSymbol string
 func buildLexer() *lexer.StatefulDefinition {
 	return lexer.MustSimple([]lexer.SimpleRule{
 		{Name: `Bytes`, Pattern: `0x[a-fA-F0-9]+`},
-		{Name: `Float`, Pattern: `[-+]?\d*\.\d+([eE][-+]?\d+)?`},
-		{Name: `Int`, Pattern: `[-+]?\d+`},
+		{Name: `Float`, Pattern: `\d*\.\d+([eE][-+]?\d+)?`},
+		{Name: `Int`, Pattern: `\d+`},
 		{Name: `String`, Pattern: `"(\\"|[^"])*"`},
+		{Name: `OpNot`, Pattern: `\b(not)\b`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
 		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
+		{Name: `OpAddSub`, Pattern: `\+|\-`},
+		{Name: `OpMultDiv`, Pattern: `\*|\/`},
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
@@ -83,6 +83,40 @@ func Test_lexer(t *testing.T) {
 			{"OpOr", "or"},
 			{"Lowercase", "but"},
 		}},
+		{"name_containing_not", "nothing knot", false, []result{
+			{"Lowercase", "nothing"},
+			{"Lowercase", "knot"}, // should not parse "not" as an operator
+		}},
+		{"parse_not", "not true", false, []result{
+			{"OpNot", "not"},
+			{"Boolean", "true"},
+		}},
+		{"math_operators", "a + b - 1 * 2.5 / c", false, []result{
+			{"Lowercase", "a"},
+			{"OpAddSub", "+"},
+			{"Lowercase", "b"},
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+			{"OpMultDiv", "*"},
+			{"Float", "2.5"},
+			{"OpMultDiv", "/"},
+			{"Lowercase", "c"},
+		}},
+		{"signed_numbers", "-1 + +2.5", false, []result{
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+			{"OpAddSub", "+"},
+			{"OpAddSub", "+"},
+			{"Float", "2.5"},
+		}},
+		{"subtraction_without_spaces", `attributes["x"]-1`, false, []result{
+			{"Lowercase", "attributes"},
+			{"Punct", "["},
+			{"String", `"x"`},
+			{"Punct", "]"},
+			{"OpAddSub", "-"},
+			{"Int", "1"},
+		}},
 		{"nothing_recognizable", "{}", true, []result{
 			{"", ""},
 		}},
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"fmt"
+)
+
+// The functions in this file evaluate arithmetic expressions. Operands must be int64 or float64 values.
+// Operations between two int64 values return an int64, while operations involving a float64 return a float64.
+
+func (p *Parser[K]) evaluateMathExpression(expr *mathExpression) (Getter[K], error) {
+	mainGetter, err := p.evaluateAddSubTerm(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range expr.Right {
+		getter, err := p.evaluateAddSubTerm(rhs.Term)
+		if err != nil {
+			return nil, err
+		}
+		mainGetter = attemptMathOperation(mainGetter, rhs.Operator, getter)
+	}
+	return mainGetter, nil
+}
+
+func (p *Parser[K]) evaluateAddSubTerm(term *addSubTerm) (Getter[K], error) {
+	mainGetter, err := p.evaluateMathValue(term.Left)
+	if err != nil {
+		return nil, err
+	}
+	for _, rhs := range term.Right {
+		getter, err := p.evaluateMathValue(rhs.Value)
+		if err != nil {
+			return nil, err
+		}
+		mainGetter = attemptMathOperation(mainGetter, rhs.Operator, getter)
+	}
+	return mainGetter, nil
+}
+
+func (p *Parser[K]) evaluateMathValue(val *mathValue) (Getter[K], error) {
+	switch {
+	case val.Literal != nil:
+		return p.newGetter(value{
+			Invocation: val.Literal.Invocation,
+			Float:      val.Literal.Float,
+			Int:        val.Literal.Int,
+			Path:       val.Literal.Path,
+		})
+	case val.SubExpression != nil:
+		return p.evaluateMathExpression(val.SubExpression)
+	}
+
+	return nil, fmt.Errorf("unhandled math value %v", val)
+}
+
+func attemptMathOperation[K any](lhs Getter[K], op mathOp, rhs Getter[K]) Getter[K] {
+	return exprGetter[K]{
+		expr: func(ctx K) (interface{}, error) {
+			x, err := lhs.Get(ctx)
+			if err != nil {
+				return nil, err
+			}
+			y, err := rhs.Get(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			switch left := x.(type) {
+			case int64:
+				switch right := y.(type) {
+				case int64:
+					return performOp(left, right, op)
+				case float64:
+					return performOp(float64(left), right, op)
+				}
+			case float64:
+				switch right := y.(type) {
+				case int64:
+					return performOp(left, float64(right), op)
+				case float64:
+					return performOp(left, right, op)
+				}
+			}
+			return nil, fmt.Errorf("unsupported operands for %s: %T and %T, only int64 and float64 are allowed", op.String(), x, y)
+		},
+	}
+}
+
+func performOp[N int64 | float64](x N, y N, op mathOp) (interface{}, error) {
+	switch op {
+	case ADD:
+		return x + y, nil
+	case SUB:
+		return x - y, nil
+	case MULT:
+		return x * y, nil
+	case DIV:
+		if y == 0 {
+			return nil, fmt.Errorf("attempted to divide by 0")
+		}
+		return x / y, nil
+	}
+	return nil, fmt.Errorf("invalid operator %v", op.String())
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottl
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+// mathParsePath resolves one_hundred and attributes["x"] to 100
+func mathParsePath(val *Path) (GetSetter[interface{}], error) {
+	if val != nil && len(val.Fields) > 0 && (val.Fields[0].Name == "one_hundred" ||
+		val.Fields[0].Name == "attributes" && val.Fields[0].MapKey != nil && *val.Fields[0].MapKey == "x") {
+		return &StandardGetSetter[interface{}]{
+			Getter: func(ctx interface{}) (interface{}, error) {
+				return int64(100), nil
+			},
+		}, nil
+	}
+	return testParsePath(val)
+}
+
+func Test_parseMathExpression(t *testing.T) {
+	parsed, err := parseStatement(`set(name, 1 + attributes["a"] * 2.5)`)
+	require.NoError(t, err)
+	assert.Equal(t, &mathExpression{
+		Left: &addSubTerm{
+			Left: &mathValue{
+				Literal: &mathExprLiteral{
+					Int: ottltest.Intp(1),
+				},
+			},
+		},
+		Right: []*opAddSubTerm{
+			{
+				Operator: ADD,
+				Term: &addSubTerm{
+					Left: &mathValue{
+						Literal: &mathExprLiteral{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name:   "attributes",
+										MapKey: ottltest.Strp("a"),
+									},
+								},
+							},
+						},
+					},
+					Right: []*opMultDivValue{
+						{
+							Operator: MULT,
+							Value: &mathValue{
+								Literal: &mathExprLiteral{
+									Float: ottltest.Floatp(2.5),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, parsed.Invocation.Arguments[1].MathExpression)
+}
+
+func Test_evaluateMathExpression(t *testing.T) {
+	functions := map[string]interface{}{
+		"identity": func(target Getter[interface{}]) (ExprFunc[interface{}], error) {
+			return target.Get, nil
+		},
+		"Two": func() (ExprFunc[interface{}], error) {
+			return func(interface{}) (interface{}, error) {
+				return int64(2), nil
+			}, nil
+		},
+	}
+	p := NewParser(functions, mathParsePath, testParseEnum, component.TelemetrySettings{})
+
+	tests := []struct {
+		expression string
+		expected   interface{}
+	}{
+		{expression: `1 + 1`, expected: int64(2)},
+		{expression: `1 - 3`, expected: int64(-2)},
+		{expression: `3 * 4`, expected: int64(12)},
+		{expression: `7 / 2`, expected: int64(3)},
+		{expression: `1.5 + 1`, expected: float64(2.5)},
+		{expression: `1 + 2 * 3`, expected: int64(7)},
+		{expression: `(1 + 2) * 3`, expected: int64(9)},
+		{expression: `10 - 4 - 3`, expected: int64(3)},
+		{expression: `12 / 3 / 2`, expected: int64(2)},
+		{expression: `one_hundred / 8.0`, expected: float64(12.5)},
+		{expression: `Two() * one_hundred`, expected: int64(200)},
+		{expression: `(Two() + 1) * (one_hundred - 1)`, expected: int64(297)},
+		{expression: `one_hundred-1`, expected: int64(99)},
+		{expression: `attributes["x"]-1`, expected: int64(99)},
+		{expression: `-1 - -2.5`, expected: float64(1.5)},
+		{expression: `2*-3`, expected: int64(-6)},
+	}
+
+	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
+	for _, tt := range tests {
+		t.Run(pat.ReplaceAllString(tt.expression, "_"), func(t *testing.T) {
+			statements, err := p.ParseStatements([]string{`identity(` + tt.expression + `)`})
+			require.NoError(t, err)
+
+			result, _, err := statements[0].Execute(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_evaluateMathExpression_error(t *testing.T) {
+	functions := map[string]interface{}{
+		"identity": func(target Getter[interface{}]) (ExprFunc[interface{}], error) {
+			return target.Get, nil
+		},
+	}
+	p := NewParser(functions, mathParsePath, testParseEnum, component.TelemetrySettings{})
+
+	tests := []struct {
+		name       string
+		expression string
+		item       interface{}
+	}{
+		{name: "int division by zero", expression: `1 / 0`},
+		{name: "float division by zero", expression: `1.5 / 0.0`},
+		{name: "string operand", expression: `name + 1`, item: "bear"},
+		{name: "nil operand", expression: `name * 2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := p.ParseStatements([]string{`identity(` + tt.expression + `)`})
+			require.NoError(t, err)
+
+			_, _, err = statements[0].Execute(tt.item)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_mathExpressionInConditions(t *testing.T) {
+	p := NewParser(map[string]interface{}{}, mathParsePath, testParseEnum, component.TelemetrySettings{})
+
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{condition: `one_hundred * 2 == 200`, expected: true},
+		{condition: `one_hundred / 3 > 33.5`, expected: false},
+		{condition: `(one_hundred - 1) * 2 >= 198 and 1 + 1 != 3`, expected: true},
+		{condition: `not (one_hundred + 1 < 100)`, expected: true},
+		{condition: `one_hundred-1 == 99`, expected: true},
+		{condition: `one_hundred > -1`, expected: true},
+	}
+	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
+	for _, tt := range tests {
+		t.Run(pat.ReplaceAllString(tt.condition, "_"), func(t *testing.T) {
+			conditions, err := p.ParseConditions([]string{tt.condition})
+			require.NoError(t, err)
+
+			result, err := conditions[0].Eval(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_copyKey(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutStr("test", "hello world")
+	input.PutInt("test2", 3)
+	input.PutEmptySlice("test3").AppendEmpty().SetBool(true)
+
+	target := &ottl.StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx pcommon.Map) (interface{}, error) {
+			return ctx, nil
+		},
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		newKey string
+		want   func(pcommon.Map)
+	}{
+		{
+			name:   "copy key",
+			key:    "test",
+			newKey: "copied",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("test", "hello world")
+				expectedMap.PutInt("test2", 3)
+				expectedMap.PutEmptySlice("test3").AppendEmpty().SetBool(true)
+				expectedMap.PutStr("copied", "hello world")
+			},
+		},
+		{
+			name:   "copy slice",
+			key:    "test3",
+			newKey: "copied",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("test", "hello world")
+				expectedMap.PutInt("test2", 3)
+				expectedMap.PutEmptySlice("test3").AppendEmpty().SetBool(true)
+				expectedMap.PutEmptySlice("copied").AppendEmpty().SetBool(true)
+			},
+		},
+		{
+			name:   "overwrite existing key",
+			key:    "test",
+			newKey: "test2",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("test", "hello world")
+				expectedMap.PutStr("test2", "hello world")
+				expectedMap.PutEmptySlice("test3").AppendEmpty().SetBool(true)
+			},
+		},
+		{
+			name:   "missing key",
+			key:    "not a valid key",
+			newKey: "copied",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("test", "hello world")
+				expectedMap.PutInt("test2", 3)
+				expectedMap.PutEmptySlice("test3").AppendEmpty().SetBool(true)
+			},
+		},
+		{
+			name:   "same key",
+			key:    "test",
+			newKey: "test",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("test", "hello world")
+				expectedMap.PutInt("test2", 3)
+				expectedMap.PutEmptySlice("test3").AppendEmpty().SetBool(true)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMap := pcommon.NewMap()
+			input.CopyTo(scenarioMap)
+
+			exprFunc, err := CopyKey[pcommon.Map](target, tt.key, tt.newKey)
+			assert.NoError(t, err)
+
+			_, err = exprFunc(scenarioMap)
+			assert.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected.AsRaw(), scenarioMap.AsRaw())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ExtractPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		pattern  string
+		expected map[string]interface{}
+	}{
+		{
+			name:    "named captures",
+			value:   "GET /api/users 200 12ms",
+			pattern: `^(?P<method>\w+) (?P<path>\S+) (?P<status>\d+)`,
+			expected: map[string]interface{}{
+				"method": "GET",
+				"path":   "/api/users",
+				"status": "200",
+			},
+		},
+		{
+			name:    "unnamed captures are ignored",
+			value:   "user=bear id=42",
+			pattern: `user=(\w+) id=(?P<id>\d+)`,
+			expected: map[string]interface{}{
+				"id": "42",
+			},
+		},
+		{
+			name:    "optional capture not matched",
+			value:   "level=info",
+			pattern: `level=(?P<level>\w+)( code=(?P<code>\d+))?`,
+			expected: map[string]interface{}{
+				"level": "info",
+				"code":  "",
+			},
+		},
+		{
+			name:    "no match",
+			value:   "nothing to see",
+			pattern: `(?P<number>\d+)`,
+		},
+		{
+			name:    "not a string",
+			value:   int64(1),
+			pattern: `(?P<number>\d+)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ExtractPatterns[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.pattern)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			if tt.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_ExtractPatterns_validation(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{}
+	_, err := ExtractPatterns[interface{}](target, `(\d+`)
+	assert.Error(t, err)
+	_, err = ExtractPatterns[interface{}](target, `(\d+)`)
+	assert.Error(t, err)
+}
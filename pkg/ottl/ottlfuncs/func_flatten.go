@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Flatten[K any](target ottl.Getter[K], prefix string, depth int64) (ottl.ExprFunc[K], error) {
+	if depth < 0 {
+		return nil, fmt.Errorf("invalid depth %d for flatten function, it must not be negative", depth)
+	}
+
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		attrs, ok := val.(pcommon.Map)
+		if !ok {
+			return nil, nil
+		}
+
+		result := pcommon.NewMap()
+		flattenMap(attrs, result, prefix, 1, depth)
+		result.CopyTo(attrs)
+		return nil, nil
+	}, nil
+}
+
+func flattenMap(m pcommon.Map, result pcommon.Map, prefix string, currentDepth int64, maxDepth int64) {
+	m.Range(func(key string, value pcommon.Value) bool {
+		flattenValue(value, result, flattenedKey(prefix, key), currentDepth, maxDepth)
+		return true
+	})
+}
+
+func flattenValue(value pcommon.Value, result pcommon.Map, key string, currentDepth int64, maxDepth int64) {
+	// empty maps and slices are kept, as there would be nothing left of them once flattened
+	canFlatten := maxDepth == 0 || currentDepth <= maxDepth
+	switch {
+	case canFlatten && value.Type() == pcommon.ValueTypeMap && value.Map().Len() > 0:
+		flattenMap(value.Map(), result, key, currentDepth+1, maxDepth)
+	case canFlatten && value.Type() == pcommon.ValueTypeSlice && value.Slice().Len() > 0:
+		for i := 0; i < value.Slice().Len(); i++ {
+			flattenValue(value.Slice().At(i), result, flattenedKey(key, strconv.Itoa(i)), currentDepth+1, maxDepth)
+		}
+	default:
+		value.CopyTo(result.PutEmpty(key))
+	}
+}
+
+func flattenedKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
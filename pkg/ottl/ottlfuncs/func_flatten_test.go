@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_flatten(t *testing.T) {
+	input := map[string]interface{}{
+		"name": "bear",
+		"address": map[string]interface{}{
+			"street": "oak",
+			"geo": map[string]interface{}{
+				"lat": 1.5,
+			},
+		},
+		"tags":  []interface{}{"a", map[string]interface{}{"b": "c"}},
+		"empty": map[string]interface{}{},
+	}
+
+	target := &ottl.StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx pcommon.Map) (interface{}, error) {
+			return ctx, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		prefix   string
+		depth    int64
+		expected map[string]interface{}
+	}{
+		{
+			name: "flatten everything",
+			expected: map[string]interface{}{
+				"name":            "bear",
+				"address.street":  "oak",
+				"address.geo.lat": 1.5,
+				"tags.0":          "a",
+				"tags.1.b":        "c",
+				"empty":           map[string]interface{}{},
+			},
+		},
+		{
+			name:   "with prefix",
+			prefix: "k8s",
+			expected: map[string]interface{}{
+				"k8s.name":            "bear",
+				"k8s.address.street":  "oak",
+				"k8s.address.geo.lat": 1.5,
+				"k8s.tags.0":          "a",
+				"k8s.tags.1.b":        "c",
+				"k8s.empty":           map[string]interface{}{},
+			},
+		},
+		{
+			name:  "limited depth",
+			depth: 1,
+			expected: map[string]interface{}{
+				"name":           "bear",
+				"address.street": "oak",
+				"address.geo": map[string]interface{}{
+					"lat": 1.5,
+				},
+				"tags.0": "a",
+				"tags.1": map[string]interface{}{"b": "c"},
+				"empty":  map[string]interface{}{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMap := pcommon.NewMap()
+			scenarioMap.FromRaw(input)
+
+			exprFunc, err := Flatten[pcommon.Map](target, tt.prefix, tt.depth)
+			assert.NoError(t, err)
+
+			_, err = exprFunc(scenarioMap)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, scenarioMap.AsRaw())
+		})
+	}
+}
+
+func Test_flatten_bad_input(t *testing.T) {
+	input := pcommon.NewValueStr("not a map")
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			return ctx, nil
+		},
+	}
+
+	exprFunc, err := Flatten[interface{}](target, "", 0)
+	assert.NoError(t, err)
+	result, err := exprFunc(input)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, pcommon.NewValueStr("not a map"), input)
+
+	_, err = Flatten[interface{}](target, "", -1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_MergeMaps(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutStr("attr1", "value1")
+	input.PutInt("attr2", 2)
+
+	source := pcommon.NewMap()
+	source.PutStr("attr1", "overwritten")
+	source.PutEmptyMap("attr3").PutBool("nested", true)
+
+	target := &ottl.StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx pcommon.Map) (interface{}, error) {
+			return ctx, nil
+		},
+	}
+	sourceGetter := &ottl.StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx pcommon.Map) (interface{}, error) {
+			return source, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		want     func(pcommon.Map)
+	}{
+		{
+			name:     "insert",
+			strategy: "insert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("attr1", "value1")
+				expectedMap.PutInt("attr2", 2)
+				expectedMap.PutEmptyMap("attr3").PutBool("nested", true)
+			},
+		},
+		{
+			name:     "update",
+			strategy: "update",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("attr1", "overwritten")
+				expectedMap.PutInt("attr2", 2)
+			},
+		},
+		{
+			name:     "upsert",
+			strategy: "upsert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("attr1", "overwritten")
+				expectedMap.PutInt("attr2", 2)
+				expectedMap.PutEmptyMap("attr3").PutBool("nested", true)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMap := pcommon.NewMap()
+			input.CopyTo(scenarioMap)
+
+			exprFunc, err := MergeMaps[pcommon.Map](target, sourceGetter, tt.strategy)
+			assert.NoError(t, err)
+
+			_, err = exprFunc(scenarioMap)
+			assert.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected.AsRaw(), scenarioMap.AsRaw())
+		})
+	}
+}
+
+func Test_MergeMaps_same_map(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutStr("attr1", "value1")
+	input.PutEmptyMap("attr2").PutStr("nested", "value2")
+	input.PutEmptySlice("attr3").AppendEmpty().SetInt(3)
+
+	target := &ottl.StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx pcommon.Map) (interface{}, error) {
+			return ctx, nil
+		},
+	}
+
+	exprFunc, err := MergeMaps[pcommon.Map](target, target, "upsert")
+	assert.NoError(t, err)
+
+	_, err = exprFunc(input)
+	assert.NoError(t, err)
+
+	expected := pcommon.NewMap()
+	expected.PutStr("attr1", "value1")
+	expected.PutEmptyMap("attr2").PutStr("nested", "value2")
+	expected.PutEmptySlice("attr3").AppendEmpty().SetInt(3)
+
+	assert.Equal(t, expected.AsRaw(), input.AsRaw())
+}
+
+func Test_MergeMaps_nested_source(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutStr("attr1", "value1")
+	nested := input.PutEmptyMap("nested")
+	nested.PutStr("attr1", "overwritten")
+	nested.PutStr("attr2", "value2")
+
+	target := &ottl.StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx pcommon.Map) (interface{}, error) {
+			return ctx, nil
+		},
+	}
+	source := &ottl.StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx pcommon.Map) (interface{}, error) {
+			val, _ := ctx.Get("nested")
+			return val.Map(), nil
+		},
+	}
+
+	exprFunc, err := MergeMaps[pcommon.Map](target, source, "upsert")
+	assert.NoError(t, err)
+
+	_, err = exprFunc(input)
+	assert.NoError(t, err)
+
+	expected := pcommon.NewMap()
+	expected.PutStr("attr1", "overwritten")
+	expectedNested := expected.PutEmptyMap("nested")
+	expectedNested.PutStr("attr1", "overwritten")
+	expectedNested.PutStr("attr2", "value2")
+	expected.PutStr("attr2", "value2")
+
+	assert.Equal(t, expected.AsRaw(), input.AsRaw())
+}
+
+func Test_MergeMaps_bad_input(t *testing.T) {
+	input := pcommon.NewValueStr("not a map")
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			return ctx, nil
+		},
+	}
+
+	exprFunc, err := MergeMaps[interface{}](target, target, "upsert")
+	assert.NoError(t, err)
+	result, err := exprFunc(input)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, pcommon.NewValueStr("not a map"), input)
+}
+
+func Test_MergeMaps_validation(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{}
+	_, err := MergeMaps[interface{}](target, target, "replace")
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:  "flat object",
+			value: `{"message": "hello", "count": 3, "success": true, "ratio": 0.5}`,
+			expected: map[string]interface{}{
+				"message": "hello",
+				"count":   float64(3),
+				"success": true,
+				"ratio":   0.5,
+			},
+		},
+		{
+			name:  "nested object and array",
+			value: `{"http": {"method": "GET", "codes": [200, 404]}, "empty": null}`,
+			expected: map[string]interface{}{
+				"http": map[string]interface{}{
+					"method": "GET",
+					"codes":  []interface{}{float64(200), float64(404)},
+				},
+				"empty": nil,
+			},
+		},
+		{
+			name:  "not an object",
+			value: `["a", "b"]`,
+		},
+		{
+			name:  "invalid json",
+			value: `{"message": `,
+		},
+		{
+			name:  "not a string",
+			value: int64(1),
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseJSON[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			if tt.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func ParseKeyValue[K any](target ottl.Getter[K], delimiter string, pairDelimiter string) (ottl.ExprFunc[K], error) {
+	if delimiter == "" || pairDelimiter == "" {
+		return nil, fmt.Errorf("the delimiters supplied to ParseKeyValue must not be empty")
+	}
+	if delimiter == pairDelimiter {
+		return nil, fmt.Errorf("the delimiter and the pair delimiter supplied to ParseKeyValue must be different, got %q for both", delimiter)
+	}
+
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		valStr, ok := val.(string)
+		if !ok {
+			return nil, nil
+		}
+
+		result := pcommon.NewMap()
+		for _, pair := range splitUnquoted(valStr, pairDelimiter) {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			key, value, found := strings.Cut(pair, delimiter)
+			if !found {
+				return nil, nil
+			}
+			result.PutStr(unquote(strings.TrimSpace(key)), unquote(strings.TrimSpace(value)))
+		}
+		return result, nil
+	}, nil
+}
+
+// splitUnquoted splits s around each instance of sep that isn't enclosed in single or double quotes.
+func splitUnquoted(s string, sep string) []string {
+	var (
+		parts []string
+		quote rune
+		start int
+	)
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case i >= start && strings.HasPrefix(s[i:], sep):
+			parts = append(parts, s[start:i])
+			start = i + len(sep)
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquote removes the single or double quotes surrounding s, if any.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseKeyValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         interface{}
+		delimiter     string
+		pairDelimiter string
+		expected      map[string]interface{}
+	}{
+		{
+			name:          "simple pairs",
+			value:         "name=bear kind=animal count=3",
+			delimiter:     "=",
+			pairDelimiter: " ",
+			expected: map[string]interface{}{
+				"name":  "bear",
+				"kind":  "animal",
+				"count": "3",
+			},
+		},
+		{
+			name:          "quoted values",
+			value:         `msg="hello world" path='/a b' eq="x=y"`,
+			delimiter:     "=",
+			pairDelimiter: " ",
+			expected: map[string]interface{}{
+				"msg":  "hello world",
+				"path": "/a b",
+				"eq":   "x=y",
+			},
+		},
+		{
+			name:          "multi-character delimiters",
+			value:         "a: 1 || b: 2 ||  c: ",
+			delimiter:     ":",
+			pairDelimiter: "||",
+			expected: map[string]interface{}{
+				"a": "1",
+				"b": "2",
+				"c": "",
+			},
+		},
+		{
+			name:          "pair without delimiter",
+			value:         "a=1 b",
+			delimiter:     "=",
+			pairDelimiter: " ",
+		},
+		{
+			name:          "not a string",
+			value:         int64(1),
+			delimiter:     "=",
+			pairDelimiter: " ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseKeyValue[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.delimiter, tt.pairDelimiter)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			if tt.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_ParseKeyValue_validation(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{}
+	_, err := ParseKeyValue[interface{}](target, "", " ")
+	assert.Error(t, err)
+	_, err = ParseKeyValue[interface{}](target, "=", "=")
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// The keys of the map returned by ParseURL, following the semantic conventions for URLs.
+const (
+	urlOriginal  = "url.original"
+	urlScheme    = "url.scheme"
+	urlDomain    = "url.domain"
+	urlPort      = "url.port"
+	urlPath      = "url.path"
+	urlExtension = "url.extension"
+	urlQuery     = "url.query"
+	urlFragment  = "url.fragment"
+	urlUsername  = "url.username"
+	urlPassword  = "url.password"
+)
+
+func ParseURL[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		valStr, ok := val.(string)
+		if !ok || valStr == "" {
+			return nil, nil
+		}
+
+		parsed, err := url.Parse(valStr)
+		if err != nil {
+			return nil, nil
+		}
+
+		result := pcommon.NewMap()
+		result.PutStr(urlOriginal, valStr)
+		putIfNotEmpty(result, urlScheme, parsed.Scheme)
+		putIfNotEmpty(result, urlDomain, parsed.Hostname())
+		if port, err := strconv.ParseInt(parsed.Port(), 10, 64); err == nil {
+			result.PutInt(urlPort, port)
+		}
+		putIfNotEmpty(result, urlPath, parsed.Path)
+		if ext := path.Ext(parsed.Path); len(ext) > 1 {
+			result.PutStr(urlExtension, ext[1:])
+		}
+		putIfNotEmpty(result, urlQuery, parsed.RawQuery)
+		putIfNotEmpty(result, urlFragment, parsed.Fragment)
+		if parsed.User != nil {
+			putIfNotEmpty(result, urlUsername, parsed.User.Username())
+			if password, ok := parsed.User.Password(); ok {
+				putIfNotEmpty(result, urlPassword, password)
+			}
+		}
+		return result, nil
+	}, nil
+}
+
+func putIfNotEmpty(m pcommon.Map, key string, value string) {
+	if value != "" {
+		m.PutStr(key, value)
+	}
+}
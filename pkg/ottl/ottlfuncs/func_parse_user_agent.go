@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// The keys of the map returned by ParseUserAgent, following the semantic conventions.
+const (
+	userAgentOriginal = "user_agent.original"
+	userAgentName     = "user_agent.name"
+	userAgentVersion  = "user_agent.version"
+	osName            = "os.name"
+	osVersion         = "os.version"
+)
+
+// userAgentRule identifies an agent by a regex capturing its version.
+type userAgentRule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// Browsers include the tokens of the browsers they are based on, so the rules are ordered from the most specific one.
+var userAgentRules = []userAgentRule{
+	{"Edge", regexp.MustCompile(`\bEdg(?:e|A|iOS)?/([\d.]+)`)},
+	{"Opera", regexp.MustCompile(`\bOPR/([\d.]+)`)},
+	{"Samsung Internet", regexp.MustCompile(`\bSamsungBrowser/([\d.]+)`)},
+	{"Firefox", regexp.MustCompile(`\b(?:Firefox|FxiOS)/([\d.]+)`)},
+	{"Chrome", regexp.MustCompile(`\b(?:Chrome|CriOS)/([\d.]+)`)},
+	{"Safari", regexp.MustCompile(`\bVersion/([\d.]+).*\bSafari/`)},
+	{"Internet Explorer", regexp.MustCompile(`\bMSIE ([\d.]+)|\bTrident/.*\brv:([\d.]+)`)},
+	{"Googlebot", regexp.MustCompile(`\bGooglebot/([\d.]+)`)},
+	{"Bingbot", regexp.MustCompile(`\bbingbot/([\d.]+)`)},
+}
+
+var (
+	// productPattern matches the first product of a user agent, such as curl/7.85.0
+	productPattern = regexp.MustCompile(`^([A-Za-z][\w.-]*)/([\w.-]+)`)
+
+	windowsPattern = regexp.MustCompile(`\bWindows NT ([\d.]+)`)
+	androidPattern = regexp.MustCompile(`\bAndroid ([\d.]+)`)
+	iosPattern     = regexp.MustCompile(`\b(?:iPhone|CPU) OS ([\d_]+)`)
+	macOSPattern   = regexp.MustCompile(`\bMac OS X ([\d_.]+)`)
+)
+
+// windowsVersions maps the versions of Windows NT to their marketing versions.
+var windowsVersions = map[string]string{
+	"10.0": "10",
+	"6.3":  "8.1",
+	"6.2":  "8",
+	"6.1":  "7",
+	"6.0":  "Vista",
+	"5.1":  "XP",
+}
+
+func ParseUserAgent[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		valStr, ok := val.(string)
+		if !ok || valStr == "" {
+			return nil, nil
+		}
+
+		result := pcommon.NewMap()
+		result.PutStr(userAgentOriginal, valStr)
+		name, version := parseUserAgentName(valStr)
+		putIfNotEmpty(result, userAgentName, name)
+		putIfNotEmpty(result, userAgentVersion, version)
+		name, version = parseUserAgentOS(valStr)
+		putIfNotEmpty(result, osName, name)
+		putIfNotEmpty(result, osVersion, version)
+		return result, nil
+	}, nil
+}
+
+// parseUserAgentName returns the name and version of the browser, bot or tool sending the user agent.
+func parseUserAgentName(userAgent string) (string, string) {
+	for _, rule := range userAgentRules {
+		if match := rule.pattern.FindStringSubmatch(userAgent); match != nil {
+			for _, version := range match[1:] {
+				if version != "" {
+					return rule.name, version
+				}
+			}
+			return rule.name, ""
+		}
+	}
+	// Tools such as curl only send their own product
+	if match := productPattern.FindStringSubmatch(userAgent); match != nil && match[1] != "Mozilla" {
+		return match[1], match[2]
+	}
+	return "", ""
+}
+
+// parseUserAgentOS returns the name and version of the operating system found in the user agent.
+func parseUserAgentOS(userAgent string) (string, string) {
+	if match := windowsPattern.FindStringSubmatch(userAgent); match != nil {
+		if version, ok := windowsVersions[match[1]]; ok {
+			return "Windows", version
+		}
+		return "Windows", match[1]
+	}
+	if match := androidPattern.FindStringSubmatch(userAgent); match != nil {
+		return "Android", match[1]
+	}
+	if match := iosPattern.FindStringSubmatch(userAgent); match != nil {
+		return "iOS", strings.ReplaceAll(match[1], "_", ".")
+	}
+	if match := macOSPattern.FindStringSubmatch(userAgent); match != nil {
+		return "macOS", strings.ReplaceAll(match[1], "_", ".")
+	}
+	switch {
+	case strings.Contains(userAgent, "CrOS"):
+		return "Chrome OS", ""
+	case strings.Contains(userAgent, "Linux"):
+		return "Linux", ""
+	}
+	return "", ""
+}
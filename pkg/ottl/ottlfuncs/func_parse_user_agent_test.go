@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:  "chrome on windows",
+			value: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Safari/537.36",
+			expected: map[string]interface{}{
+				"user_agent.original": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Safari/537.36",
+				"user_agent.name":     "Chrome",
+				"user_agent.version":  "107.0.0.0",
+				"os.name":             "Windows",
+				"os.version":          "10",
+			},
+		},
+		{
+			name:  "edge",
+			value: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Safari/537.36 Edg/107.0.1418.42",
+			expected: map[string]interface{}{
+				"user_agent.original": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Safari/537.36 Edg/107.0.1418.42",
+				"user_agent.name":     "Edge",
+				"user_agent.version":  "107.0.1418.42",
+				"os.name":             "Windows",
+				"os.version":          "10",
+			},
+		},
+		{
+			name:  "safari on iphone",
+			value: "Mozilla/5.0 (iPhone; CPU iPhone OS 16_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.1 Mobile/15E148 Safari/604.1",
+			expected: map[string]interface{}{
+				"user_agent.original": "Mozilla/5.0 (iPhone; CPU iPhone OS 16_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.1 Mobile/15E148 Safari/604.1",
+				"user_agent.name":     "Safari",
+				"user_agent.version":  "16.1",
+				"os.name":             "iOS",
+				"os.version":          "16.1",
+			},
+		},
+		{
+			name:  "firefox on linux",
+			value: "Mozilla/5.0 (X11; Linux x86_64; rv:106.0) Gecko/20100101 Firefox/106.0",
+			expected: map[string]interface{}{
+				"user_agent.original": "Mozilla/5.0 (X11; Linux x86_64; rv:106.0) Gecko/20100101 Firefox/106.0",
+				"user_agent.name":     "Firefox",
+				"user_agent.version":  "106.0",
+				"os.name":             "Linux",
+			},
+		},
+		{
+			name:  "internet explorer 11",
+			value: "Mozilla/5.0 (Windows NT 6.1; Trident/7.0; rv:11.0) like Gecko",
+			expected: map[string]interface{}{
+				"user_agent.original": "Mozilla/5.0 (Windows NT 6.1; Trident/7.0; rv:11.0) like Gecko",
+				"user_agent.name":     "Internet Explorer",
+				"user_agent.version":  "11.0",
+				"os.name":             "Windows",
+				"os.version":          "7",
+			},
+		},
+		{
+			name:  "chrome on android",
+			value: "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Mobile Safari/537.36",
+			expected: map[string]interface{}{
+				"user_agent.original": "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Mobile Safari/537.36",
+				"user_agent.name":     "Chrome",
+				"user_agent.version":  "107.0.0.0",
+				"os.name":             "Android",
+				"os.version":          "13",
+			},
+		},
+		{
+			name:  "tool",
+			value: "curl/7.85.0",
+			expected: map[string]interface{}{
+				"user_agent.original": "curl/7.85.0",
+				"user_agent.name":     "curl",
+				"user_agent.version":  "7.85.0",
+			},
+		},
+		{
+			name:  "unknown",
+			value: "Mozilla/5.0 (compatible)",
+			expected: map[string]interface{}{
+				"user_agent.original": "Mozilla/5.0 (compatible)",
+			},
+		},
+		{
+			name:  "empty string",
+			value: "",
+		},
+		{
+			name:  "not a string",
+			value: int64(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseUserAgent[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			if tt.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
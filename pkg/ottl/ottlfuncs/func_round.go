@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Round[K any](target ottl.Getter[K], precision int64) (ottl.ExprFunc[K], error) {
+	if precision < 0 {
+		return nil, fmt.Errorf("invalid precision %d for Round function, it must not be negative", precision)
+	}
+	scale := math.Pow10(int(precision))
+
+	return func(ctx K) (interface{}, error) {
+		value, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		switch value := value.(type) {
+		case float64:
+			return math.Round(value*scale) / scale, nil
+		case int64:
+			return value, nil
+		default:
+			return nil, nil
+		}
+	}, nil
+}
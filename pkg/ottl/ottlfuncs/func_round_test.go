@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Round(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		precision int64
+		expected  interface{}
+	}{
+		{
+			name:      "float64 to integer",
+			value:     float64(2.5),
+			precision: 0,
+			expected:  float64(3),
+		},
+		{
+			name:      "float64 with precision",
+			value:     float64(3.14159),
+			precision: 2,
+			expected:  float64(3.14),
+		},
+		{
+			name:      "negative float64",
+			value:     float64(-1.005),
+			precision: 1,
+			expected:  float64(-1),
+		},
+		{
+			name:      "int64",
+			value:     int64(42),
+			precision: 3,
+			expected:  int64(42),
+		},
+		{
+			name:      "string",
+			value:     "2.5",
+			precision: 0,
+			expected:  nil,
+		},
+		{
+			name:      "nil",
+			value:     nil,
+			precision: 0,
+			expected:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Round[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.precision)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Round_validation(t *testing.T) {
+	_, err := Round[interface{}](&ottl.StandardGetSetter[interface{}]{}, -1)
+	assert.Error(t, err)
+}
@@ -52,6 +52,16 @@ func (s *Statement[K]) Execute(ctx K) (any, bool, error) {
 	return result, condition, nil
 }
 
+// Condition holds a top level condition. A condition is a boolean expression used to match telemetry data.
+type Condition[K any] struct {
+	condition boolExpressionEvaluator[K]
+}
+
+// Eval returns true if the condition is met for the given context and false otherwise.
+func (c *Condition[K]) Eval(ctx K) (bool, error) {
+	return c.condition(ctx)
+}
+
 func NewParser[K any](functions map[string]interface{}, pathParser PathExpressionParser[K], enumParser EnumParser, telemetrySettings component.TelemetrySettings) Parser[K] {
 	return Parser[K]{
 		functions:         functions,
@@ -93,7 +103,37 @@ func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error
 	return parsedStatements, nil
 }
 
-var parser = newParser()
+// ParseConditions parses boolean expressions, such as the ones found in the where clause of a statement,
+// into conditions that can be evaluated on their own.
+func (p *Parser[K]) ParseConditions(conditions []string) ([]*Condition[K], error) {
+	var parsedConditions []*Condition[K]
+	var errors error
+
+	for _, condition := range conditions {
+		parsed, err := parseCondition(condition)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		expression, err := p.newBooleanExpressionEvaluator(parsed.Expression)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		parsedConditions = append(parsedConditions, &Condition[K]{
+			condition: expression,
+		})
+	}
+
+	if errors != nil {
+		return nil, errors
+	}
+	return parsedConditions, nil
+}
+
+var parser = newParser[parsedStatement]()
+
+var conditionParser = newParser[parsedCondition]()
 
 func parseStatement(raw string) (*parsedStatement, error) {
 	parsed, err := parser.ParseString("", raw)
@@ -103,14 +143,25 @@ func parseStatement(raw string) (*parsedStatement, error) {
 	return parsed, nil
 }
 
-// newParser returns a parser that can be used to read a string into a parsedStatement. An error will be returned if the string
-// is not formatted for the DSL.
-func newParser() *participle.Parser[parsedStatement] {
+func parseCondition(raw string) (*parsedCondition, error) {
+	parsed, err := conditionParser.ParseString("", raw)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// newParser returns a parser that can be used to read a string into a parsedStatement or a parsedCondition.
+// An error will be returned if the string is not formatted for the DSL.
+func newParser[G any]() *participle.Parser[G] {
 	lex := buildLexer()
-	parser, err := participle.Build[parsedStatement](
+	parser, err := participle.Build[G](
 		participle.Lexer(lex),
 		participle.Unquote("String"),
 		participle.Elide("whitespace"),
+		// a converter used as a boolean value can only be told apart from the left side of a comparison
+		// once the whole invocation has been read
+		participle.UseLookahead(participle.MaxLookahead),
 	)
 	if err != nil {
 		panic("Unable to initialize parser; this is a programming error in the transformprocessor:" + err.Error())
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
 )
@@ -944,6 +946,146 @@ func Test_parseWhere(t *testing.T) {
 				},
 			}),
 		},
+		{
+			statement: `not true`,
+			expected: setNameTest(&booleanExpression{
+				Left: &term{
+					Left: &booleanValue{
+						Negation:  ottltest.Strp("not"),
+						ConstExpr: booleanp(true),
+					},
+				},
+			}),
+		},
+		{
+			statement: `not (true or false) and false`,
+			expected: setNameTest(&booleanExpression{
+				Left: &term{
+					Left: &booleanValue{
+						Negation: ottltest.Strp("not"),
+						SubExpr: &booleanExpression{
+							Left: &term{
+								Left: &booleanValue{
+									ConstExpr: booleanp(true),
+								},
+							},
+							Right: []*opOrTerm{
+								{
+									Operator: "or",
+									Term: &term{
+										Left: &booleanValue{
+											ConstExpr: booleanp(false),
+										},
+									},
+								},
+							},
+						},
+					},
+					Right: []*opAndBooleanValue{
+						{
+							Operator: "and",
+							Value: &booleanValue{
+								ConstExpr: booleanp(false),
+							},
+						},
+					},
+				},
+			}),
+		},
+		{
+			statement: `IsMatch(name, "foo") and not IsMatch(name, "bar")`,
+			expected: setNameTest(&booleanExpression{
+				Left: &term{
+					Left: &booleanValue{
+						Converter: &invocation{
+							Function: "IsMatch",
+							Arguments: []value{
+								{
+									Path: &Path{
+										Fields: []Field{
+											{
+												Name: "name",
+											},
+										},
+									},
+								},
+								{
+									String: ottltest.Strp("foo"),
+								},
+							},
+						},
+					},
+					Right: []*opAndBooleanValue{
+						{
+							Operator: "and",
+							Value: &booleanValue{
+								Negation: ottltest.Strp("not"),
+								Converter: &invocation{
+									Function: "IsMatch",
+									Arguments: []value{
+										{
+											Path: &Path{
+												Fields: []Field{
+													{
+														Name: "name",
+													},
+												},
+											},
+										},
+										{
+											String: ottltest.Strp("bar"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
+		{
+			statement: `Concat(["a", name], "") == "ab"`,
+			expected: setNameTest(&booleanExpression{
+				Left: &term{
+					Left: &booleanValue{
+						Comparison: &comparison{
+							Left: value{
+								Invocation: &invocation{
+									Function: "Concat",
+									Arguments: []value{
+										{
+											List: &list{
+												Values: []value{
+													{
+														String: ottltest.Strp("a"),
+													},
+													{
+														Path: &Path{
+															Fields: []Field{
+																{
+																	Name: "name",
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+										{
+											String: ottltest.Strp(""),
+										},
+									},
+								},
+							},
+							Op: EQ,
+							Right: value{
+								String: ottltest.Strp("ab"),
+							},
+						},
+					},
+				},
+			}),
+		},
 	}
 
 	// create a test name that doesn't confuse vscode so we can rerun tests with one click
@@ -999,6 +1141,17 @@ func Test_parseStatement(t *testing.T) {
 		{`drop() where ==`, true},
 		{`drop() where == animal`, true},
 		{`drop() where attributes["path"] == "/healthcheck"`, false},
+		{`drop() where not attributes["path"] == "/healthcheck"`, false},
+		{`drop() where not not true`, true},
+		{`drop() where not`, true},
+		{`drop() where IsMatch(attributes["path"], "/health.*")`, false},
+		{`drop() where IsMatch(attributes["path"], "/health.*") == false`, false},
+		{`drop() where IsMatch(attributes["path"], "/health.*"`, true},
+		{`set(attributes["duration"], end_time_unix_nano - start_time_unix_nano)`, false},
+		{`set(attributes["ratio"], (attributes["a"] + 1) / Double(attributes["b"]))`, false},
+		{`drop() where attributes["bytes"] / 1048576 > 10`, false},
+		{`set(attributes["sum"], attributes["a"] +)`, true},
+		{`set(attributes["sum"], "a" + "b")`, true},
 	}
 	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
 	for _, tt := range tests {
@@ -1013,6 +1166,72 @@ func Test_parseStatement(t *testing.T) {
 	}
 }
 
+func Test_ParseConditions(t *testing.T) {
+	functions := map[string]interface{}{
+		"Bool": func(b bool) (ExprFunc[interface{}], error) {
+			return func(interface{}) (interface{}, error) {
+				return b, nil
+			}, nil
+		},
+		"Name": func() (ExprFunc[interface{}], error) {
+			return func(ctx interface{}) (interface{}, error) {
+				return ctx, nil
+			}, nil
+		},
+	}
+	p := NewParser(functions, testParsePath, testParseEnum, component.TelemetrySettings{})
+
+	tests := []struct {
+		condition string
+		item      interface{}
+		want      bool
+	}{
+		{condition: `true`, want: true},
+		{condition: `not true`, want: false},
+		{condition: `not false and not false`, want: true},
+		{condition: `not (true or false)`, want: false},
+		{condition: `name == "bear"`, item: "bear", want: true},
+		{condition: `not name == "bear"`, item: "bear", want: false},
+		{condition: `not (name == "cat" or name == "dog")`, item: "bear", want: true},
+		{condition: `Bool(true)`, want: true},
+		{condition: `Bool(true) and not Bool(false)`, want: true},
+		{condition: `Bool(false) or name == "bear"`, item: "bear", want: true},
+		{condition: `Bool(true) == false`, want: false},
+	}
+	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
+	for _, tt := range tests {
+		name := pat.ReplaceAllString(tt.condition, "_")
+		t.Run(name, func(t *testing.T) {
+			conditions, err := p.ParseConditions([]string{tt.condition})
+			assert.NoError(t, err)
+			assert.Len(t, conditions, 1)
+
+			result, err := conditions[0].Eval(tt.item)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+
+	t.Run("converter not returning a bool", func(t *testing.T) {
+		conditions, err := p.ParseConditions([]string{`Name()`})
+		assert.NoError(t, err)
+
+		_, err = conditions[0].Eval("bear")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid conditions", func(t *testing.T) {
+		_, err := p.ParseConditions([]string{
+			`set(name, "bear")`,
+			`name ==`,
+			`Unknown()`,
+			`unknown == "bear"`,
+		})
+		assert.Error(t, err)
+		assert.Len(t, multierr.Errors(err), 4)
+	})
+}
+
 func Test_Execute(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -25,6 +25,9 @@ type FileAttributes struct {
 	Path         string
 	NameResolved string
 	PathResolved string
+	// Header holds the header lines of the file, separated by newlines,
+	// if header capture is configured
+	Header string
 }
 
 // resolveFileAttributes resolves file attributes
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionNone = ""
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+	compressionAuto = "auto"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+func validateCompression(compression string) error {
+	switch compression {
+	case compressionNone, compressionGzip, compressionZstd, compressionAuto:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression '%s'", compression)
+	}
+}
+
+// resolveCompression determines the compression of a file. When the configured
+// compression is "auto", the file extension is checked first, then its magic bytes.
+func resolveCompression(compression string, file *os.File) string {
+	if compression != compressionAuto {
+		return compression
+	}
+
+	switch {
+	case strings.HasSuffix(file.Name(), ".gz"):
+		return compressionGzip
+	case strings.HasSuffix(file.Name(), ".zst"):
+		return compressionZstd
+	}
+
+	header := make([]byte, len(zstdMagic))
+	n, err := file.ReadAt(header, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return compressionNone
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return compressionGzip
+	case bytes.HasPrefix(header, zstdMagic):
+		return compressionZstd
+	}
+	return compressionNone
+}
+
+// newDecompressor wraps the beginning of the file in a reader of its uncompressed content.
+func newDecompressor(compression string, file *os.File) (io.ReadCloser, error) {
+	src := io.NewSectionReader(file, 0, 1<<63-1)
+	switch compression {
+	case compressionGzip:
+		return gzip.NewReader(src)
+	case compressionZstd:
+		dec, err := zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+}
+
+// newDecompressedFingerprint creates a fingerprint from the first bytes of the uncompressed
+// content, so that a compressed copy of a file is identified as the file itself.
+func newDecompressedFingerprint(file *os.File, compression string, size int) (*Fingerprint, error) {
+	dec, err := newDecompressor(compression, file)
+	if err != nil {
+		if errors.Is(err, io.EOF) || isTruncated(err) {
+			// Not enough data has been written to read the compression header yet
+			return &Fingerprint{FirstBytes: []byte{}}, nil
+		}
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+	defer dec.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(dec, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !isTruncated(err) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+	return &Fingerprint{FirstBytes: buf[:n]}, nil
+}
+
+// isTruncated reports whether a decompression error was caused by reaching
+// the end of a compressed file that is still being written.
+func isTruncated(err error) bool {
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func writeGzip(t testing.TB, path string, s string) {
+	file := openFile(t, path)
+	w := gzip.NewWriter(file)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+}
+
+func writeZstd(t testing.TB, path string, s string) {
+	file := openFile(t, path)
+	w, err := zstd.NewWriter(file)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+}
+
+func TestResolveCompression(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+
+	gzipPath := filepath.Join(tempDir, "app.log.1.gz")
+	writeGzip(t, gzipPath, "testlog\n")
+	gzipNoExtPath := filepath.Join(tempDir, "app.log.1")
+	writeGzip(t, gzipNoExtPath, "testlog\n")
+	zstdPath := filepath.Join(tempDir, "app.log.2.zst")
+	writeZstd(t, zstdPath, "testlog\n")
+	zstdNoExtPath := filepath.Join(tempDir, "app.log.2")
+	writeZstd(t, zstdNoExtPath, "testlog\n")
+	plainPath := filepath.Join(tempDir, "app.log")
+	writeString(t, openFile(t, plainPath), "testlog\n")
+	emptyPath := filepath.Join(tempDir, "empty.log")
+	openFile(t, emptyPath)
+
+	cases := []struct {
+		name        string
+		compression string
+		path        string
+		expected    string
+	}{
+		{"AutoGzipExtension", compressionAuto, gzipPath, compressionGzip},
+		{"AutoGzipMagic", compressionAuto, gzipNoExtPath, compressionGzip},
+		{"AutoZstdExtension", compressionAuto, zstdPath, compressionZstd},
+		{"AutoZstdMagic", compressionAuto, zstdNoExtPath, compressionZstd},
+		{"AutoPlain", compressionAuto, plainPath, compressionNone},
+		{"AutoEmpty", compressionAuto, emptyPath, compressionNone},
+		{"None", compressionNone, gzipPath, compressionNone},
+		{"Explicit", compressionGzip, plainPath, compressionGzip},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			file, err := os.Open(tc.path)
+			require.NoError(t, err)
+			defer file.Close()
+			require.Equal(t, tc.expected, resolveCompression(tc.compression, file))
+		})
+	}
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		compression string
+		fileName    string
+		write       func(testing.TB, string, string)
+	}{
+		{"Gzip", compressionGzip, "app.log.1", writeGzip},
+		{"Zstd", compressionZstd, "app.log.1", writeZstd},
+		{"AutoGzip", compressionAuto, "app.log.1.gz", writeGzip},
+		{"AutoZstd", compressionAuto, "app.log.1.zst", writeZstd},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.compression
+			operator, emitCalls := buildTestManager(t, cfg)
+			operator.persister = testutil.NewMockPersister("test")
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			tc.write(t, filepath.Join(tempDir, tc.fileName), "testlog1\ntestlog2\n")
+
+			operator.poll(context.Background())
+			waitForToken(t, emitCalls, []byte("testlog1"))
+			waitForToken(t, emitCalls, []byte("testlog2"))
+
+			// The offset is tracked in uncompressed space, so nothing is read twice
+			operator.poll(context.Background())
+			expectNoTokens(t, emitCalls)
+		})
+	}
+}
+
+// TestReadIncompleteGzipFile tests that a compressed file which is still being written
+// is read up to the last complete token, and the rest is read once it is available
+func TestReadIncompleteGzipFile(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	file := openFile(t, filepath.Join(tempDir, "app.log.gz"))
+	w := gzip.NewWriter(file)
+	_, err := w.Write([]byte("testlog1\ntestlog2\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	_, err = w.Write([]byte("testlog3\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	expectNoTokens(t, emitCalls)
+}
+
+// TestCompressedRotation tests that a file which is compressed after
+// rotation is recognized by its uncompressed fingerprint and not read again
+func TestCompressedRotation(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	path := filepath.Join(tempDir, "app.log")
+	writeString(t, openFile(t, path), "testlog1\ntestlog2\n")
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	// Rotate and compress the file, adding a final entry that was
+	// written after the last poll
+	writeGzip(t, path+".1.gz", "testlog1\ntestlog2\ntestlog3\n")
+	require.NoError(t, os.Remove(path))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestStartAtEndCompressedFile(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = compressionGzip
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	writeGzip(t, filepath.Join(tempDir, "app.log.1"), "testlog1\ntestlog2\n")
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+// TestSkipUnchangedCompressedFile tests that a compressed file which was read to the end
+// is not decompressed again until its size or modification time change
+func TestSkipUnchangedCompressedFile(t *testing.T) {
+	t.Parallel()
+	f, emitChan := testReaderFactory(t)
+	f.readerConfig.compression = compressionGzip
+
+	path := filepath.Join(t.TempDir(), "app.log.1")
+	writeGzip(t, path, "testlog1\ntestlog2\n")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	r, err := f.newReaderBuilder().withFile(file).build()
+	require.NoError(t, err)
+	r.ReadToEnd(context.Background())
+	require.Equal(t, []byte("testlog1"), readToken(t, emitChan))
+	require.Equal(t, []byte("testlog2"), readToken(t, emitChan))
+	require.True(t, r.eof)
+	require.Equal(t, info.Size(), r.CompressedSize)
+
+	// Overwrite the content without changing the size or modification time,
+	// which would fail to be decompressed if the file were read again
+	require.NoError(t, os.WriteFile(path, make([]byte, info.Size()), 0600))
+	require.NoError(t, os.Chtimes(path, info.ModTime(), info.ModTime()))
+
+	file, err = os.Open(path)
+	require.NoError(t, err)
+	r, err = f.copy(r, file)
+	require.NoError(t, err)
+	defer r.Close()
+	r.ReadToEnd(context.Background())
+	require.True(t, r.eof)
+	expectNoTokens(t, emitChan)
+}
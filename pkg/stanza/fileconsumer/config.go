@@ -42,6 +42,9 @@ func NewConfig() *Config {
 		FingerprintSize:         DefaultFingerprintSize,
 		MaxLogSize:              defaultMaxLogSize,
 		MaxConcurrentFiles:      defaultMaxConcurrentFiles,
+		OnComplete: OnCompleteConfig{
+			IdlePeriod: defaultIdlePeriod,
+		},
 	}
 }
 
@@ -57,6 +60,9 @@ type Config struct {
 	FingerprintSize         helper.ByteSize       `mapstructure:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"`
+	OnComplete              OnCompleteConfig      `mapstructure:"on_complete,omitempty"`
+	Header                  HeaderConfig          `mapstructure:"header,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
 }
 
@@ -86,6 +92,17 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		}
 	}
 
+	if c.ExcludeOlderThan < 0 {
+		return nil, fmt.Errorf("`exclude_older_than` must not be negative")
+	}
+
+	ordering, err := c.OrderingCriteria.build()
+	if err != nil {
+		return nil, err
+	}
+	finder := c.Finder
+	finder.ordering = ordering
+
 	if c.MaxLogSize <= 0 {
 		return nil, fmt.Errorf("`max_log_size` must be positive")
 	}
@@ -100,9 +117,21 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, fmt.Errorf("`fingerprint_size` must be at least %d bytes", MinFingerprintSize)
 	}
 
+	if err := validateCompression(c.Compression); err != nil {
+		return nil, err
+	}
+
+	if err := c.OnComplete.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := c.Header.validate(); err != nil {
+		return nil, err
+	}
+
 	// Ensure that splitter is buildable
 	factory := newMultilineSplitterFactory(c.Splitter.EncodingConfig, c.Splitter.Flusher, c.Splitter.Multiline)
-	_, err := factory.Build(int(c.MaxLogSize))
+	_, err = factory.Build(int(c.MaxLogSize))
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +146,12 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
 
+	// Files found on the first poll are not read when starting at the end,
+	// so they would be completed without any of their content being emitted
+	if c.OnComplete.Action != onCompleteNone && !startAtBeginning {
+		return nil, fmt.Errorf("`on_complete` requires `start_at` to be 'beginning'")
+	}
+
 	return &Manager{
 		SugaredLogger: logger.With("component", "fileconsumer"),
 		cancel:        func() {},
@@ -125,15 +160,18 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 			readerConfig: &readerConfig{
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
+				compression:     c.Compression,
+				header:          c.Header.build(),
 				emit:            emit,
 			},
 			fromBeginning:   startAtBeginning,
 			splitterFactory: factory,
 			encodingConfig:  c.Splitter.EncodingConfig,
 		},
-		finder:        c.Finder,
+		finder:        finder,
 		roller:        newRoller(),
 		pollInterval:  c.PollInterval,
+		onComplete:    c.OnComplete,
 		maxBatchFiles: c.MaxConcurrentFiles / 2,
 		knownFiles:    make([]*Reader, 0, 10),
 		seenPaths:     make(map[string]struct{}, 100),
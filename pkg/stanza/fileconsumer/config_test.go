@@ -296,6 +296,58 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "compression_auto",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Compression = "auto"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "on_complete_move",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.StartAt = "beginning"
+					cfg.OnComplete = OnCompleteConfig{
+						Action:     "move",
+						Directory:  "/var/log/archive",
+						IdlePeriod: 5 * time.Minute,
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "exclude_older_than",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.ExcludeOlderThan = 24 * time.Hour
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "ordering_criteria",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.OrderingCriteria = OrderingCriteria{
+						Regex: `err\.(?P<time>\d{10})\.(?P<num>\d+)\.log`,
+						TopN:  2,
+						SortBy: []SortRule{
+							{RegexKey: "time", SortType: "timestamp", Layout: "%Y%m%d%H", Location: "UTC"},
+							{RegexKey: "num", SortType: "numeric", Ascending: true},
+						},
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "header_pattern",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Header = HeaderConfig{Pattern: "^#"}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "max_concurrent_large",
 				Expect: func() *mockOperatorConfig {
@@ -432,6 +484,103 @@ func TestBuild(t *testing.T) {
 			require.NoError,
 			func(t *testing.T, f *Manager) {},
 		},
+		{
+			"Compression",
+			func(f *Config) {
+				f.Compression = "gzip"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, "gzip", f.readerFactory.readerConfig.compression)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"OnComplete",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.OnComplete = OnCompleteConfig{Action: "delete", IdlePeriod: time.Second}
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, OnCompleteConfig{Action: "delete", IdlePeriod: time.Second}, f.onComplete)
+			},
+		},
+		{
+			"InvalidOnComplete",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.OnComplete = OnCompleteConfig{Action: "move"}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"OnCompleteStartAtEnd",
+			func(f *Config) {
+				f.StartAt = "end"
+				f.OnComplete = OnCompleteConfig{Action: "delete"}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"NegativeExcludeOlderThan",
+			func(f *Config) {
+				f.ExcludeOlderThan = -time.Hour
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"OrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					Regex:  `(?P<num>\d+)\.log`,
+					SortBy: []SortRule{{RegexKey: "num", SortType: "numeric"}},
+				}
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, []SortRule{{RegexKey: "num", SortType: "numeric"}}, f.finder.OrderingCriteria.SortBy)
+			},
+		},
+		{
+			"InvalidOrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					Regex:  `(?P<num>\d+)\.log`,
+					SortBy: []SortRule{{RegexKey: "value", SortType: "numeric"}},
+				}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"Header",
+			func(f *Config) {
+				f.Header = HeaderConfig{LineCount: 2}
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, 2, f.readerFactory.readerConfig.header.lineCount)
+			},
+		},
+		{
+			"InvalidHeader",
+			func(f *Config) {
+				f.Header = HeaderConfig{Pattern: "^(#"}
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidEncoding",
 			func(f *Config) {
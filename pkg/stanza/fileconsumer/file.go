@@ -42,6 +42,7 @@ type Manager struct {
 
 	pollInterval  time.Duration
 	maxBatchFiles int
+	onComplete    OnCompleteConfig
 
 	knownFiles []*Reader
 	seenPaths  map[string]struct{}
@@ -144,7 +145,17 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 
 	m.roller.roll(ctx, readers)
 	m.saveCurrent(readers)
-	m.syncLastPollFiles(ctx)
+	if err := m.syncLastPollFiles(ctx); err != nil {
+		m.Errorw("Failed to sync to database", zap.Error(err))
+		return
+	}
+
+	// Files are only completed once their offsets have been checkpointed
+	if m.completeFiles(readers) {
+		if err := m.syncLastPollFiles(ctx); err != nil {
+			m.Errorw("Failed to sync to database", zap.Error(err))
+		}
+	}
 }
 
 // makeReaders takes a list of paths, then creates readers from each of those paths,
@@ -266,14 +277,13 @@ func (m *Manager) findFingerprintMatch(fp *Fingerprint) (*Reader, bool) {
 const knownFilesKey = "knownFiles"
 
 // syncLastPollFiles syncs the most recent set of files to the database
-func (m *Manager) syncLastPollFiles(ctx context.Context) {
+func (m *Manager) syncLastPollFiles(ctx context.Context) error {
 	var buf bytes.Buffer
 	enc := json.NewEncoder(&buf)
 
 	// Encode the number of known files
 	if err := enc.Encode(len(m.knownFiles)); err != nil {
-		m.Errorw("Failed to encode known files", zap.Error(err))
-		return
+		return fmt.Errorf("encode known files: %w", err)
 	}
 
 	// Encode each known file
@@ -283,9 +293,7 @@ func (m *Manager) syncLastPollFiles(ctx context.Context) {
 		}
 	}
 
-	if err := m.persister.Set(ctx, knownFilesKey, buf.Bytes()); err != nil {
-		m.Errorw("Failed to sync to database", zap.Error(err))
-	}
+	return m.persister.Set(ctx, knownFilesKey, buf.Bytes())
 }
 
 // syncLastPollFiles loads the most recent set of files to the database
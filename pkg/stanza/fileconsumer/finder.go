@@ -15,12 +15,20 @@
 package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
 
 import (
+	"os"
+	"time"
+
 	"github.com/bmatcuk/doublestar/v3"
 )
 
 type Finder struct {
-	Include []string `mapstructure:"include,omitempty"`
-	Exclude []string `mapstructure:"exclude,omitempty"`
+	Include          []string         `mapstructure:"include,omitempty"`
+	Exclude          []string         `mapstructure:"exclude,omitempty"`
+	ExcludeOlderThan time.Duration    `mapstructure:"exclude_older_than,omitempty"`
+	OrderingCriteria OrderingCriteria `mapstructure:"ordering_criteria,omitempty"`
+
+	// ordering is the compiled form of the ordering criteria, which is only applied once the finder is built
+	ordering *orderingSettings
 }
 
 // FindFiles gets a list of paths given an array of glob patterns to include and exclude
@@ -46,5 +54,38 @@ func (f Finder) FindFiles() []string {
 		}
 	}
 
-	return all
+	if f.ExcludeOlderThan > 0 {
+		all = excludeOlderThan(all, f.ExcludeOlderThan)
+	}
+
+	return f.ordering.apply(all)
+}
+
+// matches reports whether the path matches the include patterns and none of the exclude patterns
+func (f Finder) matches(path string) bool {
+	for _, exclude := range f.Exclude {
+		if itMatches, _ := doublestar.PathMatch(exclude, path); itMatches {
+			return false
+		}
+	}
+	for _, include := range f.Include {
+		if itMatches, _ := doublestar.PathMatch(include, path); itMatches {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeOlderThan removes the files that have not been modified within the given duration
+func excludeOlderThan(paths []string, age time.Duration) []string {
+	cutoff := time.Now().Add(-age)
+	result := make([]string, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Before(cutoff) {
+			continue
+		}
+		result = append(result, path)
+	}
+	return result
 }
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// HeaderConfig configures which lines of a file are captured as its header.
+// Header lines are not emitted, but are made available to every entry read from the file.
+type HeaderConfig struct {
+	LineCount int    `mapstructure:"line_count,omitempty"`
+	Pattern   string `mapstructure:"pattern,omitempty"`
+}
+
+// Enabled returns whether header lines are captured
+func (c HeaderConfig) Enabled() bool {
+	return c.LineCount != 0 || c.Pattern != ""
+}
+
+func (c HeaderConfig) validate() error {
+	if c.LineCount < 0 {
+		return fmt.Errorf("`header.line_count` must not be negative")
+	}
+	if c.LineCount > 0 && c.Pattern != "" {
+		return fmt.Errorf("only one of `header.line_count` or `header.pattern` can be set")
+	}
+	if c.Pattern != "" {
+		if _, err := regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("compile `header.pattern`: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c HeaderConfig) build() *headerSettings {
+	if !c.Enabled() {
+		return nil
+	}
+	settings := &headerSettings{lineCount: c.LineCount}
+	if c.Pattern != "" {
+		settings.pattern = regexp.MustCompile(c.Pattern) // compile error checked in validate
+	}
+	return settings
+}
+
+// headerSettings is the compiled form of a HeaderConfig
+type headerSettings struct {
+	lineCount int
+	pattern   *regexp.Regexp
+}
+
+// processHeader returns true if the token is part of the file's header.
+//
+// With a line count, the header is the first lines of the file. With a pattern, the
+// header is a block of consecutive lines that match the pattern. A block that follows
+// other lines replaces the previous header, as W3C logs repeat their directives when
+// the writer restarts.
+func (r *Reader) processHeader(token []byte) bool {
+	if r.header == nil {
+		return false
+	}
+
+	if r.header.pattern != nil {
+		if !r.header.pattern.Match(token) {
+			r.HeaderComplete = r.HeaderComplete || len(r.Header) > 0
+			return false
+		}
+		if r.HeaderComplete {
+			r.Header = nil
+			r.HeaderComplete = false
+		}
+		r.appendHeader(token)
+		return true
+	}
+
+	if r.HeaderComplete {
+		return false
+	}
+	r.appendHeader(token)
+	r.HeaderComplete = len(r.Header) >= r.header.lineCount
+	return true
+}
+
+func (r *Reader) appendHeader(token []byte) {
+	r.Header = append(r.Header, string(token))
+
+	// Attributes of entries that were already emitted must not change
+	attrs := *r.fileAttributes
+	attrs.Header = strings.Join(r.Header, "\n")
+	r.fileAttributes = &attrs
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestHeaderConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		cfg         HeaderConfig
+		expectedErr string
+	}{
+		{"None", HeaderConfig{}, ""},
+		{"LineCount", HeaderConfig{LineCount: 1}, ""},
+		{"Pattern", HeaderConfig{Pattern: "^#"}, ""},
+		{"NegativeLineCount", HeaderConfig{LineCount: -1}, "`header.line_count` must not be negative"},
+		{"Both", HeaderConfig{LineCount: 1, Pattern: "^#"}, "only one of `header.line_count` or `header.pattern` can be set"},
+		{"InvalidPattern", HeaderConfig{Pattern: "^(#"}, "compile `header.pattern`: error parsing regexp: missing closing ): `^(#`"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.validate()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func buildHeaderManager(t *testing.T, dir string, startAt string, header HeaderConfig) (*Manager, chan *emitParams) {
+	cfg := NewConfig()
+	cfg.Include = []string{filepath.Join(dir, "*.log")}
+	cfg.StartAt = startAt
+	cfg.Header = header
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	t.Cleanup(func() {
+		require.NoError(t, operator.Stop())
+	})
+	return operator, emitCalls
+}
+
+func waitForHeaderToken(t *testing.T, c chan *emitParams, expectedToken string, expectedHeader string) {
+	call := waitForEmit(t, c)
+	require.Equal(t, expectedToken, string(call.token))
+	require.Equal(t, expectedHeader, call.attrs.Header)
+}
+
+func TestHeaderLineCount(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	operator, emitCalls := buildHeaderManager(t, tempDir, "beginning", HeaderConfig{LineCount: 1})
+
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("id,severity,message\n1,info,started\n"), 0600))
+	operator.poll(context.Background())
+	waitForHeaderToken(t, emitCalls, "1,info,started", "id,severity,message")
+	expectNoTokens(t, emitCalls)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	writeString(t, file, "2,error,stopped\n")
+	require.NoError(t, file.Close())
+	operator.poll(context.Background())
+	waitForHeaderToken(t, emitCalls, "2,error,stopped", "id,severity,message")
+}
+
+func TestHeaderPattern(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	operator, emitCalls := buildHeaderManager(t, tempDir, "beginning", HeaderConfig{Pattern: "^#"})
+
+	path := filepath.Join(tempDir, "u_ex230206.log")
+	content := "#Version: 1.0\n#Fields: date time c-ip\n2023-02-06 10:00:00 10.0.0.1\n" +
+		"#Version: 1.0\n#Fields: date time s-ip cs-method\n2023-02-06 11:00:00 10.0.0.2 GET\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	operator.poll(context.Background())
+
+	waitForHeaderToken(t, emitCalls, "2023-02-06 10:00:00 10.0.0.1", "#Version: 1.0\n#Fields: date time c-ip")
+	waitForHeaderToken(t, emitCalls, "2023-02-06 11:00:00 10.0.0.2 GET", "#Version: 1.0\n#Fields: date time s-ip cs-method")
+	expectNoTokens(t, emitCalls)
+}
+
+func TestHeaderStartAtEnd(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	operator, emitCalls := buildHeaderManager(t, tempDir, "end", HeaderConfig{LineCount: 1})
+
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("id,severity,message\n1,info,started\n"), 0600))
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	writeString(t, file, "2,error,stopped\n")
+	require.NoError(t, file.Close())
+	operator.poll(context.Background())
+	waitForHeaderToken(t, emitCalls, "2,error,stopped", "id,severity,message")
+}
+
+func TestHeaderRestoredFromCheckpoint(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persister := testutil.NewMockPersister("test")
+
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("id,severity,message\n1,info,started\n"), 0600))
+
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = HeaderConfig{LineCount: 1}
+	operator, emitCalls := buildTestManager(t, cfg)
+	require.NoError(t, operator.Start(persister))
+	waitForHeaderToken(t, emitCalls, "1,info,started", "id,severity,message")
+	require.NoError(t, operator.Stop())
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	writeString(t, file, "2,error,stopped\n")
+	require.NoError(t, file.Close())
+
+	operator, emitCalls = buildTestManager(t, cfg)
+	require.NoError(t, operator.Start(persister))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForHeaderToken(t, emitCalls, "2,error,stopped", "id,severity,message")
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	onCompleteNone   = ""
+	onCompleteDelete = "delete"
+	onCompleteMove   = "move"
+	onCompleteRename = "rename"
+
+	defaultIdlePeriod = time.Minute
+)
+
+// OnCompleteConfig configures what happens to a file once it has been read completely
+type OnCompleteConfig struct {
+	Action     string        `mapstructure:"action,omitempty"`
+	Directory  string        `mapstructure:"directory,omitempty"`
+	Suffix     string        `mapstructure:"suffix,omitempty"`
+	IdlePeriod time.Duration `mapstructure:"idle_period,omitempty"`
+}
+
+func (c OnCompleteConfig) validate() error {
+	switch c.Action {
+	case onCompleteNone, onCompleteDelete:
+	case onCompleteMove:
+		if c.Directory == "" {
+			return fmt.Errorf("`on_complete.directory` is required for action '%s'", c.Action)
+		}
+	case onCompleteRename:
+		if c.Suffix == "" {
+			return fmt.Errorf("`on_complete.suffix` is required for action '%s'", c.Action)
+		}
+	default:
+		return fmt.Errorf("invalid on_complete action '%s'", c.Action)
+	}
+
+	if c.IdlePeriod < 0 {
+		return fmt.Errorf("`on_complete.idle_period` must not be negative")
+	}
+	return nil
+}
+
+// completeFiles applies the on_complete action to each file that has been read to the end
+// and has not been modified for the idle period. It must only be called once the offsets
+// of the readers have been checkpointed. Files that were deleted, or moved or renamed to a
+// path that isn't watched, are no longer known, and completeFiles reports whether any of
+// them was forgotten.
+func (m *Manager) completeFiles(readers []*Reader) bool {
+	if m.onComplete.Action == onCompleteNone {
+		return false
+	}
+
+	forgotten := false
+	for _, reader := range readers {
+		if !reader.eof || reader.file == nil {
+			continue
+		}
+		path := reader.file.Name()
+
+		info, err := os.Stat(path)
+		if err != nil {
+			// The file was removed or rotated after it was read
+			continue
+		}
+		if time.Since(info.ModTime()) < m.onComplete.IdlePeriod {
+			continue
+		}
+
+		newPath, err := m.completeFile(path)
+		if err != nil {
+			m.Errorw("Failed to complete file", zap.String("path", path), zap.String("action", m.onComplete.Action), zap.Error(err))
+			continue
+		}
+		m.Infow("Completed file", zap.String("path", path), zap.String("action", m.onComplete.Action))
+
+		if newPath == "" || !m.finder.matches(newPath) {
+			m.forgetReader(reader)
+			forgotten = true
+		}
+	}
+	return forgotten
+}
+
+// completeFile applies the on_complete action to the file, returning its new path,
+// which is empty if the file was deleted
+func (m *Manager) completeFile(path string) (string, error) {
+	switch m.onComplete.Action {
+	case onCompleteDelete:
+		return "", os.Remove(path)
+	case onCompleteMove:
+		if filepath.Clean(filepath.Dir(path)) == filepath.Clean(m.onComplete.Directory) {
+			// Already moved
+			return path, nil
+		}
+		newPath := filepath.Join(m.onComplete.Directory, filepath.Base(path))
+		return newPath, os.Rename(path, newPath)
+	case onCompleteRename:
+		if strings.HasSuffix(path, m.onComplete.Suffix) {
+			// Already renamed
+			return path, nil
+		}
+		return path + m.onComplete.Suffix, os.Rename(path, path+m.onComplete.Suffix)
+	}
+	return path, nil
+}
+
+// forgetReader removes the reader from the known files
+func (m *Manager) forgetReader(reader *Reader) {
+	for i, known := range m.knownFiles {
+		if known == reader {
+			m.knownFiles = append(m.knownFiles[:i], m.knownFiles[i+1:]...)
+			return
+		}
+	}
+}
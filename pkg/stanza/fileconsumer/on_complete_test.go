@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestOnCompleteConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		cfg         OnCompleteConfig
+		expectedErr string
+	}{
+		{"None", OnCompleteConfig{}, ""},
+		{"Delete", OnCompleteConfig{Action: "delete"}, ""},
+		{"Move", OnCompleteConfig{Action: "move", Directory: "/archive"}, ""},
+		{"Rename", OnCompleteConfig{Action: "rename", Suffix: ".done"}, ""},
+		{"MoveWithoutDirectory", OnCompleteConfig{Action: "move"}, "`on_complete.directory` is required for action 'move'"},
+		{"RenameWithoutSuffix", OnCompleteConfig{Action: "rename"}, "`on_complete.suffix` is required for action 'rename'"},
+		{"InvalidAction", OnCompleteConfig{Action: "shred"}, "invalid on_complete action 'shred'"},
+		{"NegativeIdlePeriod", OnCompleteConfig{Action: "delete", IdlePeriod: -time.Second}, "`on_complete.idle_period` must not be negative"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.validate()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func buildOnCompleteManager(t *testing.T, dir string, onComplete OnCompleteConfig) (*Manager, chan *emitParams) {
+	cfg := NewConfig()
+	cfg.Include = []string{filepath.Join(dir, "*.log")}
+	cfg.StartAt = "beginning"
+	cfg.OnComplete = onComplete
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+	t.Cleanup(func() {
+		require.NoError(t, operator.Stop())
+	})
+	return operator, emitCalls
+}
+
+// requireNoKnownFiles checks that no file is known by the manager, neither in memory nor in its checkpoint
+func requireNoKnownFiles(t *testing.T, operator *Manager) {
+	require.Empty(t, operator.knownFiles)
+
+	knownFiles := operator.knownFiles
+	require.NoError(t, operator.loadLastPollFiles(context.Background()))
+	require.Empty(t, operator.knownFiles)
+	operator.knownFiles = knownFiles
+}
+
+func TestOnCompleteDelete(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	operator, emitCalls := buildOnCompleteManager(t, tempDir, OnCompleteConfig{Action: "delete"})
+
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("testlog1\ntestlog2\n"), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	require.NoFileExists(t, path)
+	requireNoKnownFiles(t, operator)
+
+	// A new file with the same content is not mistaken for the deleted one
+	require.NoError(t, os.WriteFile(path, []byte("testlog1\ntestlog2\n"), 0600))
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+}
+
+func TestOnCompleteMove(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	archiveDir := t.TempDir()
+	operator, emitCalls := buildOnCompleteManager(t, tempDir, OnCompleteConfig{Action: "move", Directory: archiveDir})
+
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("testlog1\n"), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	require.NoFileExists(t, path)
+	require.FileExists(t, filepath.Join(archiveDir, "export.log"))
+	requireNoKnownFiles(t, operator)
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+func TestOnCompleteRename(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	operator, emitCalls := buildOnCompleteManager(t, tempDir, OnCompleteConfig{Action: "rename", Suffix: ".done"})
+
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("testlog1\n"), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	require.NoFileExists(t, path)
+	require.FileExists(t, path+".done")
+	requireNoKnownFiles(t, operator)
+}
+
+// TestOnCompleteRenameMatchingInclude tests that a renamed file which still matches
+// the include pattern is neither read again nor renamed a second time
+func TestOnCompleteRenameMatchingInclude(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	operator, emitCalls := buildOnCompleteManager(t, tempDir, OnCompleteConfig{Action: "rename", Suffix: ".done.log"})
+
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("testlog1\n"), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.FileExists(t, path+".done.log")
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+	require.FileExists(t, path+".done.log")
+}
+
+func TestOnCompleteIdlePeriod(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	operator, emitCalls := buildOnCompleteManager(t, tempDir, OnCompleteConfig{Action: "delete", IdlePeriod: time.Hour})
+
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("testlog1\n"), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.FileExists(t, path)
+
+	// Pretend the file has not been written to for longer than the idle period
+	past := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(path, past, past))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+	require.NoFileExists(t, path)
+}
+
+func TestOnCompleteIncompleteFile(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	operator, emitCalls := buildOnCompleteManager(t, tempDir, OnCompleteConfig{Action: "delete"})
+
+	// The last entry has not been terminated, so the file is not read to the end
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("testlog1\npartial"), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.FileExists(t, path)
+}
+
+type failingPersister struct{}
+
+func (failingPersister) Get(context.Context, string) ([]byte, error) { return nil, nil }
+func (failingPersister) Set(context.Context, string, []byte) error {
+	return errors.New("database unavailable")
+}
+func (failingPersister) Delete(context.Context, string) error { return nil }
+
+func TestOnCompleteNotCheckpointed(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	operator, emitCalls := buildOnCompleteManager(t, tempDir, OnCompleteConfig{Action: "delete"})
+	operator.persister = failingPersister{}
+
+	path := filepath.Join(tempDir, "export.log")
+	require.NoError(t, os.WriteFile(path, []byte("testlog1\n"), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	require.FileExists(t, path)
+}
@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	strptime "github.com/observiq/ctimefmt"
+)
+
+const (
+	sortTypeNumeric      = "numeric"
+	sortTypeTimestamp    = "timestamp"
+	sortTypeAlphabetical = "alphabetical"
+	sortTypeMtime        = "mtime"
+
+	defaultOrderingTopN = 1
+)
+
+// OrderingCriteria selects the files to read when more files match
+// the include patterns than should be tailed
+type OrderingCriteria struct {
+	Regex  string     `mapstructure:"regex,omitempty"`
+	TopN   int        `mapstructure:"top_n,omitempty"`
+	SortBy []SortRule `mapstructure:"sort_by,omitempty"`
+}
+
+// SortRule orders files by a value captured from their name, or by their modification time
+type SortRule struct {
+	RegexKey  string `mapstructure:"regex_key,omitempty"`
+	SortType  string `mapstructure:"sort_type,omitempty"`
+	Layout    string `mapstructure:"layout,omitempty"`
+	Location  string `mapstructure:"location,omitempty"`
+	Ascending bool   `mapstructure:"ascending,omitempty"`
+}
+
+// build compiles the regex and resolves the layouts and locations of the sort rules,
+// returning nil settings if no sort rule is configured
+func (c OrderingCriteria) build() (*orderingSettings, error) {
+	if len(c.SortBy) == 0 {
+		if c.Regex != "" || c.TopN != 0 {
+			return nil, fmt.Errorf("`ordering_criteria.sort_by` is required")
+		}
+		return nil, nil
+	}
+
+	if c.TopN < 0 {
+		return nil, fmt.Errorf("`ordering_criteria.top_n` must not be negative")
+	}
+
+	settings := &orderingSettings{
+		topN:  c.TopN,
+		rules: make([]sortRule, 0, len(c.SortBy)),
+	}
+	if settings.topN == 0 {
+		settings.topN = defaultOrderingTopN
+	}
+	if c.Regex != "" {
+		var err error
+		if settings.regex, err = regexp.Compile(c.Regex); err != nil {
+			return nil, fmt.Errorf("compile `ordering_criteria.regex`: %w", err)
+		}
+	}
+
+	for _, rule := range c.SortBy {
+		built, err := rule.build(settings.regex)
+		if err != nil {
+			return nil, err
+		}
+		settings.rules = append(settings.rules, built)
+	}
+	return settings, nil
+}
+
+func (r SortRule) build(re *regexp.Regexp) (sortRule, error) {
+	built := sortRule{sortType: r.SortType, ascending: r.Ascending}
+	switch r.SortType {
+	case sortTypeMtime:
+		return built, nil
+	case sortTypeNumeric, sortTypeAlphabetical, sortTypeTimestamp:
+	default:
+		return built, fmt.Errorf("invalid sort_type '%s'", r.SortType)
+	}
+
+	if re == nil {
+		return built, fmt.Errorf("`ordering_criteria.regex` is required for sort_type '%s'", r.SortType)
+	}
+	if built.regexIndex = re.SubexpIndex(r.RegexKey); built.regexIndex < 0 {
+		return built, fmt.Errorf("`ordering_criteria.regex` has no capture group named '%s'", r.RegexKey)
+	}
+
+	if r.SortType == sortTypeTimestamp {
+		if r.Layout == "" {
+			return built, fmt.Errorf("layout is required for sort_type '%s'", r.SortType)
+		}
+		var err error
+		if built.layout, err = strptime.ToNative(r.Layout); err != nil {
+			return built, fmt.Errorf("parse layout: %w", err)
+		}
+		built.location = time.UTC
+		if r.Location != "" {
+			if built.location, err = time.LoadLocation(r.Location); err != nil {
+				return built, fmt.Errorf("load location %s: %w", r.Location, err)
+			}
+		}
+	}
+	return built, nil
+}
+
+// orderingSettings is the compiled form of an OrderingCriteria
+type orderingSettings struct {
+	regex *regexp.Regexp
+	topN  int
+	rules []sortRule
+}
+
+// sortRule is the compiled form of a SortRule
+type sortRule struct {
+	sortType   string
+	regexIndex int
+	layout     string
+	location   *time.Location
+	ascending  bool
+}
+
+// sortKeys holds the values that a file is ordered by, one per sort rule
+type sortKeys struct {
+	path string
+	keys []interface{}
+}
+
+// apply returns the top N files according to the criteria. Files whose names
+// don't match the regex, or whose keys can't be parsed, are excluded.
+func (s *orderingSettings) apply(paths []string) []string {
+	if s == nil {
+		return paths
+	}
+
+	files := make([]sortKeys, 0, len(paths))
+	for _, path := range paths {
+		var match []string
+		if s.regex != nil {
+			if match = s.regex.FindStringSubmatch(filepath.Base(path)); match == nil {
+				continue
+			}
+		}
+
+		keys, ok := s.keys(path, match)
+		if !ok {
+			continue
+		}
+		files = append(files, sortKeys{path: path, keys: keys})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		for k, rule := range s.rules {
+			cmp := compareKeys(files[i].keys[k], files[j].keys[k])
+			if cmp == 0 {
+				continue
+			}
+			if rule.ascending {
+				return cmp < 0
+			}
+			return cmp > 0
+		}
+		return false
+	})
+
+	if len(files) > s.topN {
+		files = files[:s.topN]
+	}
+
+	result := make([]string, 0, len(files))
+	for _, file := range files {
+		result = append(result, file.path)
+	}
+	return result
+}
+
+func (s *orderingSettings) keys(path string, match []string) ([]interface{}, bool) {
+	keys := make([]interface{}, 0, len(s.rules))
+	for _, rule := range s.rules {
+		if rule.sortType == sortTypeMtime {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, false
+			}
+			keys = append(keys, info.ModTime())
+			continue
+		}
+
+		value := match[rule.regexIndex]
+		switch rule.sortType {
+		case sortTypeNumeric:
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			keys = append(keys, n)
+		case sortTypeTimestamp:
+			t, err := time.ParseInLocation(rule.layout, value, rule.location)
+			if err != nil {
+				return nil, false
+			}
+			keys = append(keys, t)
+		default:
+			keys = append(keys, value)
+		}
+	}
+	return keys, true
+}
+
+// compareKeys returns -1, 0 or 1 if a is less than, equal to or greater than b.
+// Both keys are produced by the same sort rule, so they always have the same type.
+func compareKeys(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		b := b.(int64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case time.Time:
+		b := b.(time.Time)
+		switch {
+		case a.Before(b):
+			return -1
+		case a.After(b):
+			return 1
+		}
+	case string:
+		switch {
+		case a < b.(string):
+			return -1
+		case a > b.(string):
+			return 1
+		}
+	}
+	return 0
+}
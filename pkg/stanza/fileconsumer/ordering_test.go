@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderingCriteriaValidate(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		criteria    OrderingCriteria
+		expectedErr string
+	}{
+		{
+			name:     "Empty",
+			criteria: OrderingCriteria{},
+		},
+		{
+			name:        "MissingSortBy",
+			criteria:    OrderingCriteria{Regex: `(?P<num>\d+)`},
+			expectedErr: "`ordering_criteria.sort_by` is required",
+		},
+		{
+			name: "NegativeTopN",
+			criteria: OrderingCriteria{
+				TopN:   -1,
+				SortBy: []SortRule{{SortType: sortTypeMtime}},
+			},
+			expectedErr: "`ordering_criteria.top_n` must not be negative",
+		},
+		{
+			name: "InvalidRegex",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+`,
+				SortBy: []SortRule{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			expectedErr: "compile `ordering_criteria.regex`",
+		},
+		{
+			name: "InvalidSortType",
+			criteria: OrderingCriteria{
+				SortBy: []SortRule{{SortType: "size"}},
+			},
+			expectedErr: "invalid sort_type 'size'",
+		},
+		{
+			name: "MissingRegex",
+			criteria: OrderingCriteria{
+				SortBy: []SortRule{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			expectedErr: "`ordering_criteria.regex` is required for sort_type 'numeric'",
+		},
+		{
+			name: "MissingCaptureGroup",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+)`,
+				SortBy: []SortRule{{RegexKey: "value", SortType: sortTypeNumeric}},
+			},
+			expectedErr: "has no capture group named 'value'",
+		},
+		{
+			name: "MissingLayout",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<time>\d+)`,
+				SortBy: []SortRule{{RegexKey: "time", SortType: sortTypeTimestamp}},
+			},
+			expectedErr: "layout is required for sort_type 'timestamp'",
+		},
+		{
+			name: "InvalidLocation",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<time>\d+)`,
+				SortBy: []SortRule{{RegexKey: "time", SortType: sortTypeTimestamp, Layout: "%Y%m%d%H", Location: "Not/AZone"}},
+			},
+			expectedErr: "load location Not/AZone",
+		},
+		{
+			name: "Valid",
+			criteria: OrderingCriteria{
+				Regex: `err\.(?P<time>\d{10})\.(?P<num>\d+)\.log`,
+				TopN:  3,
+				SortBy: []SortRule{
+					{RegexKey: "time", SortType: sortTypeTimestamp, Layout: "%Y%m%d%H", Location: "UTC"},
+					{RegexKey: "num", SortType: sortTypeNumeric},
+					{SortType: sortTypeMtime},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.criteria.build()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestOrderingCriteriaApply(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		files    []string
+		criteria OrderingCriteria
+		expected []string
+	}{
+		{
+			name:     "NoCriteria",
+			files:    []string{"a.log", "b.log", "c.log"},
+			criteria: OrderingCriteria{},
+			expected: []string{"a.log", "b.log", "c.log"},
+		},
+		{
+			name:  "NumericDefaultTopN",
+			files: []string{"err.1.log", "err.12.log", "err.3.log"},
+			criteria: OrderingCriteria{
+				Regex:  `err\.(?P<num>\d+)\.log`,
+				SortBy: []SortRule{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			expected: []string{"err.12.log"},
+		},
+		{
+			name:  "NumericAscending",
+			files: []string{"err.1.log", "err.12.log", "err.3.log"},
+			criteria: OrderingCriteria{
+				Regex:  `err\.(?P<num>\d+)\.log`,
+				TopN:   2,
+				SortBy: []SortRule{{RegexKey: "num", SortType: sortTypeNumeric, Ascending: true}},
+			},
+			expected: []string{"err.1.log", "err.3.log"},
+		},
+		{
+			name:  "Alphabetical",
+			files: []string{"err.a.log", "err.c.log", "err.b.log"},
+			criteria: OrderingCriteria{
+				Regex:  `err\.(?P<name>[a-z]+)\.log`,
+				TopN:   2,
+				SortBy: []SortRule{{RegexKey: "name", SortType: sortTypeAlphabetical}},
+			},
+			expected: []string{"err.c.log", "err.b.log"},
+		},
+		{
+			name:  "Timestamp",
+			files: []string{"err.2023020611.log", "err.2023020612.log", "err.2023020523.log", "err.2023020610.log"},
+			criteria: OrderingCriteria{
+				Regex:  `err\.(?P<time>\d{10})\.log`,
+				TopN:   2,
+				SortBy: []SortRule{{RegexKey: "time", SortType: sortTypeTimestamp, Layout: "%Y%m%d%H"}},
+			},
+			expected: []string{"err.2023020612.log", "err.2023020611.log"},
+		},
+		{
+			name:  "NonMatchingExcluded",
+			files: []string{"err.1.log", "err.2.log", "other.log", "err.x.log"},
+			criteria: OrderingCriteria{
+				Regex:  `err\.(?P<num>\d+)\.log`,
+				TopN:   10,
+				SortBy: []SortRule{{RegexKey: "num", SortType: sortTypeNumeric}},
+			},
+			expected: []string{"err.2.log", "err.1.log"},
+		},
+		{
+			name:  "MultipleRules",
+			files: []string{"err.2023020611.1.log", "err.2023020612.1.log", "err.2023020612.2.log", "err.2023020610.3.log"},
+			criteria: OrderingCriteria{
+				Regex: `err\.(?P<time>\d{10})\.(?P<num>\d+)\.log`,
+				TopN:  3,
+				SortBy: []SortRule{
+					{RegexKey: "time", SortType: sortTypeTimestamp, Layout: "%Y%m%d%H"},
+					{RegexKey: "num", SortType: sortTypeNumeric, Ascending: true},
+				},
+			},
+			expected: []string{"err.2023020612.1.log", "err.2023020612.2.log", "err.2023020611.1.log"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			files := absPath(tempDir, tc.files)
+			for _, f := range files {
+				require.NoError(t, os.WriteFile(f, []byte(filepath.Base(f)), 0600))
+			}
+
+			ordering, err := tc.criteria.build()
+			require.NoError(t, err)
+			require.Equal(t, absPath(tempDir, tc.expected), ordering.apply(files))
+		})
+	}
+}
+
+func TestOrderingCriteriaMtime(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	files := absPath(tempDir, []string{"a.log", "b.log", "c.log"})
+	now := time.Now()
+	for i, f := range files {
+		require.NoError(t, os.WriteFile(f, []byte(filepath.Base(f)), 0600))
+		mtime := now.Add(time.Duration(i-len(files)) * time.Hour)
+		require.NoError(t, os.Chtimes(f, mtime, mtime))
+	}
+
+	criteria := OrderingCriteria{
+		TopN:   2,
+		SortBy: []SortRule{{SortType: sortTypeMtime}},
+	}
+	ordering, err := criteria.build()
+	require.NoError(t, err)
+	require.Equal(t, []string{files[2], files[1]}, ordering.apply(files))
+}
+
+func TestOrderingCriteriaBuild(t *testing.T) {
+	t.Parallel()
+	criteria := OrderingCriteria{
+		Regex: `err\.(?P<time>\d{10})\.(?P<num>\d+)\.log`,
+		SortBy: []SortRule{
+			{RegexKey: "num", SortType: sortTypeNumeric},
+			{RegexKey: "time", SortType: sortTypeTimestamp, Layout: "%Y%m%d%H", Location: "America/New_York", Ascending: true},
+		},
+	}
+
+	ordering, err := criteria.build()
+	require.NoError(t, err)
+	require.Equal(t, criteria.Regex, ordering.regex.String())
+	require.Equal(t, defaultOrderingTopN, ordering.topN)
+	require.Len(t, ordering.rules, 2)
+	require.Equal(t, 2, ordering.rules[0].regexIndex)
+	require.Equal(t, 1, ordering.rules[1].regexIndex)
+	require.Equal(t, "2006010215", ordering.rules[1].layout)
+	require.Equal(t, "America/New_York", ordering.rules[1].location.String())
+	require.True(t, ordering.rules[1].ascending)
+
+	ordering, err = OrderingCriteria{}.build()
+	require.NoError(t, err)
+	require.Nil(t, ordering)
+}
+
+// TestFinderOrdering tests that the ordering criteria are applied by a built finder
+func TestFinderOrdering(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	files := absPath(tempDir, []string{"err.1.log", "err.3.log", "err.2.log"})
+	for _, f := range files {
+		require.NoError(t, os.WriteFile(f, []byte(filepath.Base(f)), 0600))
+	}
+
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.OrderingCriteria = OrderingCriteria{
+		Regex:  `err\.(?P<num>\d+)\.log`,
+		SortBy: []SortRule{{RegexKey: "num", SortType: sortTypeNumeric}},
+	}
+	operator, _ := buildTestManager(t, cfg)
+	require.Equal(t, []string{files[1]}, operator.finder.FindFiles())
+}
+
+func TestFinderExcludeOlderThan(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	files := absPath(tempDir, []string{"old.log", "new.log"})
+	for _, f := range files {
+		require.NoError(t, os.WriteFile(f, []byte(filepath.Base(f)), 0600))
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(files[0], old, old))
+
+	finder := Finder{
+		Include:          absPath(tempDir, []string{"*.log"}),
+		ExcludeOlderThan: time.Hour,
+	}
+	require.Equal(t, []string{files[1]}, finder.FindFiles())
+}
@@ -17,7 +17,9 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -28,6 +30,8 @@ import (
 type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
+	compression     string
+	header          *headerSettings
 	emit            EmitFunc
 }
 
@@ -40,13 +44,42 @@ type Reader struct {
 
 	Fingerprint    *Fingerprint
 	Offset         int64
-	generation     int
-	file           *os.File
-	fileAttributes *FileAttributes
+	Header         []string `json:",omitempty"`
+	HeaderComplete bool     `json:",omitempty"`
+
+	// CompressedSize and CompressedModTime describe a compressed file the last time
+	// it was read to the end, so that it isn't decompressed again until it changes
+	CompressedSize    int64 `json:",omitempty"`
+	CompressedModTime int64 `json:",omitempty"`
+	generation        int
+	file              *os.File
+	fileAttributes    *FileAttributes
+
+	// compression is the resolved compression of the file. Offsets of compressed
+	// files refer to positions in their uncompressed content.
+	compression string
+	source      io.Reader
+
+	// eof is true if the last call to ReadToEnd emitted all of the file's content
+	eof bool
 }
 
 // offsetToEnd sets the starting offset
 func (r *Reader) offsetToEnd() error {
+	if r.compression != compressionNone {
+		dec, err := newDecompressor(r.compression, r.file)
+		if err != nil {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		defer dec.Close()
+		n, err := io.Copy(io.Discard, dec)
+		if err != nil && !isTruncated(err) {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		r.Offset = n
+		return nil
+	}
+
 	info, err := r.file.Stat()
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
@@ -55,13 +88,88 @@ func (r *Reader) offsetToEnd() error {
 	return nil
 }
 
+// readHeader captures the header of a file that is not read from the beginning
+func (r *Reader) readHeader(splitFunc bufio.SplitFunc) error {
+	var source io.Reader
+	if r.compression != compressionNone {
+		dec, err := newDecompressor(r.compression, r.file)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("decompress: %w", err)
+		}
+		defer dec.Close()
+		source = dec
+	} else {
+		info, err := r.file.Stat()
+		if err != nil {
+			return fmt.Errorf("stat: %w", err)
+		}
+		source = io.NewSectionReader(r.file, 0, info.Size())
+	}
+
+	scanner := NewPositionalScanner(source, r.maxLogSize, 0, splitFunc)
+	for !r.HeaderComplete && scanner.Scan() {
+		token, err := r.encoding.Decode(scanner.Bytes())
+		if err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
+		if !r.processHeader(token) {
+			break
+		}
+	}
+	return nil
+}
+
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
-		r.Errorw("Failed to seek", zap.Error(err))
-		return
+	r.eof = false
+	if r.compression == compressionNone {
+		if _, err := r.file.Seek(r.Offset, 0); err != nil {
+			r.Errorw("Failed to seek", zap.Error(err))
+			return
+		}
+		r.source = r.file
+	} else {
+		info, err := r.file.Stat()
+		if err != nil {
+			r.Errorw("Failed to stat", zap.Error(err))
+			return
+		}
+		if info.Size() == r.CompressedSize && info.ModTime().UnixNano() == r.CompressedModTime {
+			r.eof = true
+			return
+		}
+		defer func() {
+			if r.eof {
+				r.CompressedSize, r.CompressedModTime = info.Size(), info.ModTime().UnixNano()
+			}
+		}()
+
+		// Compressed files cannot be seeked, so the uncompressed
+		// content is skipped up to the offset instead
+		dec, err := newDecompressor(r.compression, r.file)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !isTruncated(err) {
+				r.Errorw("Failed to decompress", zap.Error(err))
+			}
+			return
+		}
+		defer dec.Close()
+		if _, err := io.CopyN(io.Discard, dec, r.Offset); err != nil {
+			if !errors.Is(err, io.EOF) && !isTruncated(err) {
+				r.Errorw("Failed to seek", zap.Error(err))
+			}
+			return
+		}
+		r.source = dec
 	}
 
+	startOffset := r.Offset
+	counter := &countingReader{Reader: r.source}
+	r.source = counter
+
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitFunc)
 
 	// Iterate over the tokenized file, emitting entries as we go
@@ -75,15 +183,22 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		ok := scanner.Scan()
 		if !ok {
 			if err := scanner.getError(); err != nil {
-				r.Errorw("Failed during scan", zap.Error(err))
+				if r.compression != compressionNone && isTruncated(err) {
+					// The compressed file is still being written
+					r.Debugw("Reached end of incomplete compressed file", zap.Error(err))
+				} else {
+					r.Errorw("Failed during scan", zap.Error(err))
+				}
+				break
 			}
+			r.eof = r.Offset == startOffset+counter.n
 			break
 		}
 
 		token, err := r.encoding.Decode(scanner.Bytes())
 		if err != nil {
 			r.Errorw("decode: %w", zap.Error(err))
-		} else {
+		} else if !r.processHeader(token) {
 			r.emit(ctx, r.fileAttributes, token)
 		}
 
@@ -105,9 +220,9 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.source.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.source.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
@@ -119,6 +234,18 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	return n, err
 }
 
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(dst []byte) (int, error) {
+	n, err := c.Reader.Read(dst)
+	c.n += int64(n)
+	return n, err
+}
+
 func min0(a, b int) int {
 	if a < 0 || b < 0 {
 		return 0
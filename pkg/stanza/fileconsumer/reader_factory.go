@@ -17,6 +17,7 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"bufio"
 	"os"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -45,6 +46,8 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
 		withSplitterFunc(old.splitFunc).
+		withHeader(old.Header, old.HeaderComplete).
+		withCompressedState(old.CompressedSize, old.CompressedModTime).
 		build()
 }
 
@@ -53,6 +56,10 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	compression := resolveCompression(f.readerConfig.compression, file)
+	if compression != compressionNone {
+		return newDecompressedFingerprint(file, compression, f.readerConfig.fingerprintSize)
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
@@ -62,6 +69,12 @@ type readerBuilder struct {
 	fp        *Fingerprint
 	offset    int64
 	splitFunc bufio.SplitFunc
+
+	header         []string
+	headerComplete bool
+
+	compressedSize    int64
+	compressedModTime int64
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -88,10 +101,26 @@ func (b *readerBuilder) withOffset(offset int64) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withHeader(header []string, complete bool) *readerBuilder {
+	b.header = header
+	b.headerComplete = complete
+	return b
+}
+
+func (b *readerBuilder) withCompressedState(size, modTime int64) *readerBuilder {
+	b.compressedSize = size
+	b.compressedModTime = modTime
+	return b
+}
+
 func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
-		readerConfig: b.readerConfig,
-		Offset:       b.offset,
+		readerConfig:      b.readerConfig,
+		Offset:            b.offset,
+		Header:            b.header,
+		HeaderComplete:    b.headerComplete,
+		CompressedSize:    b.compressedSize,
+		CompressedModTime: b.compressedModTime,
 	}
 
 	if b.splitFunc != nil {
@@ -111,14 +140,26 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 
 	if b.file != nil {
 		r.file = b.file
+		r.compression = resolveCompression(b.readerConfig.compression, b.file)
 		r.SugaredLogger = b.SugaredLogger.With("path", b.file.Name())
 		r.fileAttributes, err = resolveFileAttributes(b.file.Name())
 		if err != nil {
 			b.Errorf("resolve attributes: %w", err)
 		}
+		r.fileAttributes.Header = strings.Join(r.Header, "\n")
 
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
+			if r.header != nil && !r.HeaderComplete {
+				// The header would otherwise be skipped along with the rest of the file's content
+				splitFunc, err := b.splitterFactory.Build(b.readerConfig.maxLogSize)
+				if err != nil {
+					return nil, err
+				}
+				if err := r.readHeader(splitFunc); err != nil {
+					return nil, err
+				}
+			}
 			if err := r.offsetToEnd(); err != nil {
 				return nil, err
 			}
@@ -7,10 +7,13 @@ require (
 	github.com/bmatcuk/doublestar/v3 v3.0.0
 	github.com/jpillora/backoff v1.0.0
 	github.com/json-iterator/go v1.1.12
+	github.com/klauspost/compress v1.15.11
 	github.com/mitchellh/mapstructure v1.5.0 // indirect
 	github.com/observiq/ctimefmt v1.0.0
 	github.com/observiq/nanojack v0.0.0-20201106172433-343928847ebc
+	github.com/pierrec/lz4/v4 v4.1.17
 	github.com/stretchr/testify v1.8.1
+	github.com/ulikunitz/xz v0.5.10
 	go.opentelemetry.io/collector v0.63.0
 	go.uber.org/zap v1.23.0
 	golang.org/x/exp v0.0.0-20200331195152-e8c3332aa8e5 // indirect
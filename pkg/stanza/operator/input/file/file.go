@@ -82,3 +82,10 @@ func setFileNameResolved(attrs *fileconsumer.FileAttributes, ent *entry.Entry) e
 func setFilePathResolved(attrs *fileconsumer.FileAttributes, ent *entry.Entry) error {
 	return ent.Set(entry.NewAttributeField("log.file.path_resolved"), attrs.PathResolved)
 }
+
+func setFileHeader(attrs *fileconsumer.FileAttributes, ent *entry.Entry) error {
+	if attrs.Header == "" {
+		return nil
+	}
+	return ent.Set(entry.NewAttributeField("log.file.header"), attrs.Header)
+}
@@ -68,6 +68,28 @@ func TestAddFileFields(t *testing.T) {
 	require.Equal(t, temp.Name(), e.Attributes["log.file.path"])
 }
 
+// AddFileHeader tests that the `log.file.header` field is included
+// when a header is configured, and that header lines are not emitted
+func TestAddFileHeader(t *testing.T) {
+	t.Parallel()
+	operator, logReceived, tempDir := newTestFileOperator(t, func(cfg *Config) {
+		cfg.Header.LineCount = 1
+	}, nil)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "id,severity,message\n1,info,started\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	e := waitForOne(t, logReceived)
+	require.Equal(t, "1,info,started", e.Body)
+	require.Equal(t, "id,severity,message", e.Attributes["log.file.header"])
+	expectNoMessages(t, logReceived)
+}
+
 // AddFileResolvedFields tests that the `log.file.name_resolved` and `log.file.path_resolved` fields are included
 // when IncludeFileNameResolved and IncludeFilePathResolved are set to true
 func TestAddFileResolvedFields(t *testing.T) {
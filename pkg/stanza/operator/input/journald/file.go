@@ -0,0 +1,346 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/ulikunitz/xz"
+)
+
+// The layout of journal files is described in
+// https://systemd.io/JOURNAL_FILE_FORMAT/. All integers are little endian.
+
+var journalSignature = []byte("LPKSHHRH")
+
+// Incompatible header flags
+const (
+	headerCompressedXZ   = 1 << 0
+	headerCompressedLZ4  = 1 << 1
+	headerKeyedHash      = 1 << 2
+	headerCompressedZSTD = 1 << 3
+	headerCompact        = 1 << 4
+
+	supportedHeaderFlags = headerCompressedXZ | headerCompressedLZ4 | headerKeyedHash | headerCompressedZSTD | headerCompact
+)
+
+// Object types and flags
+const (
+	objectData       = 1
+	objectEntry      = 3
+	objectEntryArray = 6
+
+	objectCompressedXZ   = 1 << 0
+	objectCompressedLZ4  = 1 << 1
+	objectCompressedZSTD = 1 << 2
+)
+
+// Offsets and sizes of the header and objects
+const (
+	headerMinSize                 = 208
+	headerIncompatibleFlagsOffset = 12
+	headerFileIDOffset            = 24
+	headerSeqnumIDOffset          = 72
+	headerEntryArrayOffset        = 176
+
+	objectHeaderSize = 16
+
+	dataPayloadOffset        = 64
+	dataCompactPayloadOffset = 72
+
+	entryItemsOffset = 64
+	entryItemSize    = 16
+	entryCompactSize = 4
+
+	entryArrayItemsOffset = 24
+	entryArrayItemSize    = 8
+	entryArrayCompactSize = 4
+
+	// maxObjectSize guards against reading corrupted sizes
+	maxObjectSize = 768 << 20
+)
+
+var zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+
+type id128 [16]byte
+
+// journalFile reads the entries of a systemd journal file, while it may still be written by journald.
+type journalFile struct {
+	file     *os.File
+	path     string
+	fileID   id128
+	seqnumID id128
+	compact  bool
+}
+
+// journalEntry is an entry object with the offsets of its data objects.
+type journalEntry struct {
+	seqnum    uint64
+	realtime  uint64
+	monotonic uint64
+	bootID    id128
+	xorHash   uint64
+	items     []uint64
+}
+
+// position is the location of the next entry in the chain of entry arrays of a journal file.
+type position struct {
+	array uint64
+	index uint64
+}
+
+func openJournalFile(path string) (*journalFile, error) {
+	file, err := os.Open(path) // #nosec - operator must read in files defined by user
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerMinSize)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("read journal header: %w", err)
+	}
+	if !bytes.Equal(header[:len(journalSignature)], journalSignature) {
+		file.Close()
+		return nil, errors.New("invalid journal file signature")
+	}
+
+	flags := binary.LittleEndian.Uint32(header[headerIncompatibleFlagsOffset:])
+	if flags&^supportedHeaderFlags != 0 {
+		file.Close()
+		return nil, fmt.Errorf("unsupported journal file flags 0x%x", flags&^supportedHeaderFlags)
+	}
+
+	j := &journalFile{
+		file:    file,
+		path:    path,
+		compact: flags&headerCompact != 0,
+	}
+	copy(j.fileID[:], header[headerFileIDOffset:])
+	copy(j.seqnumID[:], header[headerSeqnumIDOffset:])
+	return j, nil
+}
+
+func (j *journalFile) Close() error {
+	return j.file.Close()
+}
+
+func (j *journalFile) readUint64(offset uint64) (uint64, error) {
+	buf := make([]byte, 8)
+	if _, err := j.file.ReadAt(buf, int64(offset)); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+// readObject reads the object at the offset, after checking its type.
+func (j *journalFile) readObject(offset uint64, objectType uint8) (flags uint8, object []byte, err error) {
+	if offset == 0 || offset%8 != 0 {
+		return 0, nil, fmt.Errorf("invalid object offset %d", offset)
+	}
+
+	header := make([]byte, objectHeaderSize)
+	if _, err := j.file.ReadAt(header, int64(offset)); err != nil {
+		return 0, nil, fmt.Errorf("read object header: %w", err)
+	}
+	size, err := checkObjectHeader(header, offset, objectType)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	object = make([]byte, size)
+	if _, err := j.file.ReadAt(object, int64(offset)); err != nil {
+		return 0, nil, fmt.Errorf("read object: %w", err)
+	}
+	return header[1], object, nil
+}
+
+// checkObjectHeader checks the type of an object header and returns the size of the object.
+func checkObjectHeader(header []byte, offset uint64, objectType uint8) (uint64, error) {
+	if header[0] != objectType {
+		return 0, fmt.Errorf("expected object type %d at offset %d, got %d", objectType, offset, header[0])
+	}
+	size := binary.LittleEndian.Uint64(header[8:])
+	if size < objectHeaderSize || size > maxObjectSize {
+		return 0, fmt.Errorf("invalid object size %d at offset %d", size, offset)
+	}
+	return size, nil
+}
+
+// readEntryArray reads only the header of the entry array at the offset, since arrays
+// can hold many items. It returns the number of items the array can hold and the
+// offset of the next array.
+func (j *journalFile) readEntryArray(offset uint64) (capacity uint64, nextArray uint64, err error) {
+	if offset == 0 || offset%8 != 0 {
+		return 0, 0, fmt.Errorf("invalid object offset %d", offset)
+	}
+
+	header := make([]byte, entryArrayItemsOffset)
+	if _, err := j.file.ReadAt(header, int64(offset)); err != nil {
+		return 0, 0, fmt.Errorf("read entry array: %w", err)
+	}
+	size, err := checkObjectHeader(header, offset, objectEntryArray)
+	if err != nil {
+		return 0, 0, err
+	}
+	if size < entryArrayItemsOffset {
+		return 0, 0, fmt.Errorf("invalid entry array at offset %d", offset)
+	}
+	return (size - entryArrayItemsOffset) / j.entryArrayItemSize(), binary.LittleEndian.Uint64(header[objectHeaderSize:]), nil
+}
+
+// readEntryArrayItem reads the entry offset at the index of the entry array at the offset.
+func (j *journalFile) readEntryArrayItem(offset uint64, index uint64) (uint64, error) {
+	itemSize := j.entryArrayItemSize()
+	item := make([]byte, itemSize)
+	if _, err := j.file.ReadAt(item, int64(offset+entryArrayItemsOffset+index*itemSize)); err != nil {
+		return 0, fmt.Errorf("read entry array item: %w", err)
+	}
+	if j.compact {
+		return uint64(binary.LittleEndian.Uint32(item)), nil
+	}
+	return binary.LittleEndian.Uint64(item), nil
+}
+
+func (j *journalFile) entryArrayItemSize() uint64 {
+	if j.compact {
+		return entryArrayCompactSize
+	}
+	return entryArrayItemSize
+}
+
+// next returns the offset of the next entry object and advances the position.
+// It returns false when journald has not written any further entries yet.
+func (j *journalFile) next(pos *position) (uint64, bool, error) {
+	if pos.array == 0 {
+		first, err := j.readUint64(headerEntryArrayOffset)
+		if err != nil || first == 0 {
+			return 0, false, err
+		}
+		pos.array = first
+	}
+
+	for {
+		capacity, nextArray, err := j.readEntryArray(pos.array)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if pos.index < capacity {
+			offset, err := j.readEntryArrayItem(pos.array, pos.index)
+			if err != nil {
+				return 0, false, err
+			}
+			if offset == 0 {
+				// The array has not been filled up yet
+				return 0, false, nil
+			}
+			pos.index++
+			return offset, true, nil
+		}
+
+		if nextArray == 0 {
+			return 0, false, nil
+		}
+		pos.array, pos.index = nextArray, 0
+	}
+}
+
+func (j *journalFile) readEntry(offset uint64) (*journalEntry, error) {
+	_, object, err := j.readObject(offset, objectEntry)
+	if err != nil {
+		return nil, err
+	}
+	if len(object) < entryItemsOffset {
+		return nil, fmt.Errorf("invalid entry at offset %d", offset)
+	}
+
+	e := &journalEntry{
+		seqnum:    binary.LittleEndian.Uint64(object[16:]),
+		realtime:  binary.LittleEndian.Uint64(object[24:]),
+		monotonic: binary.LittleEndian.Uint64(object[32:]),
+		xorHash:   binary.LittleEndian.Uint64(object[56:]),
+	}
+	copy(e.bootID[:], object[40:56])
+
+	items := object[entryItemsOffset:]
+	if j.compact {
+		for i := 0; i+entryCompactSize <= len(items); i += entryCompactSize {
+			e.items = append(e.items, uint64(binary.LittleEndian.Uint32(items[i:])))
+		}
+	} else {
+		for i := 0; i+entryItemSize <= len(items); i += entryItemSize {
+			e.items = append(e.items, binary.LittleEndian.Uint64(items[i:]))
+		}
+	}
+	return e, nil
+}
+
+// readData returns the decompressed payload of a data object, which has the form FIELD=value.
+func (j *journalFile) readData(offset uint64) ([]byte, error) {
+	flags, object, err := j.readObject(offset, objectData)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadOffset := dataPayloadOffset
+	if j.compact {
+		payloadOffset = dataCompactPayloadOffset
+	}
+	if len(object) < payloadOffset {
+		return nil, fmt.Errorf("invalid data at offset %d", offset)
+	}
+	return decompress(flags, object[payloadOffset:])
+}
+
+func decompress(flags uint8, payload []byte) ([]byte, error) {
+	switch {
+	case flags&objectCompressedZSTD != 0:
+		return zstdDecoder.DecodeAll(payload, nil)
+	case flags&objectCompressedLZ4 != 0:
+		// LZ4 blocks are prefixed with their uncompressed size
+		if len(payload) < 8 {
+			return nil, errors.New("invalid lz4 payload")
+		}
+		size := binary.LittleEndian.Uint64(payload)
+		if size > maxObjectSize {
+			return nil, fmt.Errorf("invalid lz4 payload size %d", size)
+		}
+		dst := make([]byte, size)
+		n, err := lz4.UncompressBlock(payload[8:], dst)
+		if err != nil {
+			return nil, fmt.Errorf("decompress lz4: %w", err)
+		}
+		return dst[:n], nil
+	case flags&objectCompressedXZ != 0:
+		r, err := xz.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("decompress xz: %w", err)
+		}
+		return io.ReadAll(r)
+	default:
+		return payload, nil
+	}
+}
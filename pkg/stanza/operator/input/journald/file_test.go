@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package journald
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+)
+
+// The journal files in testdata were written by systemd-journald 252 and are
+// gzipped to save space. The json files hold the output of journalctl for them.
+
+// extractJournals extracts the journal files of a testdata directory into dir.
+func extractJournals(t *testing.T, fixture string, dir string) {
+	paths, err := filepath.Glob(filepath.Join("testdata", fixture, "*.journal.gz"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for _, p := range paths {
+		src, err := os.Open(p)
+		require.NoError(t, err)
+		gz, err := gzip.NewReader(src)
+		require.NoError(t, err)
+
+		dst, err := os.Create(filepath.Join(dir, strings.TrimSuffix(filepath.Base(p), ".gz")))
+		require.NoError(t, err)
+		_, err = io.Copy(dst, gz) // #nosec - test fixtures are small
+		require.NoError(t, err)
+
+		require.NoError(t, dst.Close())
+		require.NoError(t, gz.Close())
+		require.NoError(t, src.Close())
+	}
+}
+
+func TestJournalFile(t *testing.T) {
+	for _, tc := range []struct {
+		fixture string
+		compact bool
+	}{
+		{"compact", true},
+		{"regular", false},
+	} {
+		t.Run(tc.fixture, func(t *testing.T) {
+			dir := t.TempDir()
+			extractJournals(t, tc.fixture, dir)
+
+			j, err := openJournalFile(filepath.Join(dir, "system.journal"))
+			require.NoError(t, err)
+			defer j.Close()
+			require.Equal(t, tc.compact, j.compact)
+
+			var pos position
+			var seqnums []uint64
+			var messages []string
+			compressed := 0
+			for {
+				offset, ok, err := j.next(&pos)
+				require.NoError(t, err)
+				if !ok {
+					break
+				}
+
+				e, err := j.readEntry(offset)
+				require.NoError(t, err)
+				seqnums = append(seqnums, e.seqnum)
+
+				for _, item := range e.items {
+					flags, _, err := j.readObject(item, objectData)
+					require.NoError(t, err)
+					if flags != 0 {
+						compressed++
+					}
+
+					data, err := j.readData(item)
+					require.NoError(t, err)
+					if bytes.HasPrefix(data, []byte("MESSAGE=")) {
+						messages = append(messages, string(data[len("MESSAGE="):]))
+					}
+				}
+			}
+
+			require.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, seqnums)
+			require.Contains(t, messages, "hello from "+tc.fixture)
+			require.Contains(t, messages, "compressed "+strings.Repeat("0123456789abcdef", 64))
+			require.Contains(t, messages, "multi\nline")
+			require.Equal(t, 1, compressed, "the long message is compressed")
+
+			// The end of the file has been reached
+			_, ok, err := j.next(&pos)
+			require.NoError(t, err)
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestOpenJournalFileFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	invalid := filepath.Join(dir, "invalid.journal")
+	require.NoError(t, os.WriteFile(invalid, make([]byte, headerMinSize), 0600))
+	_, err := openJournalFile(invalid)
+	require.EqualError(t, err, "invalid journal file signature")
+
+	unsupported := filepath.Join(dir, "unsupported.journal")
+	header := make([]byte, headerMinSize)
+	copy(header, journalSignature)
+	binary.LittleEndian.PutUint32(header[headerIncompatibleFlagsOffset:], headerCompact|1<<5)
+	require.NoError(t, os.WriteFile(unsupported, header, 0600))
+	_, err = openJournalFile(unsupported)
+	require.EqualError(t, err, "unsupported journal file flags 0x20")
+
+	truncated := filepath.Join(dir, "truncated.journal")
+	require.NoError(t, os.WriteFile(truncated, journalSignature, 0600))
+	_, err = openJournalFile(truncated)
+	require.ErrorContains(t, err, "read journal header")
+}
+
+func TestDecompress(t *testing.T) {
+	data := []byte("MESSAGE=" + strings.Repeat("compressed message ", 100))
+
+	zstdEncoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	zstdPayload := zstdEncoder.EncodeAll(data, nil)
+
+	// journald prefixes LZ4 blocks with the uncompressed size
+	lz4Block := make([]byte, lz4.CompressBlockBound(len(data)))
+	n, err := lz4.CompressBlock(data, lz4Block, nil)
+	require.NoError(t, err)
+	lz4Payload := make([]byte, 8, 8+n)
+	binary.LittleEndian.PutUint64(lz4Payload, uint64(len(data)))
+	lz4Payload = append(lz4Payload, lz4Block[:n]...)
+
+	var xzPayload bytes.Buffer
+	xzWriter, err := xz.NewWriter(&xzPayload)
+	require.NoError(t, err)
+	_, err = xzWriter.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, xzWriter.Close())
+
+	for _, tc := range []struct {
+		name    string
+		flags   uint8
+		payload []byte
+	}{
+		{"none", 0, data},
+		{"zstd", objectCompressedZSTD, zstdPayload},
+		{"lz4", objectCompressedLZ4, lz4Payload},
+		{"xz", objectCompressedXZ, xzPayload.Bytes()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			decompressed, err := decompress(tc.flags, tc.payload)
+			require.NoError(t, err)
+			require.Equal(t, data, decompressed)
+		})
+	}
+
+	_, err = decompress(objectCompressedLZ4, []byte{1})
+	require.EqualError(t, err, "invalid lz4 payload")
+	_, err = decompress(objectCompressedXZ, []byte("invalid"))
+	require.ErrorContains(t, err, "decompress xz")
+}
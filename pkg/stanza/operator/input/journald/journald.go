@@ -36,7 +36,12 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
 
-const operatorType = "journald_input"
+const (
+	operatorType = "journald_input"
+
+	readerJournalctl = "journalctl"
+	readerNative     = "native"
+)
 
 func init() {
 	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
@@ -53,6 +58,7 @@ func NewConfigWithID(operatorID string) *Config {
 		InputConfig: helper.NewInputConfig(operatorID, operatorType),
 		StartAt:     "end",
 		Priority:    "info",
+		Reader:      readerJournalctl,
 	}
 }
 
@@ -65,6 +71,7 @@ type Config struct {
 	StartAt   string   `mapstructure:"start_at,omitempty"`
 	Units     []string `mapstructure:"units,omitempty"`
 	Priority  string   `mapstructure:"priority,omitempty"`
+	Reader    string   `mapstructure:"reader,omitempty"`
 }
 
 // Build will build a journald input operator from the supplied configuration
@@ -74,6 +81,17 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	switch c.Reader {
+	case readerJournalctl:
+	case readerNative:
+		if c.StartAt != "end" && c.StartAt != "beginning" {
+			return nil, fmt.Errorf("invalid value '%s' for parameter 'start_at'", c.StartAt)
+		}
+		return newNativeInput(inputOperator, c)
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'reader'", c.Reader)
+	}
+
 	args := make([]string, 0, 10)
 
 	// Export logs in UTC time
@@ -0,0 +1,576 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	defaultPollInterval = 200 * time.Millisecond
+
+	// maxBatchSize limits the number of entries read from a file in one poll
+	maxBatchSize = 1000
+)
+
+// defaultDirectories are the locations of the runtime and persistent journals
+var defaultDirectories = []string{"/run/log/journal", "/var/log/journal"}
+
+// NativeInput is an operator that reads journal files without journalctl
+type NativeInput struct {
+	helper.InputOperator
+
+	directories  []string
+	files        []string
+	startAtEnd   bool
+	filter       *filter
+	pollInterval time.Duration
+
+	persister operator.Persister
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+
+	// journals are the open journal files by file ID, since rotated files are renamed
+	journals map[id128]*trackedJournal
+	// paths are the journal files found by the last poll, to only reopen files that changed
+	paths map[string]journalPath
+	// startCursor is the cursor saved by a previous run
+	startCursor *cursor
+	firstPoll   bool
+}
+
+type trackedJournal struct {
+	file *journalFile
+	pos  position
+	seen bool
+}
+
+// journalPath is a journal file path with the file it pointed to when it was last opened.
+type journalPath struct {
+	info   os.FileInfo
+	fileID id128
+}
+
+type nativeEntry struct {
+	journal *journalEntry
+	fields  map[string]interface{}
+	cursor  string
+}
+
+func newNativeInput(inputOperator helper.InputOperator, c Config) (*NativeInput, error) {
+	filter, err := newFilter(c.Units, c.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &NativeInput{
+		InputOperator: inputOperator,
+		files:         c.Files,
+		startAtEnd:    c.StartAt == "end",
+		filter:        filter,
+		pollInterval:  defaultPollInterval,
+	}
+	switch {
+	case c.Directory != nil:
+		input.directories = []string{*c.Directory}
+	case len(c.Files) == 0:
+		input.directories = defaultDirectories
+	}
+	return input, nil
+}
+
+// Start will start reading entries from the journal files.
+func (n *NativeInput) Start(persister operator.Persister) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
+
+	// Start after the cursor if there is a saved one
+	saved, err := persister.Get(ctx, lastReadCursorKey)
+	if err != nil {
+		return fmt.Errorf("failed to get journal state: %w", err)
+	}
+	if saved != nil {
+		c, err := parseCursor(string(saved))
+		if err != nil {
+			n.Warnw("Ignoring invalid journal cursor", zap.Error(err))
+		} else {
+			n.startCursor = &c
+		}
+	}
+
+	n.persister = persister
+	n.journals = make(map[id128]*trackedJournal)
+	n.paths = make(map[string]journalPath)
+	n.firstPoll = true
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+
+		ticker := time.NewTicker(n.pollInterval)
+		defer ticker.Stop()
+		for {
+			// Read again right away while a backlog is being read
+			for n.poll(ctx) {
+				if ctx.Err() != nil {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop will stop reading entries.
+func (n *NativeInput) Stop() error {
+	if n.cancel != nil {
+		n.cancel()
+	}
+	n.wg.Wait()
+	for id, j := range n.journals {
+		j.file.Close()
+		delete(n.journals, id)
+	}
+	n.paths = nil
+	return nil
+}
+
+// poll reads the new entries of all journal files, and reports whether a file has more entries to read.
+func (n *NativeInput) poll(ctx context.Context) bool {
+	n.updateJournals()
+
+	var entries []nativeEntry
+	more := false
+	for _, j := range n.journals {
+		read, full := n.readJournal(j)
+		entries = append(entries, read...)
+		more = more || full
+	}
+	n.firstPoll = false
+
+	// Interleave the entries of all files in the order they were written
+	sort.SliceStable(entries, func(i, k int) bool {
+		return entries[i].journal.realtime < entries[k].journal.realtime
+	})
+
+	for _, e := range entries {
+		ent, err := n.NewEntry(e.fields)
+		if err != nil {
+			n.Warnw("Failed to create entry", zap.Error(err))
+			continue
+		}
+		ent.Timestamp = time.Unix(0, int64(e.journal.realtime)*1000) // in microseconds
+
+		if err := n.persister.Set(ctx, lastReadCursorKey, []byte(e.cursor)); err != nil {
+			n.Warnw("Failed to set offset", zap.Error(err))
+		}
+		n.Write(ctx, ent)
+	}
+	return more
+}
+
+// updateJournals opens new journal files and closes the ones that were deleted.
+// Files are only opened again when their path points to another file or their size changed.
+func (n *NativeInput) updateJournals() {
+	for _, j := range n.journals {
+		j.seen = false
+	}
+
+	paths := make(map[string]journalPath, len(n.paths))
+	for _, p := range n.journalPaths() {
+		info, err := os.Stat(p)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				n.Debugw("Failed to open journal file", zap.String("path", p), zap.Error(err))
+			}
+			continue
+		}
+		if known, ok := n.paths[p]; ok && os.SameFile(known.info, info) && known.info.Size() == info.Size() {
+			if tracked, ok := n.journals[known.fileID]; ok {
+				tracked.seen = true
+				paths[p] = known
+				continue
+			}
+		}
+
+		j, err := openJournalFile(p)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				n.Debugw("Failed to open journal file", zap.String("path", p), zap.Error(err))
+			}
+			continue
+		}
+		paths[p] = journalPath{info: info, fileID: j.fileID}
+
+		if tracked, ok := n.journals[j.fileID]; ok {
+			// Already reading the file, which may have been renamed on rotation
+			tracked.seen = true
+			j.Close()
+			continue
+		}
+
+		tracked := &trackedJournal{file: j, seen: true}
+		if n.firstPoll && n.startAtEnd && n.startCursor == nil {
+			n.seekEnd(tracked)
+		}
+		n.journals[j.fileID] = tracked
+	}
+
+	n.paths = paths
+
+	for id, j := range n.journals {
+		if !j.seen {
+			j.file.Close()
+			delete(n.journals, id)
+		}
+	}
+}
+
+// journalPaths lists the configured journal files, and the journal files in the configured
+// directories and their machine ID subdirectories.
+func (n *NativeInput) journalPaths() []string {
+	paths := append([]string{}, n.files...)
+	for _, dir := range n.directories {
+		for _, pattern := range []string{"*.journal", "*.journal~", "*/*.journal", "*/*.journal~"} {
+			matches, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err != nil {
+				continue
+			}
+			paths = append(paths, matches...)
+		}
+	}
+	return paths
+}
+
+// seekEnd moves the position past the entries that have already been written.
+func (n *NativeInput) seekEnd(j *trackedJournal) {
+	for {
+		_, ok, err := j.file.next(&j.pos)
+		if err != nil {
+			n.Warnw("Failed to read journal file", zap.String("path", j.file.path), zap.Error(err))
+			return
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// readJournal reads the next batch of entries of a journal file, and reports whether the batch is full.
+func (n *NativeInput) readJournal(j *trackedJournal) ([]nativeEntry, bool) {
+	var entries []nativeEntry
+	for len(entries) < maxBatchSize {
+		offset, ok, err := j.file.next(&j.pos)
+		if err != nil {
+			n.Warnw("Failed to read journal file", zap.String("path", j.file.path), zap.Error(err))
+			return entries, false
+		}
+		if !ok {
+			return entries, false
+		}
+
+		e, err := j.file.readEntry(offset)
+		if err != nil {
+			n.Warnw("Failed to read journal entry", zap.String("path", j.file.path), zap.Error(err))
+			continue
+		}
+		if n.startCursor != nil && !n.startCursor.before(j.file.seqnumID, e) {
+			continue
+		}
+
+		fields, err := n.readFields(j.file, e)
+		if err != nil {
+			n.Warnw("Failed to read journal entry", zap.String("path", j.file.path), zap.Error(err))
+			continue
+		}
+		if !n.filter.match(fields) {
+			continue
+		}
+
+		c := newCursor(j.file.seqnumID, e).String()
+		fields["__CURSOR"] = c
+		fields["__MONOTONIC_TIMESTAMP"] = strconv.FormatUint(e.monotonic, 10)
+		fields["_BOOT_ID"] = hex.EncodeToString(e.bootID[:])
+		entries = append(entries, nativeEntry{journal: e, fields: fields, cursor: c})
+	}
+	return entries, true
+}
+
+// readFields reads the data objects of an entry into a map, in the same
+// representation as the JSON output of journalctl. Fields that occur multiple
+// times are collected into a list, and values that are not printable are
+// represented as a list of bytes.
+func (n *NativeInput) readFields(j *journalFile, e *journalEntry) (map[string]interface{}, error) {
+	values := make(map[string][]interface{}, len(e.items))
+	for _, offset := range e.items {
+		data, err := j.readData(offset)
+		if err != nil {
+			return nil, err
+		}
+
+		sep := bytes.IndexByte(data, '=')
+		if sep <= 0 {
+			n.Debugw("Ignoring invalid journal field", zap.String("path", j.path), zap.Uint64("offset", offset))
+			continue
+		}
+		key := string(data[:sep])
+		values[key] = append(values[key], fieldValue(data[sep+1:]))
+	}
+
+	fields := make(map[string]interface{}, len(values)+3)
+	for key, v := range values {
+		if len(v) == 1 {
+			fields[key] = v[0]
+		} else {
+			fields[key] = v
+		}
+	}
+	return fields, nil
+}
+
+func fieldValue(value []byte) interface{} {
+	if isPrintable(value) {
+		return string(value)
+	}
+	b := make([]interface{}, len(value))
+	for i, c := range value {
+		b[i] = int64(c)
+	}
+	return b
+}
+
+// isPrintable reports whether a value is valid UTF-8 without control characters other than newlines.
+func isPrintable(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+	for _, r := range string(value) {
+		if r != '\n' && unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// cursor identifies an entry in the journal, in the format used by journalctl.
+type cursor struct {
+	seqnumID  id128
+	seqnum    uint64
+	bootID    id128
+	monotonic uint64
+	realtime  uint64
+	xorHash   uint64
+}
+
+func newCursor(seqnumID id128, e *journalEntry) cursor {
+	return cursor{
+		seqnumID:  seqnumID,
+		seqnum:    e.seqnum,
+		bootID:    e.bootID,
+		monotonic: e.monotonic,
+		realtime:  e.realtime,
+		xorHash:   e.xorHash,
+	}
+}
+
+func (c cursor) String() string {
+	return fmt.Sprintf("s=%x;i=%x;b=%x;m=%x;t=%x;x=%x", c.seqnumID, c.seqnum, c.bootID, c.monotonic, c.realtime, c.xorHash)
+}
+
+func parseCursor(s string) (cursor, error) {
+	var c cursor
+	for _, part := range strings.Split(s, ";") {
+		if len(part) < 2 || part[1] != '=' {
+			return c, fmt.Errorf("invalid cursor '%s'", s)
+		}
+
+		value := part[2:]
+		var err error
+		switch part[0] {
+		case 's':
+			err = parseID(value, &c.seqnumID)
+		case 'i':
+			c.seqnum, err = strconv.ParseUint(value, 16, 64)
+		case 'b':
+			err = parseID(value, &c.bootID)
+		case 'm':
+			c.monotonic, err = strconv.ParseUint(value, 16, 64)
+		case 't':
+			c.realtime, err = strconv.ParseUint(value, 16, 64)
+		case 'x':
+			c.xorHash, err = strconv.ParseUint(value, 16, 64)
+		}
+		if err != nil {
+			return c, fmt.Errorf("invalid cursor '%s': %w", s, err)
+		}
+	}
+	return c, nil
+}
+
+func parseID(s string, id *id128) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(b) != len(id) {
+		return fmt.Errorf("invalid id '%s'", s)
+	}
+	copy(id[:], b)
+	return nil
+}
+
+// before reports whether the cursor points to an entry that was written before the given entry.
+// Entries of the same sequence are compared by sequence number, others by their time.
+func (c cursor) before(seqnumID id128, e *journalEntry) bool {
+	if c.seqnumID == seqnumID {
+		return c.seqnum < e.seqnum
+	}
+	return c.realtime < e.realtime
+}
+
+// priorities are the syslog priorities accepted by journalctl
+var priorities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// unitTypes are the suffixes of systemd unit names
+var unitTypes = map[string]bool{
+	".service": true, ".socket": true, ".device": true, ".mount": true, ".automount": true, ".swap": true,
+	".target": true, ".path": true, ".timer": true, ".slice": true, ".scope": true,
+}
+
+// coredumpMessageID is the MESSAGE_ID of the entries written by systemd-coredump
+const coredumpMessageID = "fc2e22bc6ee647b6b90729ab34a250b1"
+
+// filter matches entries the same way as the --unit and --priority flags of journalctl.
+type filter struct {
+	units       []string
+	minPriority int
+	maxPriority int
+}
+
+func newFilter(units []string, priority string) (*filter, error) {
+	f := &filter{}
+	for _, unit := range units {
+		f.units = append(f.units, mangleUnit(unit))
+	}
+
+	from, to := "emerg", priority
+	if i := strings.Index(priority, ".."); i >= 0 {
+		from, to = priority[:i], priority[i+2:]
+	}
+
+	var err error
+	if f.minPriority, err = parsePriority(from); err != nil {
+		return nil, err
+	}
+	if f.maxPriority, err = parsePriority(to); err != nil {
+		return nil, err
+	}
+	if f.minPriority > f.maxPriority {
+		f.minPriority, f.maxPriority = f.maxPriority, f.minPriority
+	}
+	return f, nil
+}
+
+func parsePriority(priority string) (int, error) {
+	for i, name := range priorities {
+		if priority == name {
+			return i, nil
+		}
+	}
+	if p, err := strconv.Atoi(priority); err == nil && p >= 0 && p < len(priorities) {
+		return p, nil
+	}
+	return 0, fmt.Errorf("invalid value '%s' for parameter 'priority'", priority)
+}
+
+// mangleUnit adds the .service suffix to unit names without a unit type, like systemd does.
+func mangleUnit(unit string) string {
+	if strings.ContainsAny(unit, "*?[") || unitTypes[path.Ext(unit)] {
+		return unit
+	}
+	return unit + ".service"
+}
+
+func (f *filter) match(fields map[string]interface{}) bool {
+	// Like journalctl, entries without a priority only match when all priorities are selected
+	if f.minPriority > 0 || f.maxPriority < len(priorities)-1 {
+		priority, err := strconv.Atoi(stringField(fields, "PRIORITY"))
+		if err != nil || priority < f.minPriority || priority > f.maxPriority {
+			return false
+		}
+	}
+
+	if len(f.units) == 0 {
+		return true
+	}
+	for _, unit := range f.units {
+		if matchUnit(unit, stringField(fields, "_SYSTEMD_UNIT")) {
+			return true
+		}
+		// Messages from systemd and other privileged daemons about the unit
+		root := stringField(fields, "_UID") == "0"
+		if root && stringField(fields, "MESSAGE_ID") == coredumpMessageID && matchUnit(unit, stringField(fields, "COREDUMP_UNIT")) {
+			return true
+		}
+		if stringField(fields, "_PID") == "1" && matchUnit(unit, stringField(fields, "UNIT")) {
+			return true
+		}
+		if root && matchUnit(unit, stringField(fields, "OBJECT_SYSTEMD_UNIT")) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchUnit(pattern, unit string) bool {
+	if unit == "" {
+		return false
+	}
+	ok, err := path.Match(pattern, unit)
+	return err == nil && ok
+}
+
+func stringField(fields map[string]interface{}, key string) string {
+	s, _ := fields[key].(string)
+	return s
+}
@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package journald
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestNativeInput(t *testing.T, configure func(*Config)) (*NativeInput, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("test")
+	cfg.Reader = readerNative
+	cfg.StartAt = "beginning"
+	cfg.OutputIDs = []string{"fake"}
+	configure(cfg)
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	input := op.(*NativeInput)
+	input.pollInterval = 10 * time.Millisecond
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, input.SetOutputs([]operator.Operator{fake}))
+	return input, fake
+}
+
+// readExpected reads the JSON output of journalctl for a fixture.
+func readExpected(t *testing.T, name string) []map[string]interface{} {
+	file, err := os.Open(filepath.Join("testdata", name+".json"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	var expected []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var e map[string]interface{}
+		require.NoError(t, jsoniter.Unmarshal(scanner.Bytes(), &e))
+		expected = append(expected, e)
+	}
+	require.NoError(t, scanner.Err())
+	return expected
+}
+
+// expectEntries checks that the entries are read as journalctl outputs them.
+func expectEntries(t *testing.T, fake *testutil.FakeOutput, expected []map[string]interface{}) {
+	for _, want := range expected {
+		select {
+		case e := <-fake.Received:
+			realtime, err := strconv.ParseInt(want["__REALTIME_TIMESTAMP"].(string), 10, 64)
+			require.NoError(t, err)
+			require.Equal(t, realtime, e.Timestamp.UnixMicro())
+
+			// Compare the JSON representation, since journalctl outputs bytes as numbers
+			raw, err := jsoniter.Marshal(e.Body)
+			require.NoError(t, err)
+			var body map[string]interface{}
+			require.NoError(t, jsoniter.Unmarshal(raw, &body))
+
+			want = copyMap(want)
+			delete(want, "__REALTIME_TIMESTAMP")
+			require.Equal(t, want, body)
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for entry", want["__CURSOR"])
+		}
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func TestNativeInput(t *testing.T) {
+	cases := []struct {
+		fixture   string
+		configure func(*Config)
+	}{
+		{
+			"compact",
+			func(cfg *Config) { cfg.Priority = "debug" },
+		},
+		{
+			"regular",
+			func(cfg *Config) { cfg.Priority = "debug" },
+		},
+		{
+			"rotated",
+			func(cfg *Config) { cfg.Units = []string{"fixture"} },
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			dir := t.TempDir()
+			extractJournals(t, tc.fixture, dir)
+
+			input, fake := newTestNativeInput(t, func(cfg *Config) {
+				cfg.Directory = &dir
+				tc.configure(cfg)
+			})
+			require.NoError(t, input.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, input.Stop())
+			}()
+
+			expectEntries(t, fake, readExpected(t, tc.fixture))
+		})
+	}
+}
+
+func TestNativeInputFiles(t *testing.T) {
+	dir := t.TempDir()
+	extractJournals(t, "compact", dir)
+
+	input, fake := newTestNativeInput(t, func(cfg *Config) {
+		cfg.Files = []string{filepath.Join(dir, "system.journal")}
+		cfg.Priority = "debug"
+	})
+	require.NoError(t, input.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, input.Stop())
+	}()
+
+	expectEntries(t, fake, readExpected(t, "compact"))
+}
+
+func TestNativeInputCursor(t *testing.T) {
+	dir := t.TempDir()
+	extractJournals(t, "compact", dir)
+	expected := readExpected(t, "compact")
+
+	persister := testutil.NewMockPersister("test")
+	require.NoError(t, persister.Set(context.Background(), lastReadCursorKey, []byte(expected[2]["__CURSOR"].(string))))
+
+	input, fake := newTestNativeInput(t, func(cfg *Config) {
+		cfg.Directory = &dir
+		cfg.Priority = "debug"
+		// The saved cursor takes precedence
+		cfg.StartAt = "end"
+	})
+	require.NoError(t, input.Start(persister))
+	defer func() {
+		require.NoError(t, input.Stop())
+	}()
+
+	expectEntries(t, fake, expected[3:])
+
+	cursor, err := persister.Get(context.Background(), lastReadCursorKey)
+	require.NoError(t, err)
+	require.Equal(t, expected[len(expected)-1]["__CURSOR"], string(cursor))
+}
+
+func TestNativeInputStartAtEnd(t *testing.T) {
+	dir := t.TempDir()
+	extractJournals(t, "compact", filepath.Join(dir, "machine"))
+
+	input, fake := newTestNativeInput(t, func(cfg *Config) {
+		cfg.Directory = &dir
+		cfg.Priority = "debug"
+		cfg.StartAt = "end"
+	})
+	require.NoError(t, input.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, input.Stop())
+	}()
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	// Journal files created later are read from the beginning
+	extractJournals(t, "regular", filepath.Join(dir, "other"))
+	expectEntries(t, fake, readExpected(t, "regular"))
+}
+
+func TestNativeInputUpdateJournals(t *testing.T) {
+	dir := t.TempDir()
+	extractJournals(t, "compact", dir)
+
+	input, _ := newTestNativeInput(t, func(cfg *Config) {
+		cfg.Directory = &dir
+	})
+	input.journals = make(map[id128]*trackedJournal)
+	input.paths = make(map[string]journalPath)
+	defer func() {
+		require.NoError(t, input.Stop())
+	}()
+
+	input.updateJournals()
+	require.Len(t, input.journals, 1)
+	var file *journalFile
+	for _, j := range input.journals {
+		file = j.file
+	}
+
+	// Unchanged files are not opened again
+	input.updateJournals()
+	require.Len(t, input.journals, 1)
+	for _, j := range input.journals {
+		require.Same(t, file, j.file)
+	}
+
+	// The file is opened again when the path points to another file
+	other := t.TempDir()
+	extractJournals(t, "regular", other)
+	require.NoError(t, os.Rename(filepath.Join(other, "system.journal"), filepath.Join(dir, "system.journal")))
+	input.updateJournals()
+	require.Len(t, input.journals, 1)
+	for id, j := range input.journals {
+		require.NotEqual(t, file.fileID, id)
+		require.Equal(t, filepath.Join(dir, "system.journal"), j.file.path)
+	}
+}
+
+func TestNativeInputBuildFailure(t *testing.T) {
+	cases := []struct {
+		name        string
+		configure   func(*Config)
+		expectedErr string
+	}{
+		{"invalid_reader", func(cfg *Config) { cfg.Reader = "invalid" }, "invalid value 'invalid' for parameter 'reader'"},
+		{"invalid_start_at", func(cfg *Config) { cfg.StartAt = "invalid" }, "invalid value 'invalid' for parameter 'start_at'"},
+		{"invalid_priority", func(cfg *Config) { cfg.Priority = "loud" }, "invalid value 'loud' for parameter 'priority'"},
+		{"invalid_priority_range", func(cfg *Config) { cfg.Priority = "err..8" }, "invalid value '8' for parameter 'priority'"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Reader = readerNative
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	cases := []struct {
+		name     string
+		units    []string
+		priority string
+		fields   map[string]interface{}
+		expected bool
+	}{
+		{"priority", nil, "info", map[string]interface{}{"PRIORITY": "6"}, true},
+		{"priority_below", nil, "info", map[string]interface{}{"PRIORITY": "7"}, false},
+		{"priority_number", nil, "4", map[string]interface{}{"PRIORITY": "3"}, true},
+		{"priority_range", nil, "err..warning", map[string]interface{}{"PRIORITY": "3"}, true},
+		{"priority_range_above", nil, "err..warning", map[string]interface{}{"PRIORITY": "2"}, false},
+		{"missing_priority", nil, "info", map[string]interface{}{}, false},
+		{"all_priorities", nil, "debug", map[string]interface{}{}, true},
+		{"unit", []string{"ssh"}, "debug", map[string]interface{}{"_SYSTEMD_UNIT": "ssh.service"}, true},
+		{"unit_type", []string{"docker.socket"}, "debug", map[string]interface{}{"_SYSTEMD_UNIT": "docker.socket"}, true},
+		{"unit_glob", []string{"kube*"}, "debug", map[string]interface{}{"_SYSTEMD_UNIT": "kubelet.service"}, true},
+		{"other_unit", []string{"ssh", "kubelet"}, "debug", map[string]interface{}{"_SYSTEMD_UNIT": "docker.service"}, false},
+		{"unit_from_systemd", []string{"ssh"}, "debug", map[string]interface{}{"_PID": "1", "UNIT": "ssh.service"}, true},
+		{"unit_not_from_systemd", []string{"ssh"}, "debug", map[string]interface{}{"_PID": "2", "UNIT": "ssh.service"}, false},
+		{"unit_from_root", []string{"ssh"}, "debug", map[string]interface{}{"_UID": "0", "OBJECT_SYSTEMD_UNIT": "ssh.service"}, true},
+		{"unit_not_from_root", []string{"ssh"}, "debug", map[string]interface{}{"_UID": "1000", "OBJECT_SYSTEMD_UNIT": "ssh.service"}, false},
+		{"unit_coredump", []string{"ssh"}, "debug", map[string]interface{}{"_UID": "0", "MESSAGE_ID": coredumpMessageID, "COREDUMP_UNIT": "ssh.service"}, true},
+		{"unit_and_priority", []string{"ssh"}, "info", map[string]interface{}{"_SYSTEMD_UNIT": "ssh.service", "PRIORITY": "7"}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := newFilter(tc.units, tc.priority)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, f.match(tc.fields))
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	s := "s=b1e713b587ae4001a9ca482c4b12c005;i=1eed30;b=c4fa36de06824d21835c05ff80c54468;m=9f9d630205;t=5a369604ee333;x=16c2d4fd4fdb7c36"
+	c, err := parseCursor(s)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x1eed30), c.seqnum)
+	require.Equal(t, uint64(0x5a369604ee333), c.realtime)
+	require.Equal(t, s, c.String())
+
+	var seqnumID id128
+	copy(seqnumID[:], c.seqnumID[:])
+	require.True(t, c.before(seqnumID, &journalEntry{seqnum: c.seqnum + 1}))
+	require.False(t, c.before(seqnumID, &journalEntry{seqnum: c.seqnum}))
+	require.True(t, c.before(id128{}, &journalEntry{realtime: c.realtime + 1}))
+	require.False(t, c.before(id128{}, &journalEntry{realtime: c.realtime - 1}))
+
+	for _, invalid := range []string{"", "s=zz", "i=1;t", "b=c4fa36"} {
+		_, err := parseCursor(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	require.Equal(t, "multi\nline", fieldValue([]byte("multi\nline")))
+	require.Equal(t, []interface{}{int64(0), int64(1), int64(255)}, fieldValue([]byte{0, 1, 255}))
+	require.Equal(t, []interface{}{int64('a'), int64('\t'), int64('b')}, fieldValue([]byte("a\tb")))
+}
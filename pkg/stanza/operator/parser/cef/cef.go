@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/cef"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "cef_parser"
+
+	cefPrefix   = "CEF:"
+	headerCount = 7
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new CEF parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new CEF parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a CEF parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+}
+
+// Build will build a CEF parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+	}, nil
+}
+
+// Parser is an operator that parses ArcSight Common Event Format messages.
+type Parser struct {
+	helper.ParserOperator
+}
+
+// Process will parse an entry as CEF.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	var severity string
+	parse := func(value interface{}) (interface{}, error) {
+		parsed, err := p.parse(value)
+		if err != nil {
+			return nil, err
+		}
+		severity = parsed["severity"].(string)
+		return parsed, nil
+	}
+	return p.ParserOperator.ProcessWithCallback(ctx, e, parse, func(e *entry.Entry) error {
+		// The severity is mapped onto the entry unless a severity parser is configured
+		if p.SeverityParser == nil {
+			e.Severity = mapSeverity(severity)
+			e.SeverityText = severity
+		}
+		return nil
+	})
+}
+
+// parse will parse a value as CEF.
+func (p *Parser) parse(value interface{}) (map[string]interface{}, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("type %T cannot be parsed as CEF", value)
+	}
+
+	// A syslog header may precede the CEF message
+	start := strings.Index(raw, cefPrefix)
+	if start < 0 {
+		return nil, fmt.Errorf("missing '%s' prefix", cefPrefix)
+	}
+
+	header, extension, err := splitHeader(raw[start+len(cefPrefix):])
+	if err != nil {
+		return nil, err
+	}
+
+	extensions, err := parseExtension(extension)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"version":               header[0],
+		"device_vendor":         header[1],
+		"device_product":        header[2],
+		"device_version":        header[3],
+		"device_event_class_id": header[4],
+		"name":                  header[5],
+		"severity":              header[6],
+		"extensions":            extensions,
+	}, nil
+}
+
+// splitHeader splits a CEF message at its unescaped pipes into the header fields
+// and the extension. Pipes and backslashes in header fields are escaped with a backslash.
+func splitHeader(message string) ([]string, string, error) {
+	header := make([]string, 0, headerCount)
+	var field strings.Builder
+	for i := 0; i < len(message); i++ {
+		switch c := message[i]; {
+		case c == '\\' && i+1 < len(message) && (message[i+1] == '|' || message[i+1] == '\\'):
+			field.WriteByte(message[i+1])
+			i++
+		case c == '|':
+			header = append(header, field.String())
+			field.Reset()
+			if len(header) == headerCount {
+				return header, message[i+1:], nil
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	return nil, "", fmt.Errorf("expected %d header fields, got %d", headerCount, len(header))
+}
+
+// parseExtension parses the space separated key=value pairs of a CEF extension.
+// Values may contain spaces, so a value ends where the next key starts. Equal signs,
+// backslashes and line breaks in values are escaped with a backslash.
+func parseExtension(extension string) (map[string]interface{}, error) {
+	extensions := make(map[string]interface{})
+	extension = strings.TrimSpace(extension)
+	if extension == "" {
+		return extensions, nil
+	}
+
+	var key string
+	valueStart := -1
+	for i := 0; i < len(extension); i++ {
+		switch extension[i] {
+		case '\\':
+			// Skip the escaped character
+			i++
+		case '=':
+			if valueStart < 0 {
+				key = extension[:i]
+				if key == "" || strings.Contains(key, " ") {
+					return nil, fmt.Errorf("invalid extension key '%s'", key)
+				}
+				valueStart = i + 1
+				continue
+			}
+
+			// The next key starts after the last space before the equal sign
+			keyStart := strings.LastIndexByte(extension[valueStart:i], ' ')
+			if keyStart < 0 {
+				// An unescaped equal sign within the value
+				continue
+			}
+			keyStart += valueStart
+			extensions[key] = unescapeValue(strings.TrimRight(extension[valueStart:keyStart], " "))
+			key = extension[keyStart+1 : i]
+			if key == "" {
+				return nil, fmt.Errorf("invalid extension key '%s'", key)
+			}
+			valueStart = i + 1
+		}
+	}
+
+	if valueStart < 0 {
+		return nil, fmt.Errorf("invalid extension '%s'", extension)
+	}
+	extensions[key] = unescapeValue(extension[valueStart:])
+	return extensions, nil
+}
+
+var valueReplacer = strings.NewReplacer(`\\`, `\`, `\=`, `=`, `\|`, `|`, `\n`, "\n", `\r`, "\r")
+
+func unescapeValue(value string) string {
+	return valueReplacer.Replace(value)
+}
+
+// mapSeverity maps a CEF severity onto an entry severity. The severity is either an
+// integer from 0 to 10, or one of Unknown, Low, Medium, High and Very-High.
+func mapSeverity(severity string) entry.Severity {
+	if n, err := strconv.Atoi(severity); err == nil {
+		switch {
+		case n >= 0 && n <= 3:
+			return entry.Info
+		case n >= 4 && n <= 6:
+			return entry.Warn
+		case n >= 7 && n <= 8:
+			return entry.Error
+		case n >= 9 && n <= 10:
+			return entry.Fatal
+		}
+		return entry.Default
+	}
+
+	switch strings.ToLower(severity) {
+	case "low":
+		return entry.Info
+	case "medium":
+		return entry.Warn
+	case "high":
+		return entry.Error
+	case "very-high":
+		return entry.Fatal
+	}
+	return entry.Default
+}
@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cef
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	op, err := NewConfigWithID("test").Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid `on_error` field")
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected map[string]interface{}
+	}{
+		{
+			"simple",
+			"CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232",
+			map[string]interface{}{
+				"version":               "0",
+				"device_vendor":         "Security",
+				"device_product":        "threatmanager",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "worm successfully stopped",
+				"severity":              "10",
+				"extensions": map[string]interface{}{
+					"src": "10.0.0.1",
+					"dst": "2.1.2.2",
+					"spt": "1232",
+				},
+			},
+		},
+		{
+			"syslog_prefix",
+			"Sep 19 08:26:10 host CEF:0|Vendor|Product|1.0|100|Name|Low|",
+			map[string]interface{}{
+				"version":               "0",
+				"device_vendor":         "Vendor",
+				"device_product":        "Product",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "Name",
+				"severity":              "Low",
+				"extensions":            map[string]interface{}{},
+			},
+		},
+		{
+			"escaped_header",
+			`CEF:0|security|threat\|manager|1.0|100|detected a \\ in packet|10|`,
+			map[string]interface{}{
+				"version":               "0",
+				"device_vendor":         "security",
+				"device_product":        "threat|manager",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  `detected a \ in packet`,
+				"severity":              "10",
+				"extensions":            map[string]interface{}{},
+			},
+		},
+		{
+			"extension_values_with_spaces_and_escapes",
+			`CEF:0|Vendor|Product|1.0|100|Name|5|msg=Detected a threat. No action needed.\nline two act=blocked a \= b cs1=C:\\Windows cs1Label=Path`,
+			map[string]interface{}{
+				"version":               "0",
+				"device_vendor":         "Vendor",
+				"device_product":        "Product",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "Name",
+				"severity":              "5",
+				"extensions": map[string]interface{}{
+					"msg":      "Detected a threat. No action needed.\nline two",
+					"act":      "blocked a = b",
+					"cs1":      `C:\Windows`,
+					"cs1Label": "Path",
+				},
+			},
+		},
+		{
+			"pipe_in_extension",
+			"CEF:0|Vendor|Product|1.0|100|Name|5|request=https://example.com/?a|b suser=admin",
+			map[string]interface{}{
+				"version":               "0",
+				"device_vendor":         "Vendor",
+				"device_product":        "Product",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "Name",
+				"severity":              "5",
+				"extensions": map[string]interface{}{
+					"request": "https://example.com/?a|b",
+					"suser":   "admin",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := newTestParser(t).parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func TestParseFailure(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       interface{}
+		expectedErr string
+	}{
+		{"invalid_type", []byte("CEF:0|"), "type []uint8 cannot be parsed as CEF"},
+		{"missing_prefix", "0|Vendor|Product|1.0|100|Name|5|", "missing 'CEF:' prefix"},
+		{"missing_header_fields", "CEF:0|Vendor|Product|1.0|100|Name", "expected 7 header fields, got 5"},
+		{"invalid_extension", "CEF:0|Vendor|Product|1.0|100|Name|5|no pairs here", "invalid extension 'no pairs here'"},
+		{"invalid_extension_key", "CEF:0|Vendor|Product|1.0|100|Name|5|bad key=value", "invalid extension key 'bad key'"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newTestParser(t).parse(tc.input)
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMapSeverity(t *testing.T) {
+	cases := []struct {
+		severity string
+		expected entry.Severity
+	}{
+		{"0", entry.Info},
+		{"3", entry.Info},
+		{"4", entry.Warn},
+		{"6", entry.Warn},
+		{"7", entry.Error},
+		{"8", entry.Error},
+		{"9", entry.Fatal},
+		{"10", entry.Fatal},
+		{"11", entry.Default},
+		{"Unknown", entry.Default},
+		{"Low", entry.Info},
+		{"Medium", entry.Warn},
+		{"High", entry.Error},
+		{"Very-High", entry.Fatal},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.severity, func(t *testing.T) {
+			require.Equal(t, tc.expected, mapSeverity(tc.severity))
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     *entry.Entry
+		expect    *entry.Entry
+	}{
+		{
+			"after_syslog_parser",
+			func(c *Config) {
+				c.ParseFrom = entry.NewAttributeField("message")
+				c.ParseTo = entry.RootableField{Field: entry.NewAttributeField("cef")}
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"hostname": "host",
+					"message":  "CEF:0|Vendor|Product|1.0|100|Name|7|src=10.0.0.1",
+				},
+			},
+			&entry.Entry{
+				Severity:     entry.Error,
+				SeverityText: "7",
+				Attributes: map[string]interface{}{
+					"hostname": "host",
+					"message":  "CEF:0|Vendor|Product|1.0|100|Name|7|src=10.0.0.1",
+					"cef": map[string]interface{}{
+						"version":               "0",
+						"device_vendor":         "Vendor",
+						"device_product":        "Product",
+						"device_version":        "1.0",
+						"device_event_class_id": "100",
+						"name":                  "Name",
+						"severity":              "7",
+						"extensions": map[string]interface{}{
+							"src": "10.0.0.1",
+						},
+					},
+				},
+			},
+		},
+		{
+			"severity_parser_configured",
+			func(c *Config) {
+				parseFrom := entry.NewAttributeField("severity")
+				severity := helper.NewSeverityConfig()
+				severity.ParseFrom = &parseFrom
+				severity.Mapping = map[interface{}]interface{}{"error": "High"}
+				c.SeverityConfig = &severity
+			},
+			&entry.Entry{
+				Body: "CEF:0|Vendor|Product|1.0|100|Name|High|",
+			},
+			&entry.Entry{
+				Severity:     entry.Error,
+				SeverityText: "High",
+				Body:         "CEF:0|Vendor|Product|1.0|100|Name|High|",
+				Attributes: map[string]interface{}{
+					"version":               "0",
+					"device_vendor":         "Vendor",
+					"device_product":        "Product",
+					"device_version":        "1.0",
+					"device_event_class_id": "100",
+					"name":                  "Name",
+					"severity":              "High",
+					"extensions":            map[string]interface{}{},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+			tc.expect.ObservedTimestamp = ots
+
+			require.NoError(t, op.Process(context.Background(), tc.input))
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
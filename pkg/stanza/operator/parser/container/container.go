@@ -0,0 +1,342 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "container"
+
+	formatAuto       = ""
+	formatDocker     = "docker"
+	formatCRIO       = "crio"
+	formatContainerd = "containerd"
+
+	streamAttribute   = "log.iostream"
+	filePathAttribute = "log.file.path"
+
+	criPartialTag = "P"
+)
+
+var (
+	// criLogRegex matches the log lines written by CRI-O and containerd, such as
+	// "2023-06-22T10:10:38.148126552Z stdout F message"
+	criLogRegex = regexp.MustCompile(`^(?P<time>[^ ]+) (?P<stream>stdout|stderr) (?P<logtag>[^ ]*) ?(?P<log>.*)$`)
+
+	// podLogPathRegex matches the path of a Kubernetes container log file, such as
+	// "/var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log"
+	podLogPathRegex = regexp.MustCompile(`^.*[/\\](?P<namespace>[^_/\\]+)_(?P<pod_name>[^_/\\]+)_(?P<uid>[a-f0-9\-]+)[/\\](?P<container_name>[^._/\\]+)[/\\](?P<restart_count>\d+)\.log$`)
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new container parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig:       helper.NewTransformerConfig(operatorID, operatorType),
+		AddMetadataFromFilePath: true,
+		ForceFlushTimeout:       5 * time.Second,
+	}
+}
+
+// Config is the configuration of a container parser operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+
+	Format                  string          `mapstructure:"format"`
+	AddMetadataFromFilePath bool            `mapstructure:"add_metadata_from_filepath"`
+	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size"`
+	ForceFlushTimeout       time.Duration   `mapstructure:"force_flush_period"`
+}
+
+// Build will build a container parser operator
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformer, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Format {
+	case formatAuto, formatDocker, formatCRIO, formatContainerd:
+	default:
+		return nil, fmt.Errorf("invalid format '%s'", c.Format)
+	}
+
+	if c.MaxLogSize < 0 {
+		return nil, errors.New("'max_log_size' must not be negative")
+	}
+
+	if c.ForceFlushTimeout <= 0 {
+		return nil, errors.New("'force_flush_period' must be positive")
+	}
+
+	return &Parser{
+		TransformerOperator:     transformer,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+		maxLogSize:              int(c.MaxLogSize),
+		forceFlushTimeout:       c.ForceFlushTimeout,
+		json:                    jsoniter.ConfigFastest,
+		partials:                make(map[string]*partialLog),
+		chClose:                 make(chan struct{}),
+	}, nil
+}
+
+// Parser is an operator that parses the log lines written by container runtimes
+type Parser struct {
+	helper.TransformerOperator
+	format                  string
+	addMetadataFromFilePath bool
+	maxLogSize              int
+	forceFlushTimeout       time.Duration
+	json                    jsoniter.API
+	chClose                 chan struct{}
+	wg                      sync.WaitGroup
+
+	sync.Mutex
+	partials map[string]*partialLog
+}
+
+// containerLog is a single log line written by a container runtime
+type containerLog struct {
+	timestamp time.Time
+	stream    string
+	log       string
+	partial   bool
+}
+
+// partialLog holds a log that was split across several lines, until its last line is read
+type partialLog struct {
+	entry   *entry.Entry
+	log     strings.Builder
+	updated time.Time
+}
+
+// dockerLog is a line of a docker json-file log
+type dockerLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// Start will start the flushing of incomplete partial logs
+func (p *Parser) Start(_ operator.Persister) error {
+	p.wg.Add(1)
+	go p.flushLoop()
+	return nil
+}
+
+// Stop will flush all partial logs and stop the parser
+func (p *Parser) Stop() error {
+	close(p.chClose)
+	p.wg.Wait()
+
+	p.Lock()
+	defer p.Unlock()
+	for key := range p.partials {
+		p.flush(context.Background(), key)
+	}
+	return nil
+}
+
+func (p *Parser) flushLoop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.forceFlushTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.Lock()
+			now := time.Now()
+			for key, partial := range p.partials {
+				if now.Sub(partial.updated) >= p.forceFlushTimeout {
+					p.flush(context.Background(), key)
+				}
+			}
+			p.Unlock()
+		case <-p.chClose:
+			return
+		}
+	}
+}
+
+// Process will parse a log line written by a container runtime
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.Write(ctx, e)
+		return nil
+	}
+
+	line, ok := e.Body.(string)
+	if !ok {
+		return p.HandleEntryError(ctx, e, fmt.Errorf("type %T cannot be parsed as a container log", e.Body))
+	}
+
+	parsed, err := p.parse(line)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	if p.addMetadataFromFilePath {
+		if err := addMetadataFromFilePath(e); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	// Lines of a file are read in order, but stdout and stderr are interleaved within it
+	key := sourceKey(e, parsed.stream)
+	partial, ok := p.partials[key]
+	if !ok {
+		if !parsed.partial {
+			setLog(e, parsed.log, parsed.stream, parsed.timestamp)
+			p.Write(ctx, e)
+			return nil
+		}
+		setLog(e, "", parsed.stream, parsed.timestamp)
+		partial = &partialLog{entry: e}
+		p.partials[key] = partial
+	}
+
+	partial.log.WriteString(parsed.log)
+	partial.updated = time.Now()
+
+	if !parsed.partial || (p.maxLogSize > 0 && partial.log.Len() >= p.maxLogSize) {
+		p.flush(ctx, key)
+	}
+	return nil
+}
+
+// flush writes the partial log with the given key. The lock must be held by the caller.
+func (p *Parser) flush(ctx context.Context, key string) {
+	partial := p.partials[key]
+	delete(p.partials, key)
+	partial.entry.Body = partial.log.String()
+	p.Write(ctx, partial.entry)
+}
+
+func (p *Parser) parse(line string) (*containerLog, error) {
+	switch p.format {
+	case formatDocker:
+		return p.parseDocker(line)
+	case formatCRIO, formatContainerd:
+		return parseCRI(line)
+	}
+
+	if strings.HasPrefix(line, "{") {
+		return p.parseDocker(line)
+	}
+	return parseCRI(line)
+}
+
+func (p *Parser) parseDocker(line string) (*containerLog, error) {
+	var parsed dockerLog
+	if err := p.json.UnmarshalFromString(line, &parsed); err != nil {
+		return nil, fmt.Errorf("parse docker log: %w", err)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parsed.Time)
+	if err != nil {
+		return nil, fmt.Errorf("parse docker log time: %w", err)
+	}
+
+	// The json-file driver splits long lines, and only the last part ends with a newline
+	log := strings.TrimSuffix(parsed.Log, "\n")
+	return &containerLog{
+		timestamp: timestamp.UTC(),
+		stream:    parsed.Stream,
+		log:       log,
+		partial:   len(log) == len(parsed.Log),
+	}, nil
+}
+
+func parseCRI(line string) (*containerLog, error) {
+	match := criLogRegex.FindStringSubmatch(line)
+	if match == nil {
+		return nil, errors.New("log does not match the CRI format")
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, match[criLogRegex.SubexpIndex("time")])
+	if err != nil {
+		return nil, fmt.Errorf("parse CRI log time: %w", err)
+	}
+
+	return &containerLog{
+		timestamp: timestamp.UTC(),
+		stream:    match[criLogRegex.SubexpIndex("stream")],
+		log:       match[criLogRegex.SubexpIndex("log")],
+		partial:   match[criLogRegex.SubexpIndex("logtag")] == criPartialTag,
+	}, nil
+}
+
+func setLog(e *entry.Entry, log string, stream string, timestamp time.Time) {
+	e.Body = log
+	e.Timestamp = timestamp
+	e.AddAttribute(streamAttribute, stream)
+}
+
+func sourceKey(e *entry.Entry, stream string) string {
+	path, _ := e.Attributes[filePathAttribute].(string)
+	return path + "\x00" + stream
+}
+
+// addMetadataFromFilePath adds the Kubernetes metadata found in the path of the log file to the resource
+func addMetadataFromFilePath(e *entry.Entry) error {
+	path, ok := e.Attributes[filePathAttribute].(string)
+	if !ok {
+		return fmt.Errorf("missing attribute '%s', required to add metadata from the file path", filePathAttribute)
+	}
+
+	match := podLogPathRegex.FindStringSubmatch(path)
+	if match == nil {
+		return fmt.Errorf("file path '%s' does not match the Kubernetes pod log path format", path)
+	}
+
+	e.AddResourceKey("k8s.namespace.name", match[podLogPathRegex.SubexpIndex("namespace")])
+	e.AddResourceKey("k8s.pod.name", match[podLogPathRegex.SubexpIndex("pod_name")])
+	e.AddResourceKey("k8s.pod.uid", match[podLogPathRegex.SubexpIndex("uid")])
+	e.AddResourceKey("k8s.container.name", match[podLogPathRegex.SubexpIndex("container_name")])
+	e.AddResourceKey("k8s.container.restart_count", match[podLogPathRegex.SubexpIndex("restart_count")])
+	return nil
+}
@@ -0,0 +1,325 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const testPath = "/var/log/pods/kube-system_kube-proxy-8x5qk_49cc7c1fd3702c40b2686ea7486091d6/kube-proxy/1.log"
+
+func newTestParser(t *testing.T, configure func(*Config)) (*Parser, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	if configure != nil {
+		configure(cfg)
+	}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	return op.(*Parser), fake
+}
+
+func newTestEntry(body string) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.AddAttribute("log.file.path", testPath)
+	return e
+}
+
+func expectedResource() map[string]interface{} {
+	return map[string]interface{}{
+		"k8s.namespace.name":          "kube-system",
+		"k8s.pod.name":                "kube-proxy-8x5qk",
+		"k8s.pod.uid":                 "49cc7c1fd3702c40b2686ea7486091d6",
+		"k8s.container.name":          "kube-proxy",
+		"k8s.container.restart_count": "1",
+	}
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cases := []struct {
+		name        string
+		configure   func(*Config)
+		expectedErr string
+	}{
+		{"InvalidFormat", func(c *Config) { c.Format = "podman" }, "invalid format 'podman'"},
+		{"NegativeMaxLogSize", func(c *Config) { c.MaxLogSize = -1 }, "'max_log_size' must not be negative"},
+		{"ZeroForceFlushPeriod", func(c *Config) { c.ForceFlushTimeout = 0 }, "'force_flush_period' must be positive"},
+		{"InvalidOnError", func(c *Config) { c.OnError = "invalid_on_error" }, "invalid `on_error` field"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     string
+		expect    *entry.Entry
+	}{
+		{
+			"docker",
+			nil,
+			`{"log":"INFO: log line here\n","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+			&entry.Entry{
+				Timestamp: time.Date(2029, time.March, 30, 8, 31, 20, 545192187, time.UTC),
+				Body:      "INFO: log line here",
+				Attributes: map[string]interface{}{
+					"log.file.path": testPath,
+					"log.iostream":  "stdout",
+				},
+				Resource: expectedResource(),
+			},
+		},
+		{
+			"crio",
+			nil,
+			"2023-06-22T10:10:38.148126552+00:00 stderr F error line here",
+			&entry.Entry{
+				Timestamp: time.Date(2023, time.June, 22, 10, 10, 38, 148126552, time.UTC),
+				Body:      "error line here",
+				Attributes: map[string]interface{}{
+					"log.file.path": testPath,
+					"log.iostream":  "stderr",
+				},
+				Resource: expectedResource(),
+			},
+		},
+		{
+			"containerd",
+			func(c *Config) { c.Format = "containerd" },
+			"2023-06-22T10:10:38.148126552Z stdout F log line here",
+			&entry.Entry{
+				Timestamp: time.Date(2023, time.June, 22, 10, 10, 38, 148126552, time.UTC),
+				Body:      "log line here",
+				Attributes: map[string]interface{}{
+					"log.file.path": testPath,
+					"log.iostream":  "stdout",
+				},
+				Resource: expectedResource(),
+			},
+		},
+		{
+			"empty_cri_log",
+			nil,
+			"2023-06-22T10:10:38.148126552Z stdout F",
+			&entry.Entry{
+				Timestamp: time.Date(2023, time.June, 22, 10, 10, 38, 148126552, time.UTC),
+				Body:      "",
+				Attributes: map[string]interface{}{
+					"log.file.path": testPath,
+					"log.iostream":  "stdout",
+				},
+				Resource: expectedResource(),
+			},
+		},
+		{
+			"without_metadata",
+			func(c *Config) { c.AddMetadataFromFilePath = false },
+			"2023-06-22T10:10:38.148126552Z stdout F log line here",
+			&entry.Entry{
+				Timestamp: time.Date(2023, time.June, 22, 10, 10, 38, 148126552, time.UTC),
+				Body:      "log line here",
+				Attributes: map[string]interface{}{
+					"log.file.path": testPath,
+					"log.iostream":  "stdout",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, fake := newTestParser(t, tc.configure)
+
+			e := newTestEntry(tc.input)
+			tc.expect.ObservedTimestamp = e.ObservedTimestamp
+			require.NoError(t, op.Process(context.Background(), e))
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
+
+func TestParserErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		configure   func(*Config)
+		entry       *entry.Entry
+		expectedErr string
+	}{
+		{
+			"invalid_docker",
+			nil,
+			newTestEntry(`{"log":"INFO: log line here\n"`),
+			"parse docker log",
+		},
+		{
+			"invalid_docker_time",
+			nil,
+			newTestEntry(`{"log":"INFO: log line here\n","stream":"stdout","time":"yesterday"}`),
+			"parse docker log time",
+		},
+		{
+			"invalid_cri",
+			nil,
+			newTestEntry("log line here"),
+			"log does not match the CRI format",
+		},
+		{
+			"docker_format_with_cri_line",
+			func(c *Config) { c.Format = "docker" },
+			newTestEntry("2023-06-22T10:10:38.148126552Z stdout F log line here"),
+			"parse docker log",
+		},
+		{
+			"invalid_type",
+			nil,
+			func() *entry.Entry {
+				e := newTestEntry("")
+				e.Body = []byte("2023-06-22T10:10:38.148126552Z stdout F log line here")
+				return e
+			}(),
+			"type []uint8 cannot be parsed as a container log",
+		},
+		{
+			"missing_file_path",
+			nil,
+			func() *entry.Entry {
+				e := entry.New()
+				e.Body = "2023-06-22T10:10:38.148126552Z stdout F log line here"
+				return e
+			}(),
+			"missing attribute 'log.file.path'",
+		},
+		{
+			"invalid_file_path",
+			nil,
+			func() *entry.Entry {
+				e := newTestEntry("2023-06-22T10:10:38.148126552Z stdout F log line here")
+				e.Attributes["log.file.path"] = "/var/log/syslog"
+				return e
+			}(),
+			"file path '/var/log/syslog' does not match the Kubernetes pod log path format",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, _ := newTestParser(t, tc.configure)
+			err := op.Process(context.Background(), tc.entry)
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestParserPartialCRI(t *testing.T) {
+	op, fake := newTestParser(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, op.Process(ctx, newTestEntry("2023-06-22T10:10:38.000000001Z stdout P first ")))
+	require.NoError(t, op.Process(ctx, newTestEntry("2023-06-22T10:10:38.000000002Z stderr F error line")))
+	require.NoError(t, op.Process(ctx, newTestEntry("2023-06-22T10:10:38.000000003Z stdout P second ")))
+	require.NoError(t, op.Process(ctx, newTestEntry("2023-06-22T10:10:38.000000004Z stdout F third")))
+
+	e := <-fake.Received
+	require.Equal(t, "error line", e.Body)
+	require.Equal(t, "stderr", e.Attributes["log.iostream"])
+
+	e = <-fake.Received
+	require.Equal(t, "first second third", e.Body)
+	require.Equal(t, "stdout", e.Attributes["log.iostream"])
+	require.Equal(t, time.Date(2023, time.June, 22, 10, 10, 38, 1, time.UTC), e.Timestamp)
+	require.Equal(t, expectedResource(), e.Resource)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestParserPartialDocker(t *testing.T) {
+	op, fake := newTestParser(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, op.Process(ctx, newTestEntry(`{"log":"first ","stream":"stdout","time":"2029-03-30T08:31:20.000000001Z"}`)))
+	require.NoError(t, op.Process(ctx, newTestEntry(`{"log":"second\n","stream":"stdout","time":"2029-03-30T08:31:20.000000002Z"}`)))
+
+	e := <-fake.Received
+	require.Equal(t, "first second", e.Body)
+	require.Equal(t, time.Date(2029, time.March, 30, 8, 31, 20, 1, time.UTC), e.Timestamp)
+}
+
+func TestParserPartialMaxLogSize(t *testing.T) {
+	op, fake := newTestParser(t, func(c *Config) { c.MaxLogSize = 10 })
+	ctx := context.Background()
+
+	require.NoError(t, op.Process(ctx, newTestEntry("2023-06-22T10:10:38.000000001Z stdout P 123456")))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+	require.NoError(t, op.Process(ctx, newTestEntry("2023-06-22T10:10:38.000000002Z stdout P 7890")))
+
+	e := <-fake.Received
+	require.Equal(t, "1234567890", e.Body)
+}
+
+func TestParserPartialForceFlush(t *testing.T) {
+	op, fake := newTestParser(t, func(c *Config) { c.ForceFlushTimeout = 100 * time.Millisecond })
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+
+	require.NoError(t, op.Process(context.Background(), newTestEntry("2023-06-22T10:10:38.000000001Z stdout P incomplete")))
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "incomplete", e.Body)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for flushed entry")
+	}
+}
+
+func TestParserStopFlushes(t *testing.T) {
+	op, fake := newTestParser(t, nil)
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+
+	require.NoError(t, op.Process(context.Background(), newTestEntry("2023-06-22T10:10:38.000000001Z stdout P incomplete")))
+	require.NoError(t, op.Stop())
+
+	e := <-fake.Received
+	require.Equal(t, "incomplete", e.Body)
+}
+
+func TestAddMetadataFromWindowsFilePath(t *testing.T) {
+	e := entry.New()
+	e.AddAttribute("log.file.path", `C:\var\log\pods\kube-system_kube-proxy-8x5qk_49cc7c1fd3702c40b2686ea7486091d6\kube-proxy\1.log`)
+	require.NoError(t, addMetadataFromFilePath(e))
+	require.Equal(t, expectedResource(), e.Resource)
+}
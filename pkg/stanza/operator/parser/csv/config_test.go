@@ -76,6 +76,16 @@ func TestConfig(t *testing.T) {
 					return p
 				}(),
 			},
+			{
+				Name: "header_prefix",
+				Expect: func() *Config {
+					p := NewConfig()
+					p.HeaderAttribute = "log.file.header"
+					p.HeaderPrefix = "#Fields: "
+					p.FieldDelimiter = " "
+					return p
+				}(),
+			},
 			{
 				Name: "timestamp",
 				Expect: func() *Config {
@@ -52,6 +52,7 @@ type Config struct {
 
 	Header          string `mapstructure:"header"`
 	HeaderAttribute string `mapstructure:"header_attribute"`
+	HeaderPrefix    string `mapstructure:"header_prefix"`
 	FieldDelimiter  string `mapstructure:"delimiter"`
 	LazyQuotes      bool   `mapstructure:"lazy_quotes"`
 	IgnoreQuotes    bool   `mapstructure:"ignore_quotes"`
@@ -90,10 +91,15 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		headers = strings.Split(c.Header, c.FieldDelimiter)
 	}
 
+	if c.HeaderPrefix != "" && c.HeaderAttribute == "" {
+		return nil, errors.New("'header_prefix' requires 'header_attribute'")
+	}
+
 	return &Parser{
 		ParserOperator:  parserOperator,
 		header:          headers,
 		headerAttribute: c.HeaderAttribute,
+		headerPrefix:    c.HeaderPrefix,
 		fieldDelimiter:  fieldDelimiter,
 		lazyQuotes:      c.LazyQuotes,
 		ignoreQuotes:    c.IgnoreQuotes,
@@ -107,6 +113,7 @@ type Parser struct {
 	fieldDelimiter  rune
 	header          []string
 	headerAttribute string
+	headerPrefix    string
 	lazyQuotes      bool
 	ignoreQuotes    bool
 	parse           parseFunc
@@ -132,6 +139,14 @@ func (r *Parser) Process(ctx context.Context, e *entry.Entry) error {
 			r.Error(err)
 			return err
 		}
+		if r.headerPrefix != "" {
+			var found bool
+			if headerString, found = findHeaderLine(headerString, r.headerPrefix); !found {
+				err := fmt.Errorf("header attribute %s has no line starting with '%s'", r.headerAttribute, r.headerPrefix)
+				r.Error(err)
+				return err
+			}
+		}
 		headers := strings.Split(headerString, string([]rune{r.fieldDelimiter}))
 		parse = generateParseFunc(headers, r.fieldDelimiter, r.lazyQuotes, r.ignoreQuotes)
 	}
@@ -139,6 +154,20 @@ func (r *Parser) Process(ctx context.Context, e *entry.Entry) error {
 	return r.ParserOperator.ProcessWith(ctx, e, parse)
 }
 
+// findHeaderLine returns the last line of a multiline header that starts with the prefix,
+// with the prefix removed. For example, the field names of a W3C extended log file are
+// found in the line starting with "#Fields: ".
+func findHeaderLine(header string, prefix string) (string, bool) {
+	lines := strings.Split(header, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSuffix(lines[i], "\r")
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimPrefix(line, prefix), true
+		}
+	}
+	return "", false
+}
+
 // generateParseFunc returns a parse function for a given header, allowing
 // each entry to have a potentially unique set of fields when using dynamic
 // field names retrieved from an entry's attribute
@@ -80,6 +80,15 @@ func TestParserBuildFailureBadHeaderConfig(t *testing.T) {
 	require.Contains(t, err.Error(), "only one header parameter can be set: 'header' or 'header_attribute'")
 }
 
+func TestParserBuildFailureHeaderPrefixWithoutAttribute(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Header = testHeader
+	cfg.HeaderPrefix = "#Fields: "
+	_, err := cfg.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'header_prefix' requires 'header_attribute'")
+}
+
 func TestParserByteFailure(t *testing.T) {
 	parser := newTestParser(t)
 	_, err := parser.parse([]byte("invalid"))
@@ -333,6 +342,62 @@ func TestParserCSV(t *testing.T) {
 			false,
 			false,
 		},
+		{
+			"dynamic-fields-header-prefix",
+			func(p *Config) {
+				p.HeaderAttribute = "log.file.header"
+				p.HeaderPrefix = "#Fields: "
+				p.FieldDelimiter = " "
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "#Version: 1.0\n#Date: 2023-02-06 10:00:00\n#Fields: date time c-ip cs-method",
+					},
+					Body: "2023-02-06 10:00:00 10.0.0.1 GET",
+				},
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "#Version: 1.0\n#Date: 2023-02-06 10:00:00\n#Fields: date time c-ip cs-method",
+						"date":            "2023-02-06",
+						"time":            "10:00:00",
+						"c-ip":            "10.0.0.1",
+						"cs-method":       "GET",
+					},
+					Body: "2023-02-06 10:00:00 10.0.0.1 GET",
+				},
+			},
+			false,
+			false,
+		},
+		{
+			"dynamic-fields-header-prefix-missing",
+			func(p *Config) {
+				p.HeaderAttribute = "log.file.header"
+				p.HeaderPrefix = "#Fields: "
+				p.FieldDelimiter = " "
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "#Version: 1.0",
+					},
+					Body: "2023-02-06 10:00:00 10.0.0.1 GET",
+				},
+			},
+			[]entry.Entry{
+				{
+					Attributes: map[string]interface{}{
+						"log.file.header": "#Version: 1.0",
+					},
+					Body: "2023-02-06 10:00:00 10.0.0.1 GET",
+				},
+			},
+			false,
+			true,
+		},
 		{
 			"dynamic-fields-label-missing",
 			func(p *Config) {
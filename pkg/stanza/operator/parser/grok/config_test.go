@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grok
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestParserGoldenConfig(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "cache",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Cache.Size = 50
+					return cfg
+				}(),
+			},
+			{
+				Name: "on_error_drop",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.OnError = "drop"
+					return cfg
+				}(),
+			},
+			{
+				Name: "parse_from_simple",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseFrom = entry.NewBodyField("from")
+					return cfg
+				}(),
+			},
+			{
+				Name: "pattern",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{COMBINEDAPACHELOG}"
+					return cfg
+				}(),
+			},
+			{
+				Name: "patterns",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{QUEUE_ID:queue_id}: %{GREEDYDATA:message}"
+					cfg.Patterns = map[string]string{"QUEUE_ID": "[0-9A-F]{10,11}"}
+					return cfg
+				}(),
+			},
+			{
+				Name: "pattern_files",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Pattern = "%{POSTFIX_LINE}"
+					cfg.PatternFiles = []string{"./testdata/postfix"}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grok // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/grok"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
+)
+
+const (
+	operatorType = "grok_parser"
+
+	captureTypeString = "string"
+	captureTypeInt    = "int"
+	captureTypeFloat  = "float"
+)
+
+var (
+	// referenceRegex matches a reference to a pattern, such as %{NUMBER}, %{NUMBER:bytes} or %{NUMBER:bytes:int}
+	referenceRegex = regexp.MustCompile(`%\{(\w+)(?::([\w.@\[\]-]+))?(?::(\w+))?\}`)
+
+	// namedGroupRegex matches the start of a named capture group, such as (?P<name> or (?<name>
+	namedGroupRegex = regexp.MustCompile(`\(\?P?<([A-Za-z_][\w.@\[\]-]*)>`)
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new grok parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new grok parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a grok parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	Pattern      string            `mapstructure:"pattern"`
+	Patterns     map[string]string `mapstructure:"patterns"`
+	PatternFiles []string          `mapstructure:"pattern_files"`
+
+	Cache struct {
+		Size uint16 `mapstructure:"size"`
+	} `mapstructure:"cache"`
+}
+
+// Build will build a grok parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Pattern == "" {
+		return nil, fmt.Errorf("missing required field 'pattern'")
+	}
+
+	patterns := make(map[string]string, len(defaultPatterns))
+	for name, definition := range defaultPatterns {
+		patterns[name] = definition
+	}
+	for _, path := range c.PatternFiles {
+		if err := loadPatternFile(path, patterns); err != nil {
+			return nil, err
+		}
+	}
+	for name, definition := range c.Patterns {
+		patterns[name] = definition
+	}
+
+	comp := &compiler{patterns: patterns, captures: make(map[string]capture)}
+	expanded, err := comp.expand(c.Pattern, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := regexp.Compile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("compiling pattern: %w", err)
+	}
+
+	if len(comp.captures) == 0 {
+		return nil, errors.NewError(
+			"no named captures in grok pattern",
+			"use named captures like '%{NUMBER:bytes}' to specify the key name for the parsed field",
+		)
+	}
+
+	op := &Parser{
+		ParserOperator: parserOperator,
+		regexp:         r,
+		captures:       comp.captures,
+	}
+
+	if c.Cache.Size > 0 {
+		op.cache = regex.NewCache(c.Cache.Size)
+		logger.Debugf("configured %s with memory cache of size %d", op.ID(), op.cache.MaxSize())
+	}
+
+	return op, nil
+}
+
+// loadPatternFile reads pattern definitions from a file into patterns. Each line of the
+// file holds the name of a pattern followed by whitespace and its definition. Empty lines
+// and lines starting with # are ignored.
+func loadPatternFile(path string, patterns map[string]string) error {
+	file, err := os.Open(path) // #nosec - operator must read in pattern files defined by user
+	if err != nil {
+		return fmt.Errorf("open pattern file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.IndexAny(line, " \t")
+		if i < 0 {
+			return fmt.Errorf("pattern file %s: invalid definition on line %d", path, lineNumber)
+		}
+		patterns[line[:i]] = strings.TrimSpace(line[i:])
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read pattern file %s: %w", path, err)
+	}
+	return nil
+}
+
+// capture is a named capture of a grok pattern
+type capture struct {
+	name        string
+	captureType string
+}
+
+// compiler expands grok patterns into regular expressions. Named captures are renamed to
+// unique group names, as grok allows names that are not valid in regular expressions and
+// the same name may be captured several times.
+type compiler struct {
+	patterns map[string]string
+	captures map[string]capture
+}
+
+func (c *compiler) addCapture(name string, captureType string) (string, error) {
+	switch captureType {
+	case "", captureTypeString, captureTypeInt, captureTypeFloat:
+	default:
+		return "", fmt.Errorf("invalid type '%s' for capture '%s'", captureType, name)
+	}
+	group := fmt.Sprintf("g%d", len(c.captures))
+	c.captures[group] = capture{name: name, captureType: captureType}
+	return group, nil
+}
+
+// expand replaces all references to patterns with their definitions. The stack holds
+// the names of the patterns being expanded, to detect recursive definitions.
+func (c *compiler) expand(pattern string, stack []string) (string, error) {
+	var err error
+	pattern = namedGroupRegex.ReplaceAllStringFunc(pattern, func(match string) string {
+		if err != nil {
+			return match
+		}
+		var group string
+		group, err = c.addCapture(namedGroupRegex.FindStringSubmatch(match)[1], "")
+		return "(?P<" + group + ">"
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range referenceRegex.FindAllStringSubmatchIndex(pattern, -1) {
+		sb.WriteString(pattern[last:loc[0]])
+		last = loc[1]
+
+		name := pattern[loc[2]:loc[3]]
+		definition, ok := c.patterns[name]
+		if !ok {
+			return "", fmt.Errorf("undefined pattern '%s'", name)
+		}
+		for _, parent := range stack {
+			if parent == name {
+				return "", fmt.Errorf("pattern '%s' references itself", name)
+			}
+		}
+
+		expanded, err := c.expand(definition, append(stack, name))
+		if err != nil {
+			return "", err
+		}
+
+		if loc[4] < 0 {
+			sb.WriteString("(?:" + expanded + ")")
+			continue
+		}
+
+		var captureType string
+		if loc[6] >= 0 {
+			captureType = pattern[loc[6]:loc[7]]
+		}
+		group, err := c.addCapture(pattern[loc[4]:loc[5]], captureType)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString("(?P<" + group + ">" + expanded + ")")
+	}
+	sb.WriteString(pattern[last:])
+	return sb.String(), nil
+}
+
+// Parser is an operator that parses grok patterns in an entry.
+type Parser struct {
+	helper.ParserOperator
+	regexp   *regexp.Regexp
+	captures map[string]capture
+	cache    *regex.Cache
+}
+
+// Process will parse an entry with a grok pattern.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value using the grok pattern.
+func (p *Parser) parse(value interface{}) (interface{}, error) {
+	var raw string
+	switch m := value.(type) {
+	case string:
+		raw = m
+	default:
+		return nil, fmt.Errorf("type '%T' cannot be parsed as grok", value)
+	}
+	return p.match(raw)
+}
+
+func (p *Parser) match(value string) (interface{}, error) {
+	if p.cache != nil {
+		if x := p.cache.Get(value); x != nil {
+			return x, nil
+		}
+	}
+
+	matches := p.regexp.FindStringSubmatchIndex(value)
+	if matches == nil {
+		return nil, fmt.Errorf("grok pattern does not match")
+	}
+
+	parsedValues := map[string]interface{}{}
+	for i, group := range p.regexp.SubexpNames() {
+		c, ok := p.captures[group]
+		if !ok || matches[2*i] < 0 {
+			// Skip unnamed groups and captures that did not participate in the match
+			continue
+		}
+		if _, ok := parsedValues[c.name]; ok {
+			// The first capture of a name takes precedence
+			continue
+		}
+
+		parsedValue, err := convert(value[matches[2*i]:matches[2*i+1]], c.captureType)
+		if err != nil {
+			return nil, fmt.Errorf("capture '%s': %w", c.name, err)
+		}
+		parsedValues[c.name] = parsedValue
+	}
+
+	if p.cache != nil {
+		p.cache.Add(value, parsedValues)
+	}
+
+	return parsedValues, nil
+}
+
+func convert(value string, captureType string) (interface{}, error) {
+	switch captureType {
+	case captureTypeInt:
+		return strconv.ParseInt(value, 10, 64)
+	case captureTypeFloat:
+		return strconv.ParseFloat(value, 64)
+	}
+	return value, nil
+}
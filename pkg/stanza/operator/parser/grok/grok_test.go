@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grok
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T, pattern string, cacheSize uint16) *Parser {
+	cfg := NewConfigWithID("test")
+	cfg.Pattern = pattern
+	cfg.Cache.Size = cacheSize
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestDefaultPatternsCompile(t *testing.T) {
+	for name := range defaultPatterns {
+		comp := &compiler{patterns: defaultPatterns, captures: make(map[string]capture)}
+		expanded, err := comp.expand("%{"+name+"}", nil)
+		require.NoError(t, err, name)
+		_, err = regexp.Compile(expanded)
+		require.NoError(t, err, name)
+	}
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cases := []struct {
+		name        string
+		configure   func(*Config)
+		expectedErr string
+	}{
+		{
+			"invalid_on_error",
+			func(c *Config) {
+				c.Pattern = "%{WORD:word}"
+				c.OnError = "invalid_on_error"
+			},
+			"invalid `on_error` field",
+		},
+		{
+			"missing_pattern",
+			func(c *Config) {},
+			"missing required field 'pattern'",
+		},
+		{
+			"undefined_pattern",
+			func(c *Config) { c.Pattern = "%{NOPE:word}" },
+			"undefined pattern 'NOPE'",
+		},
+		{
+			"recursive_pattern",
+			func(c *Config) {
+				c.Pattern = "%{A:a}"
+				c.Patterns = map[string]string{"A": "a%{B}", "B": "b%{A}"}
+			},
+			"pattern 'A' references itself",
+		},
+		{
+			"invalid_type",
+			func(c *Config) { c.Pattern = "%{NUMBER:bytes:long}" },
+			"invalid type 'long' for capture 'bytes'",
+		},
+		{
+			"invalid_regex",
+			func(c *Config) {
+				c.Pattern = "%{BAD:bad}"
+				c.Patterns = map[string]string{"BAD": "(unclosed"}
+			},
+			"compiling pattern",
+		},
+		{
+			"no_named_captures",
+			func(c *Config) { c.Pattern = "%{WORD} %{NUMBER}" },
+			"no named captures in grok pattern",
+		},
+		{
+			"missing_pattern_file",
+			func(c *Config) {
+				c.Pattern = "%{WORD:word}"
+				c.PatternFiles = []string{filepath.Join("testdata", "missing")}
+			},
+			"open pattern file",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestParserByteFailure(t *testing.T) {
+	parser := newTestParser(t, "%{WORD:word}", 0)
+	_, err := parser.parse([]byte("invalid"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type '[]uint8' cannot be parsed as grok")
+}
+
+func TestParserStringFailure(t *testing.T) {
+	parser := newTestParser(t, "^%{NUMBER:number}$", 0)
+	_, err := parser.parse("invalid")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "grok pattern does not match")
+}
+
+func TestParserConversionFailure(t *testing.T) {
+	parser := newTestParser(t, "^%{NUMBER:number:int}$", 0)
+	_, err := parser.parse("1.5")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "capture 'number'")
+}
+
+func TestParserPatterns(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     string
+		expected  map[string]interface{}
+	}{
+		{
+			"combined_apache_log",
+			func(c *Config) { c.Pattern = "%{COMBINEDAPACHELOG}" },
+			`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			map[string]interface{}{
+				"clientip":    "127.0.0.1",
+				"ident":       "-",
+				"auth":        "frank",
+				"timestamp":   "10/Oct/2000:13:55:36 -0700",
+				"verb":        "GET",
+				"request":     "/apache_pb.gif",
+				"httpversion": "1.0",
+				"response":    "200",
+				"bytes":       "2326",
+				"referrer":    `"http://www.example.com/start.html"`,
+				"agent":       `"Mozilla/4.08"`,
+			},
+		},
+		{
+			"typed_captures",
+			func(c *Config) {
+				c.Pattern = `^%{IP:client} %{WORD:method} %{URIPATHPARAM:request} %{NUMBER:bytes:int} %{NUMBER:duration:float}$`
+			},
+			"55.3.244.1 GET /index.html?q=1 15824 0.043",
+			map[string]interface{}{
+				"client":   "55.3.244.1",
+				"method":   "GET",
+				"request":  "/index.html?q=1",
+				"bytes":    int64(15824),
+				"duration": 0.043,
+			},
+		},
+		{
+			"syslog_line",
+			func(c *Config) { c.Pattern = "%{SYSLOGLINE}" },
+			"Feb  6 10:00:00 web-1 sshd[2813]: Accepted publickey for deploy",
+			map[string]interface{}{
+				"timestamp": "Feb  6 10:00:00",
+				"logsource": "web-1",
+				"program":   "sshd",
+				"pid":       "2813",
+				"message":   "Accepted publickey for deploy",
+			},
+		},
+		{
+			"optional_captures_omitted",
+			func(c *Config) { c.Pattern = "%{SYSLOGLINE}" },
+			"Feb  6 10:00:00 web-1 kernel: eth0 link up",
+			map[string]interface{}{
+				"timestamp": "Feb  6 10:00:00",
+				"logsource": "web-1",
+				"program":   "kernel",
+				"message":   "eth0 link up",
+			},
+		},
+		{
+			"nginx_error",
+			func(c *Config) { c.Pattern = "%{NGINXERROR}" },
+			"2023/02/06 10:00:00 [error] 1234#0: *5 open() failed",
+			map[string]interface{}{
+				"timestamp":  "2023/02/06 10:00:00",
+				"loglevel":   "error",
+				"pid":        "1234",
+				"tid":        "0",
+				"connection": "5",
+				"message":    "open() failed",
+			},
+		},
+		{
+			"ipv6",
+			func(c *Config) { c.Pattern = "^%{IP:ip}$" },
+			"2001:db8::8a2e:370:7334",
+			map[string]interface{}{
+				"ip": "2001:db8::8a2e:370:7334",
+			},
+		},
+		{
+			"inline_patterns",
+			func(c *Config) {
+				c.Pattern = `%{QUEUE_ID:queue_id}: (?<message>.*)`
+				c.Patterns = map[string]string{"QUEUE_ID": "[0-9A-F]{10,11}"}
+			},
+			"BEF25A72965: message-id=<20130101142543.5828399CCAF@example.com>",
+			map[string]interface{}{
+				"queue_id": "BEF25A72965",
+				"message":  "message-id=<20130101142543.5828399CCAF@example.com>",
+			},
+		},
+		{
+			"pattern_file",
+			func(c *Config) {
+				c.Pattern = "%{POSTFIX_LINE}"
+				c.PatternFiles = []string{filepath.Join("testdata", "postfix")}
+			},
+			"BEF25A72965: removed",
+			map[string]interface{}{
+				"queue_id": "BEF25A72965",
+				"message":  "removed",
+			},
+		},
+		{
+			"inline_patterns_override_files",
+			func(c *Config) {
+				c.Pattern = "%{POSTFIX_LINE}"
+				c.PatternFiles = []string{filepath.Join("testdata", "postfix")}
+				c.Patterns = map[string]string{"POSTFIX_QUEUEID": "[0-9]+"}
+			},
+			"12345: removed",
+			map[string]interface{}{
+				"queue_id": "12345",
+				"message":  "removed",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			parsed, err := op.(*Parser).parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func TestParserCache(t *testing.T) {
+	parser := newTestParser(t, "^%{WORD:word}$", 10)
+	require.NotNil(t, parser.cache, "expected cache to be configured")
+	require.Equal(t, uint16(10), parser.cache.MaxSize())
+
+	parsed, err := parser.parse("cached")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"word": "cached"}, parsed)
+	require.Equal(t, parsed, parser.cache.Get("cached"))
+}
+
+func TestParserProcess(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Pattern = "%{WORD:verb} %{NUMBER:status:int}"
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	ots := time.Now()
+	input := &entry.Entry{Body: "GET 200", ObservedTimestamp: ots}
+	require.NoError(t, op.Process(context.Background(), input))
+	fake.ExpectEntry(t, &entry.Entry{
+		ObservedTimestamp: ots,
+		Body:              "GET 200",
+		Attributes: map[string]interface{}{
+			"verb":   "GET",
+			"status": int64(200),
+		},
+	})
+}
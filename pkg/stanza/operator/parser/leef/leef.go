@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/leef"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "leef_parser"
+
+	leefPrefix       = "LEEF:"
+	headerCount      = 5
+	defaultDelimiter = "\t"
+	severityKey      = "sev"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new LEEF parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new LEEF parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a LEEF parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+}
+
+// Build will build a LEEF parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+	}, nil
+}
+
+// Parser is an operator that parses IBM QRadar Log Event Extended Format messages.
+type Parser struct {
+	helper.ParserOperator
+}
+
+// Process will parse an entry as LEEF.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	var severity string
+	parse := func(value interface{}) (interface{}, error) {
+		parsed, err := p.parse(value)
+		if err != nil {
+			return nil, err
+		}
+		if sev, ok := parsed["extensions"].(map[string]interface{})[severityKey]; ok {
+			severity = sev.(string)
+		}
+		return parsed, nil
+	}
+	return p.ParserOperator.ProcessWithCallback(ctx, e, parse, func(e *entry.Entry) error {
+		// The sev attribute is mapped onto the entry unless a severity parser is configured
+		if p.SeverityParser == nil && severity != "" {
+			e.Severity = mapSeverity(severity)
+			e.SeverityText = severity
+		}
+		return nil
+	})
+}
+
+// parse will parse a value as LEEF.
+func (p *Parser) parse(value interface{}) (map[string]interface{}, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("type %T cannot be parsed as LEEF", value)
+	}
+
+	// A syslog header may precede the LEEF message
+	start := strings.Index(raw, leefPrefix)
+	if start < 0 {
+		return nil, fmt.Errorf("missing '%s' prefix", leefPrefix)
+	}
+
+	fields := strings.SplitN(raw[start+len(leefPrefix):], "|", headerCount+1)
+	if len(fields) <= headerCount {
+		return nil, fmt.Errorf("expected %d header fields, got %d", headerCount, len(fields)-1)
+	}
+	header, extension := fields[:headerCount], fields[headerCount]
+
+	delimiter := defaultDelimiter
+	if strings.HasPrefix(header[0], "2") {
+		var err error
+		delimiter, extension, err = splitDelimiter(extension)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	extensions, err := parseExtension(extension, delimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"version":         header[0],
+		"vendor":          header[1],
+		"product":         header[2],
+		"product_version": header[3],
+		"event_id":        header[4],
+		"extensions":      extensions,
+	}, nil
+}
+
+// splitDelimiter splits the optional delimiter field of a LEEF 2.0 header from the
+// extension. The delimiter is a single character or its hex code, such as 0x09 or x09.
+func splitDelimiter(message string) (string, string, error) {
+	end := strings.IndexByte(message, '|')
+	if end < 0 || strings.Contains(message[:end], "=") {
+		// The delimiter field is omitted
+		return defaultDelimiter, message, nil
+	}
+
+	delimiter, extension := message[:end], message[end+1:]
+	switch {
+	case delimiter == "":
+		return defaultDelimiter, extension, nil
+	case len(delimiter) == 1:
+		return delimiter, extension, nil
+	}
+
+	hex := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(delimiter), "0"), "x")
+	if hex == delimiter || len(hex) > 4 {
+		return "", "", fmt.Errorf("invalid delimiter '%s'", delimiter)
+	}
+	code, err := strconv.ParseUint(hex, 16, 16)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid delimiter '%s'", delimiter)
+	}
+	return string(rune(code)), extension, nil
+}
+
+// parseExtension parses the delimited key=value pairs of a LEEF extension.
+func parseExtension(extension, delimiter string) (map[string]interface{}, error) {
+	extensions := make(map[string]interface{})
+	for _, pair := range strings.Split(extension, delimiter) {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid extension '%s'", pair)
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+
+// mapSeverity maps a LEEF sev attribute, an integer from 1 to 10, onto an entry severity.
+func mapSeverity(severity string) entry.Severity {
+	n, err := strconv.Atoi(severity)
+	if err != nil {
+		return entry.Default
+	}
+
+	switch {
+	case n >= 0 && n <= 3:
+		return entry.Info
+	case n >= 4 && n <= 6:
+		return entry.Warn
+	case n >= 7 && n <= 8:
+		return entry.Error
+	case n >= 9 && n <= 10:
+		return entry.Fatal
+	}
+	return entry.Default
+}
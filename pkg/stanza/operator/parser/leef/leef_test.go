@@ -0,0 +1,308 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leef
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	op, err := NewConfigWithID("test").Build(testutil.Logger(t))
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	_, err := config.Build(testutil.Logger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid `on_error` field")
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected map[string]interface{}
+	}{
+		{
+			"version_1",
+			"LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tcat=anomaly\tmsg=there are spaces in this message",
+			map[string]interface{}{
+				"version":         "1.0",
+				"vendor":          "Microsoft",
+				"product":         "MSExchange",
+				"product_version": "4.0 SP1",
+				"event_id":        "15345",
+				"extensions": map[string]interface{}{
+					"src": "192.0.2.0",
+					"dst": "172.50.123.1",
+					"sev": "5",
+					"cat": "anomaly",
+					"msg": "there are spaces in this message",
+				},
+			},
+		},
+		{
+			"version_2_character_delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^srcPort=81^dstPort=21",
+			map[string]interface{}{
+				"version":         "2.0",
+				"vendor":          "Lancope",
+				"product":         "StealthWatch",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"extensions": map[string]interface{}{
+					"src":     "10.0.1.8",
+					"dst":     "10.0.0.5",
+					"sev":     "5",
+					"srcPort": "81",
+					"dstPort": "21",
+				},
+			},
+		},
+		{
+			"version_2_hex_delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|0x7c|src=10.0.1.8|dst=10.0.0.5",
+			map[string]interface{}{
+				"version":         "2.0",
+				"vendor":          "Lancope",
+				"product":         "StealthWatch",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"extensions": map[string]interface{}{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+				},
+			},
+		},
+		{
+			"version_2_omitted_delimiter",
+			"LEEF:2.0|Lancope|StealthWatch|1.0|41|src=10.0.1.8\tdst=10.0.0.5",
+			map[string]interface{}{
+				"version":         "2.0",
+				"vendor":          "Lancope",
+				"product":         "StealthWatch",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"extensions": map[string]interface{}{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+				},
+			},
+		},
+		{
+			"syslog_prefix",
+			"Jan 18 11:07:53 host LEEF:1.0|Vendor|Product|1.0|100|url=https://example.com/?a=b",
+			map[string]interface{}{
+				"version":         "1.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "100",
+				"extensions": map[string]interface{}{
+					"url": "https://example.com/?a=b",
+				},
+			},
+		},
+		{
+			"empty_extension",
+			"LEEF:1.0|Vendor|Product|1.0|100|",
+			map[string]interface{}{
+				"version":         "1.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "100",
+				"extensions":      map[string]interface{}{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := newTestParser(t).parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func TestParseFailure(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       interface{}
+		expectedErr string
+	}{
+		{"invalid_type", []byte("LEEF:1.0|"), "type []uint8 cannot be parsed as LEEF"},
+		{"missing_prefix", "1.0|Vendor|Product|1.0|100|", "missing 'LEEF:' prefix"},
+		{"missing_header_fields", "LEEF:1.0|Vendor|Product|1.0", "expected 5 header fields, got 3"},
+		{"invalid_delimiter", "LEEF:2.0|Vendor|Product|1.0|100|ab|src=10.0.1.8", "invalid delimiter 'ab'"},
+		{"invalid_hex_delimiter", "LEEF:2.0|Vendor|Product|1.0|100|0xzz|src=10.0.1.8", "invalid delimiter '0xzz'"},
+		{"invalid_extension", "LEEF:1.0|Vendor|Product|1.0|100|src=10.0.1.8\tnovalue", "invalid extension 'novalue'"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newTestParser(t).parse(tc.input)
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMapSeverity(t *testing.T) {
+	cases := []struct {
+		severity string
+		expected entry.Severity
+	}{
+		{"1", entry.Info},
+		{"3", entry.Info},
+		{"4", entry.Warn},
+		{"6", entry.Warn},
+		{"7", entry.Error},
+		{"8", entry.Error},
+		{"9", entry.Fatal},
+		{"10", entry.Fatal},
+		{"11", entry.Default},
+		{"high", entry.Default},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.severity, func(t *testing.T) {
+			require.Equal(t, tc.expected, mapSeverity(tc.severity))
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     *entry.Entry
+		expect    *entry.Entry
+	}{
+		{
+			"after_syslog_parser",
+			func(c *Config) {
+				c.ParseFrom = entry.NewAttributeField("message")
+				c.ParseTo = entry.RootableField{Field: entry.NewAttributeField("leef")}
+			},
+			&entry.Entry{
+				Attributes: map[string]interface{}{
+					"hostname": "host",
+					"message":  "LEEF:1.0|Vendor|Product|1.0|100|src=10.0.0.1\tsev=9",
+				},
+			},
+			&entry.Entry{
+				Severity:     entry.Fatal,
+				SeverityText: "9",
+				Attributes: map[string]interface{}{
+					"hostname": "host",
+					"message":  "LEEF:1.0|Vendor|Product|1.0|100|src=10.0.0.1\tsev=9",
+					"leef": map[string]interface{}{
+						"version":         "1.0",
+						"vendor":          "Vendor",
+						"product":         "Product",
+						"product_version": "1.0",
+						"event_id":        "100",
+						"extensions": map[string]interface{}{
+							"src": "10.0.0.1",
+							"sev": "9",
+						},
+					},
+				},
+			},
+		},
+		{
+			"no_severity",
+			func(c *Config) {},
+			&entry.Entry{
+				Body: "LEEF:1.0|Vendor|Product|1.0|100|src=10.0.0.1",
+			},
+			&entry.Entry{
+				Body: "LEEF:1.0|Vendor|Product|1.0|100|src=10.0.0.1",
+				Attributes: map[string]interface{}{
+					"version":         "1.0",
+					"vendor":          "Vendor",
+					"product":         "Product",
+					"product_version": "1.0",
+					"event_id":        "100",
+					"extensions": map[string]interface{}{
+						"src": "10.0.0.1",
+					},
+				},
+			},
+		},
+		{
+			"severity_parser_configured",
+			func(c *Config) {
+				parseFrom := entry.NewAttributeField("extensions", "sev")
+				severity := helper.NewSeverityConfig()
+				severity.ParseFrom = &parseFrom
+				severity.Mapping = map[interface{}]interface{}{"warn": "1"}
+				c.SeverityConfig = &severity
+			},
+			&entry.Entry{
+				Body: "LEEF:1.0|Vendor|Product|1.0|100|sev=1",
+			},
+			&entry.Entry{
+				Severity:     entry.Warn,
+				SeverityText: "1",
+				Body:         "LEEF:1.0|Vendor|Product|1.0|100|sev=1",
+				Attributes: map[string]interface{}{
+					"version":         "1.0",
+					"vendor":          "Vendor",
+					"product":         "Product",
+					"product_version": "1.0",
+					"event_id":        "100",
+					"extensions": map[string]interface{}{
+						"sev": "1",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+			tc.expect.ObservedTimestamp = ots
+
+			require.NoError(t, op.Process(context.Background(), tc.input))
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
@@ -123,6 +123,33 @@ func (m *memoryCache) maxSize() uint16 {
 	return uint16(cap(m.keys))
 }
 
+// Cache is a memory cache of the values parsed from raw strings. It is
+// exported so that other parsers built on regular expressions can share
+// the regex parser's caching.
+type Cache struct {
+	cache cache
+}
+
+// NewCache returns a memory cache that holds up to maxSize values
+func NewCache(maxSize uint16) *Cache {
+	return &Cache{cache: newMemoryCache(maxSize, 0)}
+}
+
+// Get returns the value cached for the key, or nil if there is none
+func (c *Cache) Get(key string) interface{} {
+	return c.cache.get(key)
+}
+
+// Add caches a value for the key. It returns false if the cache is throttled.
+func (c *Cache) Add(key string, data interface{}) bool {
+	return c.cache.add(key, data)
+}
+
+// MaxSize returns the maximum number of values held by the cache
+func (c *Cache) MaxSize() uint16 {
+	return c.cache.maxSize()
+}
+
 // limiter provides rate limiting methods for
 // the cache
 type limiter interface {
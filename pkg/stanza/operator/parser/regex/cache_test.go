@@ -271,3 +271,17 @@ func TestThrottledCache(t *testing.T) {
 	require.True(t, c.limiter.throttled())
 	require.False(t, result, "expected add to return false when cache writes are throttled")
 }
+
+func TestCache(t *testing.T) {
+	c := NewCache(2)
+	require.Equal(t, uint16(2), c.MaxSize())
+	require.Nil(t, c.Get("key"))
+
+	require.True(t, c.Add("key", "value"))
+	require.Equal(t, "value", c.Get("key"))
+
+	require.True(t, c.Add("key2", "value2"))
+	require.True(t, c.Add("key3", "value3"))
+	require.Nil(t, c.Get("key"), "oldest value should be evicted")
+	require.Equal(t, "value3", c.Get("key3"))
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unroll // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/unroll"
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "unroll"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new unroll operator config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new unroll operator config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+		Field:             entry.NewBodyField(),
+		KeepTimestamp:     true,
+	}
+}
+
+// Config is the configuration of an unroll operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	Field                    entry.Field `mapstructure:"field"`
+	To                       entry.Field `mapstructure:"to"`
+	KeepTimestamp            bool        `mapstructure:"keep_timestamp"`
+}
+
+// Build will build an unroll operator from the supplied configuration
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Field.FieldInterface == nil || c.Field == entry.NewNilField() {
+		return nil, fmt.Errorf("unroll: missing field")
+	}
+
+	to := c.To
+	if to.FieldInterface == nil {
+		to = c.Field
+	}
+
+	return &Transformer{
+		TransformerOperator: transformerOperator,
+		Field:               c.Field,
+		To:                  to,
+		KeepTimestamp:       c.KeepTimestamp,
+		json:                jsoniter.ConfigFastest,
+	}, nil
+}
+
+// Transformer emits one entry for each element of a list field
+type Transformer struct {
+	helper.TransformerOperator
+	Field         entry.Field
+	To            entry.Field
+	KeepTimestamp bool
+	json          jsoniter.API
+}
+
+// Process will unroll an entry into one entry per element of its list field.
+func (p *Transformer) Process(ctx context.Context, e *entry.Entry) error {
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.Write(ctx, e)
+		return nil
+	}
+
+	// The list is removed from a copy of the entry, so that each element
+	// is used by exactly one new entry and the original is left untouched.
+	base := e.Copy()
+	val, ok := p.Field.Delete(base)
+	if !ok {
+		return p.HandleEntryError(ctx, e, fmt.Errorf("unroll: field does not exist in this entry: %s", p.Field.String()))
+	}
+
+	elements, err := p.toList(val)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	if !p.KeepTimestamp {
+		base.Timestamp = time.Time{}
+	}
+
+	for _, element := range elements {
+		unrolled := base.Copy()
+		if err := p.To.Set(unrolled, element); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+		p.Write(ctx, unrolled)
+	}
+	return nil
+}
+
+// toList converts a field value into a list of elements. Strings are
+// decoded as JSON arrays.
+func (p *Transformer) toList(val interface{}) ([]interface{}, error) {
+	switch v := val.(type) {
+	case []interface{}:
+		return v, nil
+	case string:
+		if !strings.HasPrefix(strings.TrimSpace(v), "[") {
+			return nil, fmt.Errorf("unroll: string field is not a JSON array: %s", p.Field.String())
+		}
+		var elements []interface{}
+		if err := p.json.UnmarshalFromString(v, &elements); err != nil {
+			return nil, fmt.Errorf("unroll: decode JSON array: %w", err)
+		}
+		return elements, nil
+	case []byte:
+		return nil, fmt.Errorf("unroll: type %T cannot be unrolled", val)
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("unroll: type %T cannot be unrolled", val)
+	}
+	elements := make([]interface{}, rv.Len())
+	for i := range elements {
+		elements[i] = rv.Index(i).Interface()
+	}
+	return elements, nil
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unroll
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Field = entry.NewNilField()
+	_, err := cfg.Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "unroll: missing field")
+}
+
+// Test building and processing a Config
+func TestBuildAndProcess(t *testing.T) {
+	now := time.Now()
+	ts := time.Unix(1586632809, 0)
+	newTestEntry := func(body interface{}) *entry.Entry {
+		e := entry.New()
+		e.ObservedTimestamp = now
+		e.Timestamp = ts
+		e.Attributes = map[string]interface{}{"log.file.name": "records.json"}
+		e.Resource = map[string]interface{}{"host.name": "host"}
+		e.Body = body
+		// Unrolled entries are copies of the original entry
+		return e.Copy()
+	}
+
+	cases := []struct {
+		name   string
+		op     *Config
+		input  *entry.Entry
+		output []*entry.Entry
+	}{
+		{
+			"body_list",
+			NewConfig(),
+			newTestEntry([]interface{}{"one", map[string]interface{}{"key": "two"}}),
+			[]*entry.Entry{
+				newTestEntry("one"),
+				newTestEntry(map[string]interface{}{"key": "two"}),
+			},
+		},
+		{
+			"body_json_array",
+			NewConfig(),
+			newTestEntry(`[{"key":"one"}, {"key":2}]`),
+			[]*entry.Entry{
+				newTestEntry(map[string]interface{}{"key": "one"}),
+				newTestEntry(map[string]interface{}{"key": float64(2)}),
+			},
+		},
+		{
+			"typed_list",
+			NewConfig(),
+			newTestEntry([]string{"one", "two"}),
+			[]*entry.Entry{
+				newTestEntry("one"),
+				newTestEntry("two"),
+			},
+		},
+		{
+			"empty_list",
+			NewConfig(),
+			newTestEntry([]interface{}{}),
+			[]*entry.Entry{},
+		},
+		{
+			"nested_body_field_to_body",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.Field = entry.NewBodyField("Records")
+				cfg.To = entry.NewBodyField()
+				return cfg
+			}(),
+			newTestEntry(map[string]interface{}{
+				"Records": []interface{}{
+					map[string]interface{}{"eventName": "ConsoleLogin"},
+					map[string]interface{}{"eventName": "AssumeRole"},
+				},
+			}),
+			[]*entry.Entry{
+				newTestEntry(map[string]interface{}{"eventName": "ConsoleLogin"}),
+				newTestEntry(map[string]interface{}{"eventName": "AssumeRole"}),
+			},
+		},
+		{
+			"attribute_field",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.Field = entry.NewAttributeField("ids")
+				return cfg
+			}(),
+			func() *entry.Entry {
+				e := newTestEntry("body")
+				e.Attributes["ids"] = []interface{}{"a", "b"}
+				return e
+			}(),
+			[]*entry.Entry{
+				func() *entry.Entry {
+					e := newTestEntry("body")
+					e.Attributes["ids"] = "a"
+					return e
+				}(),
+				func() *entry.Entry {
+					e := newTestEntry("body")
+					e.Attributes["ids"] = "b"
+					return e
+				}(),
+			},
+		},
+		{
+			"discard_timestamp",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.KeepTimestamp = false
+				return cfg
+			}(),
+			newTestEntry([]interface{}{"one"}),
+			[]*entry.Entry{
+				func() *entry.Entry {
+					e := newTestEntry("one")
+					e.Timestamp = time.Time{}
+					return e
+				}(),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.op
+			cfg.OutputIDs = []string{"fake"}
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			require.NoError(t, op.Process(context.Background(), tc.input))
+			for _, expected := range tc.output {
+				fake.ExpectEntry(t, expected)
+			}
+			fake.ExpectNoEntry(t, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestProcessError(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        interface{}
+		expectedErr string
+	}{
+		{"missing_field", nil, "unroll: field does not exist in this entry: body.missing"},
+		{"not_a_list", map[string]interface{}{"missing": 1}, "unroll: type int cannot be unrolled"},
+		{"bytes", map[string]interface{}{"missing": []byte("[]")}, "unroll: type []uint8 cannot be unrolled"},
+		{"not_a_json_array", map[string]interface{}{"missing": `{"key":"val"}`}, "unroll: string field is not a JSON array: body.missing"},
+		{"invalid_json_array", map[string]interface{}{"missing": `[{"key":}]`}, "unroll: decode JSON array"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Field = entry.NewBodyField("missing")
+			cfg.OnError = helper.SendOnError
+			cfg.OutputIDs = []string{"fake"}
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			input := entry.New()
+			input.Body = tc.body
+			err = op.Process(context.Background(), input)
+			require.ErrorContains(t, err, tc.expectedErr)
+
+			// The original entry is sent on error
+			fake.ExpectEntry(t, input)
+		})
+	}
+}
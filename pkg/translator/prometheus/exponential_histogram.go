@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/histogramutils"
+)
+
+const (
+	// Prometheus native histograms support schemas from -4 to 8, see
+	// https://github.com/prometheus/prometheus/blob/main/model/histogram/histogram.go
+	nativeHistogramMinSchema = -4
+	nativeHistogramMaxSchema = 8
+
+	// maxBucketGap is the number of empty buckets that are encoded inline rather
+	// than starting a new span, following the Prometheus client libraries.
+	maxBucketGap = 2
+)
+
+// BucketSpan is a run of consecutive buckets of a Prometheus native histogram.
+type BucketSpan struct {
+	// Offset is the index of the first bucket for the first span, and the
+	// number of buckets skipped since the end of the previous span otherwise.
+	Offset int32
+	// Length is the number of consecutive buckets in the span.
+	Length uint32
+}
+
+// NativeHistogram is the Prometheus native (sparse) histogram representation of an
+// OpenTelemetry exponential histogram data point. Bucket counts are delta encoded:
+// each delta is the difference to the previous bucket count.
+type NativeHistogram struct {
+	Schema         int32
+	ZeroThreshold  float64
+	ZeroCount      uint64
+	Count          uint64
+	Sum            float64
+	PositiveSpans  []BucketSpan
+	PositiveDeltas []int64
+	NegativeSpans  []BucketSpan
+	NegativeDeltas []int64
+}
+
+// ToNativeHistogram converts an exponential histogram data point to a Prometheus
+// native histogram.
+//
+// OpenTelemetry scales map directly to Prometheus schemas. Scales above 8 are
+// reduced to 8 by merging adjacent buckets; scales below -4 can not be represented
+// and an error is returned.
+func ToNativeHistogram(dp pmetric.ExponentialHistogramDataPoint) (NativeHistogram, error) {
+	scale := dp.Scale()
+	if scale < nativeHistogramMinSchema {
+		return NativeHistogram{}, fmt.Errorf("cannot convert exponential histogram with scale %d to a native histogram, the minimum supported scale is %d", scale, nativeHistogramMinSchema)
+	}
+	var scaleDown int32
+	if scale > nativeHistogramMaxSchema {
+		scaleDown = scale - nativeHistogramMaxSchema
+		scale = nativeHistogramMaxSchema
+	}
+
+	h := NativeHistogram{
+		Schema:    scale,
+		ZeroCount: dp.ZeroCount(),
+		Count:     dp.Count(),
+		Sum:       dp.Sum(),
+	}
+	h.PositiveSpans, h.PositiveDeltas = toNativeBuckets(dp.Positive(), scaleDown)
+	h.NegativeSpans, h.NegativeDeltas = toNativeBuckets(dp.Negative(), scaleDown)
+	return h, nil
+}
+
+// toNativeBuckets converts exponential histogram buckets to native histogram spans and
+// deltas, merging buckets to reduce the scale by scaleDown.
+func toNativeBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]BucketSpan, []int64) {
+	if buckets.BucketCounts().Len() == 0 {
+		return nil, nil
+	}
+
+	// Bucket i of an exponential histogram covers (base^i, base^(i+1)] while bucket i
+	// of a native histogram covers (base^(i-1), base^i], so indexes are shifted by one.
+	first, counts := histogramutils.Downscale(buckets.Offset(), buckets.BucketCounts().AsRaw(), scaleDown)
+
+	var (
+		spans     []BucketSpan
+		deltas    []int64
+		prevCount int64
+		prevIndex int32
+	)
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+		index := first + int32(i) + 1
+		switch gap := index - prevIndex - 1; {
+		case len(spans) == 0:
+			spans = append(spans, BucketSpan{Offset: index, Length: 1})
+		case gap <= maxBucketGap:
+			for j := int32(0); j < gap; j++ {
+				deltas = append(deltas, -prevCount)
+				prevCount = 0
+			}
+			spans[len(spans)-1].Length += uint32(gap) + 1
+		default:
+			spans = append(spans, BucketSpan{Offset: gap, Length: 1})
+		}
+		deltas = append(deltas, int64(count)-prevCount)
+		prevCount = int64(count)
+		prevIndex = index
+	}
+	return spans, deltas
+}
+
+// ExponentialToExplicitHistogram converts an exponential histogram data point to an
+// explicit bucket histogram data point for consumers that do not support native
+// histograms.
+//
+// Each populated exponential bucket becomes an explicit bucket with the same upper
+// boundary: negative buckets first, then a bucket with upper boundary 0 holding the
+// zero count, then positive buckets. The final (+Inf) bucket is always empty.
+func ExponentialToExplicitHistogram(src pmetric.ExponentialHistogramDataPoint, dest pmetric.HistogramDataPoint) {
+	src.Attributes().CopyTo(dest.Attributes())
+	dest.SetStartTimestamp(src.StartTimestamp())
+	dest.SetTimestamp(src.Timestamp())
+	dest.SetFlags(src.Flags())
+	dest.SetCount(src.Count())
+	if src.HasSum() {
+		dest.SetSum(src.Sum())
+	}
+	if src.HasMin() {
+		dest.SetMin(src.Min())
+	}
+	if src.HasMax() {
+		dest.SetMax(src.Max())
+	}
+	src.Exemplars().CopyTo(dest.Exemplars())
+
+	scale := src.Scale()
+	negative := src.Negative()
+	positive := src.Positive()
+	size := negative.BucketCounts().Len() + positive.BucketCounts().Len() + 1
+	bounds := make([]float64, 0, size)
+	counts := make([]uint64, 0, size+1)
+
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		bounds = append(bounds, -lowerBoundary(negative.Offset()+int32(i), scale))
+		counts = append(counts, negative.BucketCounts().At(i))
+	}
+	bounds = append(bounds, 0)
+	counts = append(counts, src.ZeroCount())
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		bounds = append(bounds, lowerBoundary(positive.Offset()+int32(i)+1, scale))
+		counts = append(counts, positive.BucketCounts().At(i))
+	}
+	counts = append(counts, 0)
+
+	dest.ExplicitBounds().FromRaw(bounds)
+	dest.BucketCounts().FromRaw(counts)
+}
+
+// lowerBoundary returns the lower boundary of the exponential histogram bucket with
+// the given index, base^index with base = 2^(2^-scale).
+func lowerBoundary(index, scale int32) float64 {
+	if scale <= 0 {
+		return math.Ldexp(1, int(index)<<-scale)
+	}
+	return math.Exp2(float64(index) / float64(int64(1)<<scale))
+}
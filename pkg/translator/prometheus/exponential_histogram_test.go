@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func createExponentialHistogramDataPoint(scale int32, zeroCount uint64, posOffset int32, pos []uint64, negOffset int32, neg []uint64) pmetric.ExponentialHistogramDataPoint {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(scale)
+	dp.SetZeroCount(zeroCount)
+	count := zeroCount
+	for _, c := range append(append([]uint64{}, pos...), neg...) {
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(42)
+	dp.Positive().SetOffset(posOffset)
+	dp.Positive().BucketCounts().FromRaw(pos)
+	dp.Negative().SetOffset(negOffset)
+	dp.Negative().BucketCounts().FromRaw(neg)
+	return dp
+}
+
+func TestToNativeHistogram(t *testing.T) {
+	tests := []struct {
+		name     string
+		dp       pmetric.ExponentialHistogramDataPoint
+		expected NativeHistogram
+	}{
+		{
+			name: "empty",
+			dp:   createExponentialHistogramDataPoint(2, 0, 0, nil, 0, nil),
+			expected: NativeHistogram{
+				Schema: 2,
+				Sum:    42,
+			},
+		},
+		{
+			name: "contiguous buckets",
+			dp:   createExponentialHistogramDataPoint(1, 3, 0, []uint64{1, 4, 2}, -2, []uint64{5}),
+			expected: NativeHistogram{
+				Schema:         1,
+				ZeroCount:      3,
+				Count:          15,
+				Sum:            42,
+				PositiveSpans:  []BucketSpan{{Offset: 1, Length: 3}},
+				PositiveDeltas: []int64{1, 3, -2},
+				NegativeSpans:  []BucketSpan{{Offset: -1, Length: 1}},
+				NegativeDeltas: []int64{5},
+			},
+		},
+		{
+			name: "small gaps are kept inline",
+			dp:   createExponentialHistogramDataPoint(0, 0, 3, []uint64{2, 0, 0, 1}, 0, nil),
+			expected: NativeHistogram{
+				Count:          3,
+				Sum:            42,
+				PositiveSpans:  []BucketSpan{{Offset: 4, Length: 4}},
+				PositiveDeltas: []int64{2, -2, 0, 1},
+			},
+		},
+		{
+			name: "large gaps start a new span",
+			dp:   createExponentialHistogramDataPoint(0, 0, -1, []uint64{0, 2, 0, 0, 0, 1, 0}, 0, nil),
+			expected: NativeHistogram{
+				Count:          3,
+				Sum:            42,
+				PositiveSpans:  []BucketSpan{{Offset: 1, Length: 1}, {Offset: 3, Length: 1}},
+				PositiveDeltas: []int64{2, -1},
+			},
+		},
+		{
+			name: "scale is reduced to the maximum schema",
+			dp:   createExponentialHistogramDataPoint(10, 0, -3, []uint64{1, 2, 3, 4, 5}, 0, nil),
+			expected: NativeHistogram{
+				Schema:         8,
+				Count:          15,
+				Sum:            42,
+				PositiveSpans:  []BucketSpan{{Offset: 0, Length: 2}},
+				PositiveDeltas: []int64{6, 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := ToNativeHistogram(tt.dp)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, h)
+		})
+	}
+}
+
+func TestToNativeHistogramUnsupportedScale(t *testing.T) {
+	_, err := ToNativeHistogram(createExponentialHistogramDataPoint(-5, 0, 0, []uint64{1}, 0, nil))
+	assert.Error(t, err)
+}
+
+func TestExponentialToExplicitHistogram(t *testing.T) {
+	src := createExponentialHistogramDataPoint(0, 3, 1, []uint64{1, 0, 2}, 0, []uint64{4, 5})
+	src.Attributes().PutStr("key", "value")
+	src.SetStartTimestamp(pcommon.Timestamp(1))
+	src.SetTimestamp(pcommon.Timestamp(2))
+	src.SetMin(-3)
+	src.SetMax(15)
+	src.Exemplars().AppendEmpty().SetDoubleValue(1.5)
+
+	dest := pmetric.NewHistogramDataPoint()
+	ExponentialToExplicitHistogram(src, dest)
+
+	assert.Equal(t, []float64{-2, -1, 0, 4, 8, 16}, dest.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{5, 4, 3, 1, 0, 2, 0}, dest.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(15), dest.Count())
+	assert.Equal(t, 42.0, dest.Sum())
+	assert.Equal(t, -3.0, dest.Min())
+	assert.Equal(t, 15.0, dest.Max())
+	assert.Equal(t, pcommon.Timestamp(1), dest.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(2), dest.Timestamp())
+	assert.Equal(t, map[string]interface{}{"key": "value"}, dest.Attributes().AsRaw())
+	assert.Equal(t, 1, dest.Exemplars().Len())
+}
+
+func TestExponentialToExplicitHistogramPositiveScale(t *testing.T) {
+	src := createExponentialHistogramDataPoint(1, 0, -2, []uint64{1, 1, 1, 1}, 0, nil)
+	dest := pmetric.NewHistogramDataPoint()
+	ExponentialToExplicitHistogram(src, dest)
+
+	bounds := dest.ExplicitBounds().AsRaw()
+	require.Len(t, bounds, 5)
+	assert.Equal(t, 0.0, bounds[0])
+	assert.InDelta(t, 0.7071067811865476, bounds[1], 1e-12)
+	assert.Equal(t, 1.0, bounds[2])
+	assert.InDelta(t, 1.4142135623730951, bounds[3], 1e-12)
+	assert.Equal(t, 2.0, bounds[4])
+}
@@ -12,6 +12,7 @@ require (
 	go.opentelemetry.io/collector/pdata v0.63.0
 	go.opentelemetry.io/collector/semconv v0.63.0
 	go.uber.org/multierr v1.8.0
+	google.golang.org/protobuf v1.28.1
 )
 
 require (
@@ -29,7 +30,6 @@ require (
 	golang.org/x/text v0.4.0 // indirect
 	google.golang.org/genproto v0.0.0-20220822174746-9e6da59bd2fc // indirect
 	google.golang.org/grpc v1.50.1 // indirect
-	google.golang.org/protobuf v1.28.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
@@ -250,6 +250,8 @@ func validateMetrics(metric pmetric.Metric) bool {
 		return metric.Sum().DataPoints().Len() != 0 && metric.Sum().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
 	case pmetric.MetricTypeHistogram:
 		return metric.Histogram().DataPoints().Len() != 0 && metric.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+	case pmetric.MetricTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().Len() != 0 && metric.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
 	case pmetric.MetricTypeSummary:
 		return metric.Summary().DataPoints().Len() != 0
 	}
@@ -428,6 +430,11 @@ func mostRecentTimestampInMetric(metric pmetric.Metric) pcommon.Timestamp {
 		for x := 0; x < dataPoints.Len(); x++ {
 			ts = maxTimestamp(ts, dataPoints.At(x).Timestamp())
 		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dataPoints := metric.ExponentialHistogram().DataPoints()
+		for x := 0; x < dataPoints.Len(); x++ {
+			ts = maxTimestamp(ts, dataPoints.At(x).Timestamp())
+		}
 	case pmetric.MetricTypeSummary:
 		dataPoints := metric.Summary().DataPoints()
 		for x := 0; x < dataPoints.Len(); x++ {
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// Field numbers of the native histogram messages of the remote write protocol, see
+// https://github.com/prometheus/prometheus/blob/main/prompb/types.proto
+const (
+	timeSeriesHistogramsField = 4
+
+	histogramCountIntField      = 1
+	histogramSumField           = 3
+	histogramSchemaField        = 4
+	histogramZeroThresholdField = 5
+	histogramZeroCountIntField  = 6
+	histogramNegativeSpansField = 8
+	histogramNegativeDeltaField = 9
+	histogramPositiveSpansField = 11
+	histogramPositiveDeltaField = 12
+	histogramTimestampField     = 15
+
+	bucketSpanOffsetField = 1
+	bucketSpanLengthField = 2
+)
+
+// addSingleExponentialHistogramDataPoint converts pt to a native histogram and adds it to its corresponding
+// time series in tsMap. If settings.DownconvertExponentialHistograms is set, pt is converted to an explicit
+// bucket histogram instead.
+func addSingleExponentialHistogramDataPoint(pt pmetric.ExponentialHistogramDataPoint, resource pcommon.Resource, metric pmetric.Metric, settings Settings, tsMap map[string]*prompb.TimeSeries) error {
+	if settings.DownconvertExponentialHistograms {
+		explicit := pmetric.NewHistogramDataPoint()
+		prometheustranslator.ExponentialToExplicitHistogram(pt, explicit)
+		addSingleHistogramDataPoint(explicit, resource, metric, settings, tsMap)
+		return nil
+	}
+
+	h, err := prometheustranslator.ToNativeHistogram(pt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", metric.Name(), err)
+	}
+	if pt.Flags().NoRecordedValue() {
+		h.Sum = math.Float64frombits(value.StaleNaN)
+	}
+
+	name := prometheustranslator.BuildPromCompliantName(metric, settings.Namespace)
+	labels := createAttributes(resource, pt.Attributes(), settings.ExternalLabels, nameStr, name)
+	sig := timeSeriesSignature(metric.Type().String(), &labels)
+	ts, ok := tsMap[sig]
+	if !ok {
+		ts = &prompb.TimeSeries{Labels: labels}
+		tsMap[sig] = ts
+	}
+	// The prompb package of the Prometheus version in use predates native histograms, so the
+	// histogram is appended to the time series as an unrecognized field, which is marshaled
+	// as-is after the known fields.
+	ts.XXX_unrecognized = appendHistogram(ts.XXX_unrecognized, h, convertTimeStamp(pt.Timestamp()))
+	return nil
+}
+
+// appendHistogram appends h as the histograms field of a remote write TimeSeries message to b.
+func appendHistogram(b []byte, h prometheustranslator.NativeHistogram, timestamp int64) []byte {
+	var m []byte
+	m = protowire.AppendTag(m, histogramCountIntField, protowire.VarintType)
+	m = protowire.AppendVarint(m, h.Count)
+	m = protowire.AppendTag(m, histogramSumField, protowire.Fixed64Type)
+	m = protowire.AppendFixed64(m, math.Float64bits(h.Sum))
+	m = protowire.AppendTag(m, histogramSchemaField, protowire.VarintType)
+	m = protowire.AppendVarint(m, protowire.EncodeZigZag(int64(h.Schema)))
+	m = protowire.AppendTag(m, histogramZeroThresholdField, protowire.Fixed64Type)
+	m = protowire.AppendFixed64(m, math.Float64bits(h.ZeroThreshold))
+	m = protowire.AppendTag(m, histogramZeroCountIntField, protowire.VarintType)
+	m = protowire.AppendVarint(m, h.ZeroCount)
+	m = appendBuckets(m, histogramNegativeSpansField, histogramNegativeDeltaField, h.NegativeSpans, h.NegativeDeltas)
+	m = appendBuckets(m, histogramPositiveSpansField, histogramPositiveDeltaField, h.PositiveSpans, h.PositiveDeltas)
+	m = protowire.AppendTag(m, histogramTimestampField, protowire.VarintType)
+	m = protowire.AppendVarint(m, uint64(timestamp))
+
+	b = protowire.AppendTag(b, timeSeriesHistogramsField, protowire.BytesType)
+	return protowire.AppendBytes(b, m)
+}
+
+// appendBuckets appends the repeated spans and packed deltas fields of one side of a histogram to b.
+func appendBuckets(b []byte, spansField, deltasField protowire.Number, spans []prometheustranslator.BucketSpan, deltas []int64) []byte {
+	for _, span := range spans {
+		var s []byte
+		s = protowire.AppendTag(s, bucketSpanOffsetField, protowire.VarintType)
+		s = protowire.AppendVarint(s, protowire.EncodeZigZag(int64(span.Offset)))
+		s = protowire.AppendTag(s, bucketSpanLengthField, protowire.VarintType)
+		s = protowire.AppendVarint(s, uint64(span.Length))
+		b = protowire.AppendTag(b, spansField, protowire.BytesType)
+		b = protowire.AppendBytes(b, s)
+	}
+	if len(deltas) == 0 {
+		return b
+	}
+	var d []byte
+	for _, delta := range deltas {
+		d = protowire.AppendVarint(d, protowire.EncodeZigZag(delta))
+	}
+	b = protowire.AppendTag(b, deltasField, protowire.BytesType)
+	return protowire.AppendBytes(b, d)
+}
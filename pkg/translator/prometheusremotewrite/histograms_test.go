@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// decodedHistogram mirrors the remote write Histogram message for the fields written by appendHistogram.
+type decodedHistogram struct {
+	count          uint64
+	sum            float64
+	schema         int32
+	zeroThreshold  float64
+	zeroCount      uint64
+	negativeSpans  []prometheustranslator.BucketSpan
+	negativeDeltas []int64
+	positiveSpans  []prometheustranslator.BucketSpan
+	positiveDeltas []int64
+	timestamp      int64
+}
+
+// decodeHistograms decodes the histograms field of a TimeSeries message encoded in b.
+func decodeHistograms(t *testing.T, b []byte) []decodedHistogram {
+	var histograms []decodedHistogram
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		require.Equal(t, protowire.Number(timeSeriesHistogramsField), num)
+		require.Equal(t, protowire.BytesType, typ)
+		m, n := protowire.ConsumeBytes(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		histograms = append(histograms, decodeHistogram(t, m))
+	}
+	return histograms
+}
+
+func decodeHistogram(t *testing.T, b []byte) decodedHistogram {
+	var h decodedHistogram
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		switch num {
+		case histogramCountIntField:
+			h.count, n = protowire.ConsumeVarint(b)
+		case histogramSumField:
+			var v uint64
+			v, n = protowire.ConsumeFixed64(b)
+			h.sum = math.Float64frombits(v)
+		case histogramSchemaField:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			h.schema = int32(protowire.DecodeZigZag(v))
+		case histogramZeroThresholdField:
+			var v uint64
+			v, n = protowire.ConsumeFixed64(b)
+			h.zeroThreshold = math.Float64frombits(v)
+		case histogramZeroCountIntField:
+			h.zeroCount, n = protowire.ConsumeVarint(b)
+		case histogramNegativeSpansField, histogramPositiveSpansField:
+			var m []byte
+			m, n = protowire.ConsumeBytes(b)
+			span := decodeBucketSpan(t, m)
+			if num == histogramNegativeSpansField {
+				h.negativeSpans = append(h.negativeSpans, span)
+			} else {
+				h.positiveSpans = append(h.positiveSpans, span)
+			}
+		case histogramNegativeDeltaField, histogramPositiveDeltaField:
+			var m []byte
+			m, n = protowire.ConsumeBytes(b)
+			var deltas []int64
+			for len(m) > 0 {
+				v, k := protowire.ConsumeVarint(m)
+				require.GreaterOrEqual(t, k, 0)
+				m = m[k:]
+				deltas = append(deltas, protowire.DecodeZigZag(v))
+			}
+			if num == histogramNegativeDeltaField {
+				h.negativeDeltas = deltas
+			} else {
+				h.positiveDeltas = deltas
+			}
+		case histogramTimestampField:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			h.timestamp = int64(v)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+	}
+	return h
+}
+
+func decodeBucketSpan(t *testing.T, b []byte) prometheustranslator.BucketSpan {
+	var span prometheustranslator.BucketSpan
+	for len(b) > 0 {
+		num, _, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		v, n := protowire.ConsumeVarint(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		switch num {
+		case bucketSpanOffsetField:
+			span.Offset = int32(protowire.DecodeZigZag(v))
+		case bucketSpanLengthField:
+			span.Length = uint32(v)
+		}
+	}
+	return span
+}
+
+func exponentialHistogramMetrics(metrics ...pmetric.Metric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	for _, m := range metrics {
+		m.CopyTo(sm.Metrics().AppendEmpty())
+	}
+	return md
+}
+
+func TestFromMetricsExponentialHistogram(t *testing.T) {
+	metric := getExponentialHistogramMetric("exp_hist", lbs1, time1, floatVal2, 1, -1, []uint64{1, 0, 2})
+	neg := metric.ExponentialHistogram().DataPoints().At(0).Negative()
+	neg.SetOffset(2)
+	neg.BucketCounts().FromRaw([]uint64{3})
+	metric.ExponentialHistogram().DataPoints().At(0).SetZeroCount(4)
+	metric.ExponentialHistogram().DataPoints().At(0).SetCount(10)
+
+	tsMap, err := FromMetrics(exponentialHistogramMetrics(metric), Settings{})
+	require.NoError(t, err)
+	require.Len(t, tsMap, 1)
+
+	for _, ts := range tsMap {
+		assert.ElementsMatch(t, getPromLabels(label11, value11, label12, value12, nameStr, "exp_hist"), ts.Labels)
+		assert.Empty(t, ts.Samples)
+
+		// The histogram must survive a marshaling round trip of the time series.
+		b, err := ts.Marshal()
+		require.NoError(t, err)
+		var decoded prompb.TimeSeries
+		require.NoError(t, decoded.Unmarshal(b))
+
+		assert.Equal(t, []decodedHistogram{{
+			count:          10,
+			sum:            floatVal2,
+			schema:         1,
+			zeroCount:      4,
+			negativeSpans:  []prometheustranslator.BucketSpan{{Offset: 3, Length: 1}},
+			negativeDeltas: []int64{3},
+			positiveSpans:  []prometheustranslator.BucketSpan{{Offset: 0, Length: 3}},
+			positiveDeltas: []int64{1, -1, 2},
+			timestamp:      msTime1,
+		}}, decodeHistograms(t, decoded.XXX_unrecognized))
+	}
+}
+
+func TestFromMetricsExponentialHistogramStale(t *testing.T) {
+	metric := getExponentialHistogramMetric("staleNaN_exp_hist", lbs1, time1, floatVal2, 0, 0, []uint64{1})
+
+	tsMap, err := FromMetrics(exponentialHistogramMetrics(metric), Settings{})
+	require.NoError(t, err)
+	require.Len(t, tsMap, 1)
+	for _, ts := range tsMap {
+		histograms := decodeHistograms(t, ts.XXX_unrecognized)
+		require.Len(t, histograms, 1)
+		assert.True(t, value.IsStaleNaN(histograms[0].sum))
+	}
+}
+
+func TestFromMetricsExponentialHistogramUnsupportedScale(t *testing.T) {
+	metric := getExponentialHistogramMetric("exp_hist", lbs1, time1, floatVal2, -5, 0, []uint64{1})
+
+	tsMap, err := FromMetrics(exponentialHistogramMetrics(metric), Settings{})
+	assert.Error(t, err)
+	assert.Empty(t, tsMap)
+}
+
+func TestFromMetricsExponentialHistogramDownconvert(t *testing.T) {
+	metric := getExponentialHistogramMetric("exp_hist", lbs1, time1, floatVal2, 0, 0, []uint64{1, 2})
+
+	tsMap, err := FromMetrics(exponentialHistogramMetrics(metric), Settings{DownconvertExponentialHistograms: true})
+	require.NoError(t, err)
+
+	got := map[string]float64{}
+	for _, ts := range tsMap {
+		assert.Empty(t, ts.XXX_unrecognized)
+		require.Len(t, ts.Samples, 1)
+		var name, le string
+		for _, l := range ts.Labels {
+			switch l.Name {
+			case nameStr:
+				name = l.Value
+			case leStr:
+				le = l.Value
+			}
+		}
+		got[name+le] = ts.Samples[0].Value
+	}
+	assert.Equal(t, map[string]float64{
+		"exp_hist_sum":        floatVal2,
+		"exp_hist_count":      3,
+		"exp_hist_bucket0":    0,
+		"exp_hist_bucket2":    1,
+		"exp_hist_bucket4":    3,
+		"exp_hist_bucket+Inf": 3,
+	}, got)
+}
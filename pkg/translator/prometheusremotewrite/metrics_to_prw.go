@@ -38,6 +38,9 @@ type Settings struct {
 	Namespace         string
 	ExternalLabels    map[string]string
 	DisableTargetInfo bool
+	// DownconvertExponentialHistograms converts exponential histograms to explicit bucket
+	// histograms instead of native histograms, for targets without native histogram support.
+	DownconvertExponentialHistograms bool
 }
 
 // FromMetrics converts pmetric.Metrics to prometheus remote write format.
@@ -88,6 +91,16 @@ func FromMetrics(md pmetric.Metrics, settings Settings) (tsMap map[string]*promp
 					for x := 0; x < dataPoints.Len(); x++ {
 						addSingleHistogramDataPoint(dataPoints.At(x), resource, metric, settings, tsMap)
 					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dataPoints := metric.ExponentialHistogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						if err := addSingleExponentialHistogramDataPoint(dataPoints.At(x), resource, metric, settings, tsMap); err != nil {
+							errs = multierr.Append(errs, err)
+						}
+					}
 				case pmetric.MetricTypeSummary:
 					dataPoints := metric.Summary().DataPoints()
 					if dataPoints.Len() == 0 {
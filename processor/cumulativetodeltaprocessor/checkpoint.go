@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+)
+
+// trackerStateKey is the storage key holding the previous points of the tracked streams.
+const trackerStateKey = "tracker_state"
+
+func (ctdp *cumulativeToDeltaProcessor) setStorageClient(ctx context.Context, host component.Host) error {
+	extension, ok := host.GetExtensions()[*ctdp.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", ctdp.storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", ctdp.storageID)
+	}
+
+	client, err := storageExtension.GetClient(ctx, component.KindProcessor, ctdp.componentID, "")
+	if err != nil {
+		return err
+	}
+	ctdp.storageClient = client
+	return nil
+}
+
+// checkpoint writes the previous points of the tracked streams to the storage. They are gob
+// encoded, as unlike JSON it supports the NaN sums of histograms.
+func (ctdp *cumulativeToDeltaProcessor) checkpoint(ctx context.Context) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(ctdp.deltaCalculator.Snapshot()); err != nil {
+		return fmt.Errorf("failed to encode the tracked state: %w", err)
+	}
+	return ctdp.storageClient.Set(ctx, trackerStateKey, buf.Bytes())
+}
+
+// restore loads the previous points of the streams tracked when the storage was last checkpointed.
+func (ctdp *cumulativeToDeltaProcessor) restore(ctx context.Context) error {
+	content, err := ctdp.storageClient.Get(ctx, trackerStateKey)
+	if err != nil || content == nil {
+		return err
+	}
+
+	var points map[string]tracking.ValuePoint
+	if err = gob.NewDecoder(bytes.NewReader(content)).Decode(&points); err != nil {
+		// the state is only an optimization, so start from scratch rather than failing
+		ctdp.logger.Warn("Failed to read the tracked state from the storage", zap.Error(err))
+		return nil
+	}
+	ctdp.deltaCalculator.Restore(points)
+
+	ctdp.logger.Info("Restored tracked state from the storage", zap.Int("streams", len(points)))
+	return nil
+}
+
+// checkpointPeriodically checkpoints the tracked state until the processor is shut down, so that
+// little state is lost if the collector does not shut down gracefully.
+func (ctdp *cumulativeToDeltaProcessor) checkpointPeriodically() {
+	defer close(ctdp.checkpointDone)
+	ticker := time.NewTicker(ctdp.checkpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ctdp.checkpoint(ctdp.ctx); err != nil {
+				ctdp.logger.Warn("Failed to checkpoint the tracked state", zap.Error(err))
+			}
+		case <-ctdp.ctx.Done():
+			return
+		}
+	}
+}
@@ -36,6 +36,10 @@ type Config struct {
 	// Cannot be used with deprecated Metrics config option.
 	Include MatchMetrics `mapstructure:"include"`
 	Exclude MatchMetrics `mapstructure:"exclude"`
+
+	// StorageID is the ID of the storage extension used to checkpoint the tracked state, so that deltas
+	// continue from the last seen points when the collector restarts. State is kept only in memory when not set.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
 
 type MatchMetrics struct {
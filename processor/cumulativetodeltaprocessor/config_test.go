@@ -91,6 +91,15 @@ func TestLoadConfig(t *testing.T) {
 				MaxStaleness: 10 * time.Second,
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "storage"),
+			expected: func() config.Processor {
+				cfg := createDefaultConfig().(*Config)
+				storageID := config.NewComponentID("file_storage")
+				cfg.StorageID = &storageID
+				return cfg
+			}(),
+		},
 		{
 			id:           config.NewComponentIDWithName(typeStr, "missing_match_type"),
 			errorMessage: "match_type must be set if metrics are supplied",
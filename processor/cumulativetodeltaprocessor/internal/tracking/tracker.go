@@ -41,10 +41,11 @@ type State struct {
 }
 
 type DeltaValue struct {
-	StartTimestamp pcommon.Timestamp
-	FloatValue     float64
-	IntValue       int64
-	HistogramValue *HistogramPoint
+	StartTimestamp            pcommon.Timestamp
+	FloatValue                float64
+	IntValue                  int64
+	HistogramValue            *HistogramPoint
+	ExponentialHistogramValue *ExponentialHistogramPoint
 }
 
 func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
@@ -92,10 +93,11 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 	if !ok {
 		if metricID.MetricIsMonotonic {
 			out = DeltaValue{
-				StartTimestamp: metricPoint.ObservedTimestamp,
-				FloatValue:     metricPoint.FloatValue,
-				IntValue:       metricPoint.IntValue,
-				HistogramValue: metricPoint.HistogramValue,
+				StartTimestamp:            metricPoint.ObservedTimestamp,
+				FloatValue:                metricPoint.FloatValue,
+				IntValue:                  metricPoint.IntValue,
+				HistogramValue:            metricPoint.HistogramValue,
+				ExponentialHistogramValue: metricPoint.ExponentialHistogramValue,
 			}
 			valid = true
 		}
@@ -110,7 +112,8 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 	out.StartTimestamp = state.PrevPoint.ObservedTimestamp
 
 	switch metricID.MetricType {
-	case pmetric.MetricTypeHistogram:
+	case pmetric.MetricTypeHistogram, pmetric.MetricTypeSummary:
+		// Summaries are tracked as histograms without buckets, only their count and sum are converted.
 		value := metricPoint.HistogramValue
 		prevValue := state.PrevPoint.HistogramValue
 		if math.IsNaN(value.Sum) {
@@ -133,6 +136,36 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.HistogramValue = &delta
+	case pmetric.MetricTypeExponentialHistogram:
+		value := metricPoint.ExponentialHistogramValue
+		prevValue := *state.PrevPoint.ExponentialHistogramValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = prevValue.Sum
+		}
+
+		// SDKs reduce the scale of exponential histograms as their range grows, so the
+		// previous buckets are merged to the current scale before subtracting them.
+		if value.Scale > prevValue.Scale {
+			valid = false
+		} else if value.Scale < prevValue.Scale {
+			prevValue.Positive = prevValue.Positive.Downscale(prevValue.Scale - value.Scale)
+			prevValue.Negative = prevValue.Negative.Downscale(prevValue.Scale - value.Scale)
+		}
+
+		delta := value.Clone()
+		positive, positiveValid := delta.Positive.Sub(prevValue.Positive)
+		negative, negativeValid := delta.Negative.Sub(prevValue.Negative)
+
+		// Calculate deltas unless histogram count was reset
+		if valid && positiveValid && negativeValid && delta.Count >= prevValue.Count && delta.ZeroCount >= prevValue.ZeroCount {
+			delta.Count -= prevValue.Count
+			delta.Sum -= prevValue.Sum
+			delta.ZeroCount -= prevValue.ZeroCount
+			delta.Positive = positive
+			delta.Negative = negative
+		}
+
+		out.ExponentialHistogramValue = &delta
 	case pmetric.MetricTypeSum:
 		if metricID.IsFloatVal() {
 			value := metricPoint.FloatValue
@@ -163,6 +196,27 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 	return
 }
 
+// Snapshot returns the previous point of every tracked stream, keyed by stream identity.
+func (t *MetricTracker) Snapshot() map[string]ValuePoint {
+	points := make(map[string]ValuePoint)
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*State)
+		s.Lock()
+		points[key.(string)] = s.PrevPoint
+		s.Unlock()
+		return true
+	})
+	return points
+}
+
+// Restore tracks the streams of a snapshot, so that their next points are converted
+// relative to the snapshotted points. Streams that are already tracked are kept.
+func (t *MetricTracker) Restore(points map[string]ValuePoint) {
+	for key, point := range points {
+		t.states.LoadOrStore(key, &State{PrevPoint: point})
+	}
+}
+
 func (t *MetricTracker) removeStale(staleBefore pcommon.Timestamp) {
 	t.states.Range(func(key, value interface{}) bool {
 		s := value.(*State)
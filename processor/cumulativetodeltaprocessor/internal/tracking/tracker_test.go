@@ -151,6 +151,181 @@ func TestMetricTracker_Convert(t *testing.T) {
 	})
 }
 
+func TestMetricTracker_ConvertSummary(t *testing.T) {
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSummary,
+		MetricIsMonotonic:      true,
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	tests := []struct {
+		name      string
+		value     HistogramPoint
+		wantCount uint64
+		wantSum   float64
+	}{
+		{
+			name:      "Initial Value recorded",
+			value:     HistogramPoint{Count: 10, Sum: 100},
+			wantCount: 10,
+			wantSum:   100,
+		},
+		{
+			name:      "Higher Value Recorded",
+			value:     HistogramPoint{Count: 15, Sum: 160},
+			wantCount: 5,
+			wantSum:   60,
+		},
+		{
+			name:      "Lower Value Recorded",
+			value:     HistogramPoint{Count: 4, Sum: 40},
+			wantCount: 4,
+			wantSum:   40,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := tt.value
+			gotOut, valid := m.Convert(MetricPoint{
+				Identity: mi,
+				Value: ValuePoint{
+					ObservedTimestamp: pcommon.Timestamp(i + 1),
+					HistogramValue:    &value,
+				},
+			})
+			if !valid || gotOut.HistogramValue.Count != tt.wantCount || gotOut.HistogramValue.Sum != tt.wantSum {
+				t.Errorf("MetricTracker.Convert(MetricTypeSummary) = %v, want count %v and sum %v", gotOut.HistogramValue, tt.wantCount, tt.wantSum)
+			}
+		})
+	}
+}
+
+func TestMetricTracker_ConvertExponentialHistogram(t *testing.T) {
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeExponentialHistogram,
+		MetricIsMonotonic:      true,
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	tests := []struct {
+		name    string
+		value   ExponentialHistogramPoint
+		wantOut ExponentialHistogramPoint
+	}{
+		{
+			name: "Initial Value recorded",
+			value: ExponentialHistogramPoint{
+				Count: 6, Sum: 10, Scale: 2, ZeroCount: 1,
+				Positive: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 2, 1}},
+				Negative: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+			wantOut: ExponentialHistogramPoint{
+				Count: 6, Sum: 10, Scale: 2, ZeroCount: 1,
+				Positive: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 2, 1}},
+				Negative: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+		},
+		{
+			name: "Buckets grow",
+			value: ExponentialHistogramPoint{
+				Count: 10, Sum: 20, Scale: 2, ZeroCount: 1,
+				Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 1, 4, 1, 1}},
+				Negative: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{2}},
+			},
+			wantOut: ExponentialHistogramPoint{
+				Count: 4, Sum: 10, Scale: 2, ZeroCount: 0,
+				Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 0, 2, 0, 1}},
+				Negative: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			},
+		},
+		{
+			name: "Scale is reduced",
+			value: ExponentialHistogramPoint{
+				Count: 12, Sum: 25, Scale: 1, ZeroCount: 1,
+				Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{3, 6, 1}},
+				Negative: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{2}},
+			},
+			wantOut: ExponentialHistogramPoint{
+				Count: 2, Sum: 5, Scale: 1, ZeroCount: 0,
+				Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 1, 0}},
+				Negative: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{0}},
+			},
+		},
+		{
+			name: "Histogram reset",
+			value: ExponentialHistogramPoint{
+				Count: 3, Sum: 4, Scale: 1,
+				Positive: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{3}},
+			},
+			wantOut: ExponentialHistogramPoint{
+				Count: 3, Sum: 4, Scale: 1,
+				Positive: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{3}},
+				Negative: ExponentialBuckets{BucketCounts: []uint64{}},
+			},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := tt.value
+			gotOut, valid := m.Convert(MetricPoint{
+				Identity: mi,
+				Value: ValuePoint{
+					ObservedTimestamp:         pcommon.Timestamp(i + 1),
+					ExponentialHistogramValue: &value,
+				},
+			})
+			if !valid || !reflect.DeepEqual(*gotOut.ExponentialHistogramValue, tt.wantOut) {
+				t.Errorf("MetricTracker.Convert(MetricTypeExponentialHistogram) = %v, want %v", gotOut.ExponentialHistogramValue, tt.wantOut)
+			}
+		})
+	}
+
+	t.Run("Scale is increased", func(t *testing.T) {
+		value := ExponentialHistogramPoint{Count: 20, Scale: 3}
+		if _, valid := m.Convert(MetricPoint{
+			Identity: mi,
+			Value:    ValuePoint{ObservedTimestamp: 10, ExponentialHistogramValue: &value},
+		}); valid {
+			t.Error("Expected invalid for exponential histogram with increased scale")
+		}
+	})
+}
+
+func TestMetricTracker_SnapshotRestore(t *testing.T) {
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	m.Convert(MetricPoint{Identity: mi, Value: ValuePoint{ObservedTimestamp: 10, IntValue: 100}})
+	snapshot := m.Snapshot()
+	if len(snapshot) != 1 {
+		t.Fatalf("MetricTracker.Snapshot() = %v, want a single stream", snapshot)
+	}
+
+	restored := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	restored.Restore(snapshot)
+	gotOut, valid := restored.Convert(MetricPoint{Identity: mi, Value: ValuePoint{ObservedTimestamp: 20, IntValue: 150}})
+	if !valid || gotOut.StartTimestamp != 10 || gotOut.IntValue != 50 {
+		t.Errorf("MetricTracker.Convert() after Restore() = %v, want delta 50 since 10", gotOut)
+	}
+}
+
 func Test_metricTracker_removeStale(t *testing.T) {
 	currentTime := pcommon.Timestamp(100)
 	freshPoint := ValuePoint{
@@ -14,13 +14,18 @@
 
 package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
 
-import "go.opentelemetry.io/collector/pdata/pcommon"
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/histogramutils"
+)
 
 type ValuePoint struct {
-	ObservedTimestamp pcommon.Timestamp
-	FloatValue        float64
-	IntValue          int64
-	HistogramValue    *HistogramPoint
+	ObservedTimestamp         pcommon.Timestamp
+	FloatValue                float64
+	IntValue                  int64
+	HistogramValue            *HistogramPoint
+	ExponentialHistogramValue *ExponentialHistogramPoint
 }
 
 type HistogramPoint struct {
@@ -39,3 +44,64 @@ func (point *HistogramPoint) Clone() HistogramPoint {
 		Buckets: bucketValues,
 	}
 }
+
+type ExponentialHistogramPoint struct {
+	Count     uint64
+	Sum       float64
+	Scale     int32
+	ZeroCount uint64
+	Positive  ExponentialBuckets
+	Negative  ExponentialBuckets
+}
+
+type ExponentialBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+func (point *ExponentialHistogramPoint) Clone() ExponentialHistogramPoint {
+	return ExponentialHistogramPoint{
+		Count:     point.Count,
+		Sum:       point.Sum,
+		Scale:     point.Scale,
+		ZeroCount: point.ZeroCount,
+		Positive:  point.Positive.Clone(),
+		Negative:  point.Negative.Clone(),
+	}
+}
+
+func (buckets ExponentialBuckets) Clone() ExponentialBuckets {
+	bucketCounts := make([]uint64, len(buckets.BucketCounts))
+	copy(bucketCounts, buckets.BucketCounts)
+
+	return ExponentialBuckets{
+		Offset:       buckets.Offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+// Downscale reduces the scale of the buckets by the given amount, merging each group of 2^by adjacent buckets.
+func (buckets ExponentialBuckets) Downscale(by int32) ExponentialBuckets {
+	offset, bucketCounts := histogramutils.Downscale(buckets.Offset, buckets.BucketCounts, by)
+	return ExponentialBuckets{
+		Offset:       offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+// Sub returns the buckets with the counts of prev subtracted. It returns false if a bucket of prev
+// holds more than the corresponding bucket, which happens when the histogram was reset.
+func (buckets ExponentialBuckets) Sub(prev ExponentialBuckets) (ExponentialBuckets, bool) {
+	delta := buckets.Clone()
+	for i, prevCount := range prev.BucketCounts {
+		if prevCount == 0 {
+			continue
+		}
+		j := int(prev.Offset-buckets.Offset) + i
+		if j < 0 || j >= len(delta.BucketCounts) || delta.BucketCounts[j] < prevCount {
+			return buckets, false
+		}
+		delta.BucketCounts[j] -= prevCount
+	}
+	return delta, true
+}
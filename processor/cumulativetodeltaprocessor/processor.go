@@ -17,16 +17,24 @@ package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentele
 import (
 	"context"
 	"math"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
 )
 
-const enableHistogramSupportGateID = "processor.cumulativetodeltaprocessor.EnableHistogramSupport"
+const (
+	enableHistogramSupportGateID = "processor.cumulativetodeltaprocessor.EnableHistogramSupport"
+	enableSummarySupportGateID   = "processor.cumulativetodeltaprocessor.EnableSummarySupport"
+)
 
 var enableHistogramSupportGate = featuregate.Gate{
 	ID:          enableHistogramSupportGateID,
@@ -34,17 +42,36 @@ var enableHistogramSupportGate = featuregate.Gate{
 	Description: "Enables histogram conversion support",
 }
 
+var enableSummarySupportGate = featuregate.Gate{
+	ID:          enableSummarySupportGateID,
+	Enabled:     false,
+	Description: "Enables the conversion of the count and sum of summaries, which OTLP defines as cumulative only",
+}
+
 func init() {
 	featuregate.GetRegistry().MustRegister(enableHistogramSupportGate)
+	featuregate.GetRegistry().MustRegister(enableSummarySupportGate)
 }
 
+// checkpointInterval is how often the tracked state is written to the storage, if any.
+const checkpointInterval = time.Minute
+
 type cumulativeToDeltaProcessor struct {
 	includeFS               filterset.FilterSet
 	excludeFS               filterset.FilterSet
 	logger                  *zap.Logger
 	deltaCalculator         *tracking.MetricTracker
+	ctx                     context.Context
 	cancelFunc              context.CancelFunc
 	histogramSupportEnabled bool
+	summarySupportEnabled   bool
+
+	// storageID and componentID identify the storage client used to checkpoint the tracked state, if any.
+	storageID          *config.ComponentID
+	componentID        config.ComponentID
+	storageClient      storage.Client
+	checkpointInterval time.Duration
+	checkpointDone     chan struct{}
 }
 
 func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulativeToDeltaProcessor {
@@ -52,8 +79,13 @@ func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulati
 	p := &cumulativeToDeltaProcessor{
 		logger:                  logger,
 		deltaCalculator:         tracking.NewMetricTracker(ctx, logger, config.MaxStaleness),
+		ctx:                     ctx,
 		cancelFunc:              cancel,
 		histogramSupportEnabled: featuregate.GetRegistry().IsEnabled(enableHistogramSupportGateID),
+		summarySupportEnabled:   featuregate.GetRegistry().IsEnabled(enableSummarySupportGateID),
+		storageID:               config.StorageID,
+		componentID:             config.ID(),
+		checkpointInterval:      checkpointInterval,
 	}
 	if len(config.Include.Metrics) > 0 {
 		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
@@ -123,6 +155,58 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 
 					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					if !ctdp.histogramSupportEnabled {
+						return false
+					}
+
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						return false
+					}
+
+					if ms.DataPoints().Len() == 0 {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricType:             m.Type(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+					}
+
+					ctdp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeSummary:
+					// Summaries are always cumulative, their count and sum are converted
+					// while their quantiles are left unchanged.
+					if !ctdp.summarySupportEnabled {
+						return false
+					}
+
+					ms := m.Summary()
+					if ms.DataPoints().Len() == 0 {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricType:             m.Type(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+					}
+
+					ctdp.convertSummaryDataPoints(ms.DataPoints(), baseIdentity)
+					return ms.DataPoints().Len() == 0
 				default:
 					return false
 				}
@@ -134,11 +218,34 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 	return md, nil
 }
 
-func (ctdp *cumulativeToDeltaProcessor) shutdown(context.Context) error {
-	ctdp.cancelFunc()
+func (ctdp *cumulativeToDeltaProcessor) start(ctx context.Context, host component.Host) error {
+	if ctdp.storageID == nil {
+		return nil
+	}
+	if err := ctdp.setStorageClient(ctx, host); err != nil {
+		return err
+	}
+	if err := ctdp.restore(ctx); err != nil {
+		err = multierr.Append(err, ctdp.storageClient.Close(ctx))
+		ctdp.storageClient = nil
+		return err
+	}
+	ctdp.checkpointDone = make(chan struct{})
+	go ctdp.checkpointPeriodically()
 	return nil
 }
 
+func (ctdp *cumulativeToDeltaProcessor) shutdown(ctx context.Context) error {
+	ctdp.cancelFunc()
+	if ctdp.storageClient == nil {
+		return nil
+	}
+	if ctdp.checkpointDone != nil {
+		<-ctdp.checkpointDone
+	}
+	return multierr.Combine(ctdp.checkpoint(ctx), ctdp.storageClient.Close(ctx))
+}
+
 func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(metricName string) bool {
 	return (ctdp.includeFS == nil || ctdp.includeFS.Matches(metricName)) &&
 		(ctdp.excludeFS == nil || !ctdp.excludeFS.Matches(metricName))
@@ -224,3 +331,91 @@ func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(in interface{
 		})
 	}
 }
+
+func (ctdp *cumulativeToDeltaProcessor) convertExponentialHistogramDataPoints(in interface{}, baseIdentity tracking.MetricIdentity) {
+
+	if dps, ok := in.(pmetric.ExponentialHistogramDataPointSlice); ok {
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			id := baseIdentity
+			id.StartTimestamp = dp.StartTimestamp()
+			id.Attributes = dp.Attributes()
+
+			point := tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				ExponentialHistogramValue: &tracking.ExponentialHistogramPoint{
+					Count:     dp.Count(),
+					Sum:       dp.Sum(),
+					Scale:     dp.Scale(),
+					ZeroCount: dp.ZeroCount(),
+					Positive: tracking.ExponentialBuckets{
+						Offset:       dp.Positive().Offset(),
+						BucketCounts: dp.Positive().BucketCounts().AsRaw(),
+					},
+					Negative: tracking.ExponentialBuckets{
+						Offset:       dp.Negative().Offset(),
+						BucketCounts: dp.Negative().BucketCounts().AsRaw(),
+					},
+				},
+			}
+
+			trackingPoint := tracking.MetricPoint{
+				Identity: id,
+				Value:    point,
+			}
+			delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+
+			if valid {
+				dp.SetStartTimestamp(delta.StartTimestamp)
+				dp.SetCount(delta.ExponentialHistogramValue.Count)
+				if dp.HasSum() && !math.IsNaN(dp.Sum()) {
+					dp.SetSum(delta.ExponentialHistogramValue.Sum)
+				}
+				dp.SetScale(delta.ExponentialHistogramValue.Scale)
+				dp.SetZeroCount(delta.ExponentialHistogramValue.ZeroCount)
+				dp.Positive().SetOffset(delta.ExponentialHistogramValue.Positive.Offset)
+				dp.Positive().BucketCounts().FromRaw(delta.ExponentialHistogramValue.Positive.BucketCounts)
+				dp.Negative().SetOffset(delta.ExponentialHistogramValue.Negative.Offset)
+				dp.Negative().BucketCounts().FromRaw(delta.ExponentialHistogramValue.Negative.BucketCounts)
+				return false
+			}
+
+			return !valid
+		})
+	}
+}
+
+func (ctdp *cumulativeToDeltaProcessor) convertSummaryDataPoints(in interface{}, baseIdentity tracking.MetricIdentity) {
+
+	if dps, ok := in.(pmetric.SummaryDataPointSlice); ok {
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+			id := baseIdentity
+			id.StartTimestamp = dp.StartTimestamp()
+			id.Attributes = dp.Attributes()
+
+			point := tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				HistogramValue: &tracking.HistogramPoint{
+					Count: dp.Count(),
+					Sum:   dp.Sum(),
+				},
+			}
+
+			trackingPoint := tracking.MetricPoint{
+				Identity: id,
+				Value:    point,
+			}
+			delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+
+			if valid {
+				dp.SetStartTimestamp(delta.StartTimestamp)
+				dp.SetCount(delta.HistogramValue.Count)
+				if !math.IsNaN(dp.Sum()) {
+					dp.SetSum(delta.HistogramValue.Sum)
+				}
+				return false
+			}
+
+			return !valid
+		})
+	}
+}
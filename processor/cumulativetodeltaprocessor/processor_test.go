@@ -16,6 +16,7 @@ package cumulativetodeltaprocessor
 
 import (
 	"context"
+	"errors"
 	"math"
 	"testing"
 	"time"
@@ -26,11 +27,13 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
 )
 
@@ -371,6 +374,208 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 	}
 }
 
+func TestCumulativeToDeltaProcessorSummary(t *testing.T) {
+	registry := featuregate.GetRegistry()
+	require.NoError(t, registry.Apply(map[string]bool{enableSummarySupportGateID: true}))
+	defer func() {
+		require.NoError(t, registry.Apply(map[string]bool{enableSummarySupportGateID: false}))
+	}()
+
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, createDefaultConfig().(*Config), next, componenttest.NewNopHost())
+
+	md := generateTestSummaryMetrics([]uint64{10, 25, 5})
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	dps := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Summary().DataPoints()
+	// the count decreased in the last point, which is then treated as a reset
+	require.Equal(t, 3, dps.Len())
+	for i, expected := range []uint64{10, 15, 5} {
+		assert.Equal(t, expected, dps.At(i).Count())
+		assert.Equal(t, float64(expected)*2, dps.At(i).Sum())
+	}
+	// quantiles are left unchanged
+	assert.Equal(t, 25.0, dps.At(1).QuantileValues().At(0).Value())
+}
+
+func TestCumulativeToDeltaProcessorSummaryDisabled(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, createDefaultConfig().(*Config), next, componenttest.NewNopHost())
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), generateTestSummaryMetrics([]uint64{10, 25, 5})))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	assert.Equal(t, generateTestSummaryMetrics([]uint64{10, 25, 5}), got[0])
+}
+
+func TestCumulativeToDeltaProcessorExponentialHistogram(t *testing.T) {
+	require.NoError(t, featuregate.GetRegistry().Apply(map[string]bool{
+		enableHistogramSupportGateID: true,
+	}))
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, createDefaultConfig().(*Config), next, componenttest.NewNopHost())
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("exponential_histogram")
+	eh := m.SetEmptyExponentialHistogram()
+	eh.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	dp := eh.DataPoints().AppendEmpty()
+	dp.SetTimestamp(1)
+	dp.SetScale(1)
+	dp.SetCount(4)
+	dp.SetSum(10)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(0)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+
+	// the scale is reduced, so the previous buckets are merged before subtracting them
+	dp = eh.DataPoints().AppendEmpty()
+	dp.SetTimestamp(2)
+	dp.SetScale(0)
+	dp.SetCount(10)
+	dp.SetSum(30)
+	dp.SetZeroCount(2)
+	dp.Positive().SetOffset(0)
+	dp.Positive().BucketCounts().FromRaw([]uint64{5, 2})
+	dp.Negative().SetOffset(-1)
+	dp.Negative().BucketCounts().FromRaw([]uint64{1})
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	out := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, out.AggregationTemporality())
+	require.Equal(t, 2, out.DataPoints().Len())
+
+	first := out.DataPoints().At(0)
+	assert.Equal(t, uint64(4), first.Count())
+	assert.Equal(t, []uint64{1, 2}, first.Positive().BucketCounts().AsRaw())
+
+	second := out.DataPoints().At(1)
+	assert.Equal(t, int32(0), second.Scale())
+	assert.Equal(t, uint64(6), second.Count())
+	assert.Equal(t, 20.0, second.Sum())
+	assert.Equal(t, uint64(1), second.ZeroCount())
+	assert.Equal(t, int32(0), second.Positive().Offset())
+	assert.Equal(t, []uint64{2, 2}, second.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(-1), second.Negative().Offset())
+	assert.Equal(t, []uint64{1}, second.Negative().BucketCounts().AsRaw())
+}
+
+func TestCumulativeToDeltaStateIsRestored(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	// the first instance sees the initial point, and checkpoints it on shutdown
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, cfg, next, host)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), generateTestSumMetrics(testSumMetric{
+		metricNames:  []string{"metric_1"},
+		metricValues: [][]float64{{100}},
+		isCumulative: []bool{true},
+	})))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// the second instance continues from the restored point
+	next = new(consumertest.MetricsSink)
+	p = newTestProcessor(t, cfg, next, host)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), generateTestSumMetrics(testSumMetric{
+		metricNames:  []string{"metric_1"},
+		metricValues: [][]float64{{150}},
+		isCumulative: []bool{true},
+	})))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	dps := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, 50.0, dps.At(0).DoubleValue())
+}
+
+func TestCumulativeToDeltaStartWithMissingStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	p, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, p.Start(context.Background(), storagetest.NewStorageHost()))
+}
+
+// failingStorage is a storage extension whose clients fail to read
+type failingStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client *failingClient
+}
+
+func (s *failingStorage) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return s.client, nil
+}
+
+type failingClient struct {
+	storage.Client
+	closed bool
+}
+
+func (c *failingClient) Get(context.Context, string) ([]byte, error) {
+	return nil, errors.New("failed")
+}
+
+func (c *failingClient) Close(context.Context) error {
+	c.closed = true
+	return nil
+}
+
+func TestCumulativeToDeltaStartWithFailingStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("failing")
+	ext := &failingStorage{client: &failingClient{}}
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	p, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, p.Start(context.Background(), storagetest.NewStorageHost().WithExtension(storageID, ext)))
+	assert.True(t, ext.client.closed)
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func newTestProcessor(t *testing.T, cfg *Config, next *consumertest.MetricsSink, host component.Host) component.MetricsProcessor {
+	p, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), host))
+	return p
+}
+
+func generateTestSummaryMetrics(counts []uint64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("summary")
+	summary := m.SetEmptySummary()
+	for i, count := range counts {
+		dp := summary.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(i + 1))
+		dp.SetCount(count)
+		dp.SetSum(float64(count) * 2)
+		q := dp.QuantileValues().AppendEmpty()
+		q.SetQuantile(0.5)
+		q.SetValue(float64(count))
+	}
+	return md
+}
+
 func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"bytes"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// sep separates the fields of a stream key.
+const sep = byte(0x1E)
+
+// metricKey identifies a metric across batches, by its resource, scope, name, unit and type.
+func metricKey(b *bytes.Buffer, resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric) string {
+	b.Reset()
+	b.WriteString(strconv.Itoa(int(m.Type())))
+	if m.Type() == pmetric.MetricTypeSum && m.Sum().IsMonotonic() {
+		b.WriteByte('Y')
+	}
+	writeAttributes(b, resource.Attributes())
+	b.WriteByte(sep)
+	b.WriteString(scope.Name())
+	b.WriteByte(sep)
+	b.WriteString(scope.Version())
+	b.WriteByte(sep)
+	b.WriteString(m.Name())
+	b.WriteByte(sep)
+	b.WriteString(m.Unit())
+	return b.String()
+}
+
+// streamKey identifies a stream of points, by the key of its metric and the attributes of its points.
+// Unlike cumulative streams, the start time is not part of the identity, as it changes with every delta point.
+func streamKey(b *bytes.Buffer, metricKey string, attributes pcommon.Map) string {
+	b.Reset()
+	b.WriteString(metricKey)
+	writeAttributes(b, attributes)
+	return b.String()
+}
+
+func writeAttributes(b *bytes.Buffer, attributes pcommon.Map) {
+	attributes.Sort().Range(func(k string, v pcommon.Value) bool {
+		b.WriteByte(sep)
+		b.WriteString(k)
+		b.WriteByte(':')
+		b.WriteString(v.AsString())
+		return true
+	})
+}
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type deltaToCumulativeProcessor struct {
+	logger       *zap.Logger
+	maxStaleness time.Duration
+	maxStreams   int
+	cancelFunc   context.CancelFunc
+
+	// mu guards streams, which holds the tracked streams by their key.
+	mu      sync.Mutex
+	streams map[string]*stream
+}
+
+// dataPoint is implemented by the data points of all the accumulated metric types.
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	Flags() pmetric.DataPointFlags
+}
+
+func newDeltaToCumulativeProcessor(config *Config, logger *zap.Logger) *deltaToCumulativeProcessor {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &deltaToCumulativeProcessor{
+		logger:       logger,
+		maxStaleness: config.MaxStaleness,
+		maxStreams:   config.MaxStreams,
+		cancelFunc:   cancel,
+		streams:      make(map[string]*stream),
+	}
+	if p.maxStaleness > 0 {
+		go p.sweeper(ctx)
+	}
+	return p
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (p *deltaToCumulativeProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var b bytes.Buffer
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					ms := m.Sum()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+
+					key := metricKey(&b, rm.Resource(), sm.Scope(), m)
+					ms.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						s, keep := p.lookup(streamKey(&b, key, dp.Attributes()), dp, now)
+						if s != nil {
+							s.accumulateNumber(dp)
+						}
+						return !keep
+					})
+
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					ms := m.Histogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+
+					key := metricKey(&b, rm.Resource(), sm.Scope(), m)
+					ms.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+						s, keep := p.lookup(streamKey(&b, key, dp.Attributes()), dp, now)
+						if s != nil {
+							s.accumulateHistogram(dp)
+						}
+						return !keep
+					})
+
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+
+					key := metricKey(&b, rm.Resource(), sm.Scope(), m)
+					ms.DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+						s, keep := p.lookup(streamKey(&b, key, dp.Attributes()), dp, now)
+						if s != nil {
+							s.accumulateExponentialHistogram(dp)
+						}
+						return !keep
+					})
+
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				default:
+					return false
+				}
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+// lookup returns the stream the point must be accumulated into, and whether the point must be kept.
+// A point without recorded value ends its stream, and is kept with the start time of the stream
+// but not accumulated. Points older than the last accumulated one, and points of new streams beyond
+// the maximum number of streams, are dropped.
+func (p *deltaToCumulativeProcessor) lookup(key string, dp dataPoint, now time.Time) (*stream, bool) {
+	s, ok := p.streams[key]
+	if dp.Flags().NoRecordedValue() {
+		if ok {
+			dp.SetStartTimestamp(s.start)
+			delete(p.streams, key)
+		}
+		return nil, true
+	}
+
+	if !ok {
+		if p.maxStreams > 0 && len(p.streams) >= p.maxStreams {
+			p.logger.Debug("dropping point of new stream, as the maximum number of streams is tracked",
+				zap.Int("max_streams", p.maxStreams))
+			return nil, false
+		}
+		s = &stream{}
+		p.streams[key] = s
+	} else if dp.Timestamp() <= s.last {
+		p.logger.Debug("dropping point older than the last point of its stream",
+			zap.Stringer("timestamp", dp.Timestamp()), zap.Stringer("last", s.last))
+		return nil, false
+	}
+
+	s.last = dp.Timestamp()
+	s.lastSeen = now
+	return s, true
+}
+
+// removeStale stops tracking the streams without points since staleBefore.
+func (p *deltaToCumulativeProcessor) removeStale(staleBefore time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, s := range p.streams {
+		if s.lastSeen.Before(staleBefore) {
+			delete(p.streams, key)
+		}
+	}
+}
+
+func (p *deltaToCumulativeProcessor) sweeper(ctx context.Context) {
+	ticker := time.NewTicker(p.maxStaleness)
+	for {
+		select {
+		case currentTime := <-ticker.C:
+			p.removeStale(currentTime.Add(-p.maxStaleness))
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+func (p *deltaToCumulativeProcessor) shutdown(context.Context) error {
+	p.cancelFunc()
+	return nil
+}
@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type testSumPoint struct {
+	attr  string
+	start pcommon.Timestamp
+	ts    pcommon.Timestamp
+	value int64
+}
+
+func generateTestSumMetrics(temporality pmetric.AggregationTemporality, points ...testSumPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("sum")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	for _, point := range points {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("attr", point.attr)
+		dp.SetStartTimestamp(point.start)
+		dp.SetTimestamp(point.ts)
+		dp.SetIntValue(point.value)
+	}
+	return md
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) *deltaToCumulativeProcessor {
+	p := newDeltaToCumulativeProcessor(cfg, zap.NewNop())
+	t.Cleanup(func() {
+		require.NoError(t, p.shutdown(context.Background()))
+	})
+	return p
+}
+
+func process(t *testing.T, p *deltaToCumulativeProcessor, md pmetric.Metrics) pmetric.Metrics {
+	out, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	return out
+}
+
+func TestAccumulateSums(t *testing.T) {
+	p := newTestProcessor(t, &Config{})
+
+	out := process(t, p, generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 1, ts: 2, value: 10},
+		testSumPoint{attr: "b", start: 1, ts: 2, value: 1},
+	))
+	sum := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+	assert.Equal(t, int64(10), sum.DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(1), sum.DataPoints().At(1).IntValue())
+
+	out = process(t, p, generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 2, ts: 3, value: 5},
+		testSumPoint{attr: "a", start: 3, ts: 4, value: 1},
+		// out of order, dropped
+		testSumPoint{attr: "b", start: 0, ts: 2, value: 100},
+	))
+	dps := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(15), dps.At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(1), dps.At(0).StartTimestamp())
+	assert.Equal(t, int64(16), dps.At(1).IntValue())
+	assert.Equal(t, pcommon.Timestamp(1), dps.At(1).StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(4), dps.At(1).Timestamp())
+
+	// the whole metric is removed once all its points are dropped
+	out = process(t, p, generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 0, ts: 4, value: 1},
+	))
+	assert.Equal(t, 0, out.ResourceMetrics().Len())
+}
+
+func TestCumulativeSumsAreUnchanged(t *testing.T) {
+	p := newTestProcessor(t, &Config{})
+
+	in := generateTestSumMetrics(pmetric.AggregationTemporalityCumulative,
+		testSumPoint{attr: "a", start: 1, ts: 2, value: 10},
+	)
+	expected := pmetric.NewMetrics()
+	in.CopyTo(expected)
+	assert.Equal(t, expected, process(t, p, in))
+	assert.Empty(t, p.streams)
+}
+
+func TestAccumulateSumValueTypeChange(t *testing.T) {
+	p := newTestProcessor(t, &Config{})
+
+	process(t, p, generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 1, ts: 2, value: 10},
+	))
+
+	md := generateTestSumMetrics(pmetric.AggregationTemporalityDelta)
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("attr", "a")
+	dp.SetStartTimestamp(2)
+	dp.SetTimestamp(3)
+	dp.SetDoubleValue(1.5)
+
+	// the stream restarts from the point with a different value type
+	out := process(t, p, md)
+	dp = out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, 1.5, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(2), dp.StartTimestamp())
+}
+
+func TestAccumulateHistograms(t *testing.T) {
+	p := newTestProcessor(t, &Config{})
+
+	generate := func(start, ts pcommon.Timestamp, bounds []float64, counts []uint64, min, max float64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("histogram")
+		hist := m.SetEmptyHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(float64(count))
+		dp.SetMin(min)
+		dp.SetMax(max)
+		return md
+	}
+	point := func(md pmetric.Metrics) pmetric.HistogramDataPoint {
+		hist := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram()
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, hist.AggregationTemporality())
+		return hist.DataPoints().At(0)
+	}
+
+	process(t, p, generate(1, 2, []float64{1, 10}, []uint64{1, 2, 3}, 0.5, 20))
+
+	dp := point(process(t, p, generate(2, 3, []float64{1, 10}, []uint64{0, 4, 0}, 2, 5)))
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	assert.Equal(t, uint64(10), dp.Count())
+	assert.Equal(t, 10.0, dp.Sum())
+	assert.Equal(t, 0.5, dp.Min())
+	assert.Equal(t, 20.0, dp.Max())
+	assert.Equal(t, []uint64{1, 6, 3}, dp.BucketCounts().AsRaw())
+
+	// the stream restarts when the bucket boundaries change
+	dp = point(process(t, p, generate(3, 4, []float64{5}, []uint64{2, 2}, 1, 8)))
+	assert.Equal(t, pcommon.Timestamp(3), dp.StartTimestamp())
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, 1.0, dp.Min())
+	assert.Equal(t, []uint64{2, 2}, dp.BucketCounts().AsRaw())
+}
+
+func TestAccumulateExponentialHistograms(t *testing.T) {
+	p := newTestProcessor(t, &Config{})
+
+	generate := func(start, ts pcommon.Timestamp, scale int32, offset int32, counts []uint64, zeroCount uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("exponential_histogram")
+		hist := m.SetEmptyExponentialHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetScale(scale)
+		dp.SetZeroCount(zeroCount)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(counts)
+		count := zeroCount
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		return md
+	}
+	point := func(md pmetric.Metrics) pmetric.ExponentialHistogramDataPoint {
+		hist := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, hist.AggregationTemporality())
+		return hist.DataPoints().At(0)
+	}
+
+	process(t, p, generate(1, 2, 2, 4, []uint64{1, 2, 3}, 1))
+
+	dp := point(process(t, p, generate(2, 3, 2, 2, []uint64{1}, 0)))
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	assert.Equal(t, int32(2), dp.Scale())
+	assert.Equal(t, uint64(8), dp.Count())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, int32(2), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 0, 1, 2, 3}, dp.Positive().BucketCounts().AsRaw())
+	assert.False(t, dp.HasSum())
+
+	// the accumulated buckets are merged to the lower scale of the new point
+	dp = point(process(t, p, generate(3, 4, 1, 3, []uint64{1}, 2)))
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(11), dp.Count())
+	assert.Equal(t, uint64(3), dp.ZeroCount())
+	assert.Equal(t, int32(1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 3, 4}, dp.Positive().BucketCounts().AsRaw())
+
+	// a point with a higher scale is merged to the accumulated scale
+	dp = point(process(t, p, generate(4, 5, 3, 8, []uint64{1, 1}, 0)))
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(13), dp.Count())
+	assert.Equal(t, int32(1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 5, 4}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestMaxStreams(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxStreams: 1})
+
+	out := process(t, p, generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 1, ts: 2, value: 10},
+		testSumPoint{attr: "b", start: 1, ts: 2, value: 1},
+	))
+	dps := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	a, _ := dps.At(0).Attributes().Get("attr")
+	assert.Equal(t, "a", a.Str())
+	assert.Len(t, p.streams, 1)
+
+	// points of tracked streams are still accumulated
+	out = process(t, p, generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 2, ts: 3, value: 5},
+	))
+	assert.Equal(t, int64(15), out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+}
+
+func TestRemoveStale(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxStaleness: time.Minute})
+
+	process(t, p, generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 1, ts: 2, value: 10},
+	))
+	p.removeStale(time.Now().Add(-time.Minute))
+	assert.Len(t, p.streams, 1)
+	p.removeStale(time.Now().Add(time.Second))
+	assert.Empty(t, p.streams)
+
+	// the evicted stream restarts with a new start time
+	out := process(t, p, generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 2, ts: 3, value: 5},
+	))
+	dp := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(5), dp.IntValue())
+	assert.Equal(t, pcommon.Timestamp(2), dp.StartTimestamp())
+}
+
+func TestNoRecordedValueEndsStream(t *testing.T) {
+	p := newTestProcessor(t, &Config{})
+
+	process(t, p, generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 1, ts: 2, value: 10},
+	))
+
+	md := generateTestSumMetrics(pmetric.AggregationTemporalityDelta,
+		testSumPoint{attr: "a", start: 2, ts: 3},
+	)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).
+		SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	out := process(t, p, md)
+	dp := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.True(t, dp.Flags().NoRecordedValue())
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	assert.Empty(t, p.streams)
+}
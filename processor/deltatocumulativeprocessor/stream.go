@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/histogramutils"
+)
+
+// stream holds the cumulative value of a stream of delta points.
+type stream struct {
+	// start is the start time of the cumulative points, kept until the stream is reset.
+	start pcommon.Timestamp
+	// last is the time of the last accumulated point, older points are dropped.
+	last pcommon.Timestamp
+	// lastSeen is when the last point of the stream was received, used to evict stale streams.
+	lastSeen time.Time
+	// acc is a pmetric.NumberDataPoint, pmetric.HistogramDataPoint or pmetric.ExponentialHistogramDataPoint
+	// holding the values accumulated so far.
+	acc interface{}
+}
+
+// reset starts the stream over from the given point.
+func (s *stream) reset(startTimestamp, timestamp pcommon.Timestamp) {
+	s.start = startTimestamp
+	if s.start == 0 {
+		s.start = timestamp
+	}
+}
+
+// accumulateNumber adds the value of dp to the stream, and sets dp to the cumulative value.
+func (s *stream) accumulateNumber(dp pmetric.NumberDataPoint) {
+	acc, ok := s.acc.(pmetric.NumberDataPoint)
+	if !ok || acc.ValueType() != dp.ValueType() {
+		s.reset(dp.StartTimestamp(), dp.Timestamp())
+		acc = pmetric.NewNumberDataPoint()
+		s.acc = acc
+	} else {
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			dp.SetIntValue(acc.IntValue() + dp.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			dp.SetDoubleValue(acc.DoubleValue() + dp.DoubleValue())
+		}
+	}
+
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		acc.SetIntValue(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		acc.SetDoubleValue(dp.DoubleValue())
+	}
+	dp.SetStartTimestamp(s.start)
+}
+
+// accumulateHistogram adds the counts of dp to the stream, and sets dp to the cumulative counts.
+// The stream is reset when the bucket boundaries change.
+func (s *stream) accumulateHistogram(dp pmetric.HistogramDataPoint) {
+	acc, ok := s.acc.(pmetric.HistogramDataPoint)
+	if !ok || !histogramutils.EqualBounds(acc.ExplicitBounds(), dp.ExplicitBounds()) || acc.BucketCounts().Len() != dp.BucketCounts().Len() {
+		s.reset(dp.StartTimestamp(), dp.Timestamp())
+		acc = pmetric.NewHistogramDataPoint()
+		dp.ExplicitBounds().CopyTo(acc.ExplicitBounds())
+		acc.BucketCounts().FromRaw(make([]uint64, dp.BucketCounts().Len()))
+		s.acc = acc
+	}
+
+	histogramutils.MergeStats(acc, dp)
+	for i := 0; i < dp.BucketCounts().Len(); i++ {
+		acc.BucketCounts().SetAt(i, acc.BucketCounts().At(i)+dp.BucketCounts().At(i))
+	}
+
+	dp.SetStartTimestamp(s.start)
+	histogramutils.CopyStats(dp, acc)
+	acc.BucketCounts().CopyTo(dp.BucketCounts())
+}
+
+// accumulateExponentialHistogram adds the counts of dp to the stream, and sets dp to the cumulative counts.
+// When the scales differ, the buckets are merged to the lowest of them.
+func (s *stream) accumulateExponentialHistogram(dp pmetric.ExponentialHistogramDataPoint) {
+	acc, ok := s.acc.(pmetric.ExponentialHistogramDataPoint)
+	if !ok {
+		s.reset(dp.StartTimestamp(), dp.Timestamp())
+		acc = pmetric.NewExponentialHistogramDataPoint()
+		acc.SetScale(dp.Scale())
+		s.acc = acc
+	}
+
+	histogramutils.MergeExponentialHistograms(acc, dp)
+
+	dp.SetStartTimestamp(s.start)
+	dp.SetScale(acc.Scale())
+	dp.SetZeroCount(acc.ZeroCount())
+	histogramutils.CopyStats(dp, acc)
+	acc.Positive().CopyTo(dp.Positive())
+	acc.Negative().CopyTo(dp.Negative())
+}
@@ -230,18 +230,22 @@ func (em *eventMachine) consume(td ptrace.Traces) error {
 		return fmt.Errorf("eventmachine consume failed: %w", err)
 	}
 
+	em.workerForTraceID(traceID).fire(event{
+		typ:     traceReceived,
+		payload: tracesWithID{id: traceID, td: td},
+	})
+	return nil
+}
+
+// workerForTraceID returns the worker responsible for the given trace ID.
+func (em *eventMachine) workerForTraceID(traceID pcommon.TraceID) *eventMachineWorker {
 	var bucket uint64
 	if len(em.workers) != 1 {
 		bucket = workerIndexForTraceID(traceID, len(em.workers))
 	}
 
 	em.logger.Debug("scheduled trace to worker", zap.Uint64("id", bucket))
-
-	em.workers[bucket].fire(event{
-		typ:     traceReceived,
-		payload: tracesWithID{id: traceID, td: td},
-	})
-	return nil
+	return em.workers[bucket]
 }
 
 func workerIndexForTraceID(traceID pcommon.TraceID, numWorkers int) uint64 {
@@ -39,8 +39,7 @@ const (
 )
 
 var (
-	errDiskStorageNotSupported    = fmt.Errorf("option 'disk storage' not supported in this release")
-	errDiscardOrphansNotSupported = fmt.Errorf("option 'discard orphans' not supported in this release")
+	errDiskStorageWithoutExtension = fmt.Errorf("option 'disk storage' requires a storage extension to be configured")
 )
 
 // NewFactory returns a new factory for the Filter processor.
@@ -61,10 +60,8 @@ func createDefaultConfig() config.Processor {
 		NumTraces:         defaultNumTraces,
 		NumWorkers:        defaultNumWorkers,
 		WaitDuration:      defaultWaitDuration,
-
-		// not supported for now
-		DiscardOrphans: defaultDiscardOrphans,
-		StoreOnDisk:    defaultStoreOnDisk,
+		DiscardOrphans:    defaultDiscardOrphans,
+		StoreOnDisk:       defaultStoreOnDisk,
 	}
 }
 
@@ -79,14 +76,13 @@ func createTracesProcessor(
 
 	var st storage
 	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
-	}
-	if oCfg.DiscardOrphans {
-		return nil, errDiscardOrphansNotSupported
+		if oCfg.StorageID == nil {
+			return nil, errDiskStorageWithoutExtension
+		}
+		st = newDiskStorage(params.Logger, *oCfg.StorageID, oCfg.ID())
+	} else {
+		st = newMemoryStorage()
 	}
 
-	// the only supported storage for now
-	st = newMemoryStorage()
-
 	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg), nil
 }
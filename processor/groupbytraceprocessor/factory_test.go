@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 )
 
 func TestDefaultConfiguration(t *testing.T) {
@@ -47,10 +48,11 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
-func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
 	// prepare
 	f := NewFactory()
 	next := &mockProcessor{}
+	storageID := config.NewComponentID("file_storage")
 
 	// test
 	for _, tt := range []struct {
@@ -59,21 +61,29 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 	}{
 		{
 			&Config{
-				DiscardOrphans: true,
+				StoreOnDisk: true,
 			},
-			errDiscardOrphansNotSupported,
+			errDiskStorageWithoutExtension,
 		},
 		{
 			&Config{
-				StoreOnDisk: true,
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				StoreOnDisk:       true,
+				StorageID:         &storageID,
+				NumWorkers:        1,
 			},
-			errDiskStorageNotSupported,
+			nil,
 		},
 	} {
 		p, err := f.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), tt.config, next)
 
 		// verify
-		assert.Error(t, tt.expectedErr, err)
-		assert.Nil(t, p)
+		if tt.expectedErr != nil {
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, p)
+		} else {
+			assert.NoError(t, err)
+			assert.NotNil(t, p)
+		}
 	}
 }
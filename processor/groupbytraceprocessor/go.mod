@@ -3,6 +3,7 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/processor/group
 go 1.18
 
 require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.63.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal v0.63.0
 	github.com/stretchr/testify v1.8.1
 	go.opencensus.io v0.23.0
@@ -45,3 +46,5 @@ require (
 )
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal => ../../pkg/batchpersignal
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage => ../../extension/storage
@@ -93,14 +93,22 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
+	stats.Record(context.Background(), mOrphansDiscarded.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
+	// the workers aren't running yet, so it's safe to touch their buffers here
+	sp.restoreTraces()
+
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
@@ -146,16 +154,44 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
 	}
 
-	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
+	sp.scheduleRelease(traceID, worker, sp.config.WaitDuration)
+	return nil
+}
+
+// restoreTraces places the traces kept by the storage from a previous run back into the workers' buffers,
+// scheduling them to be released once the remainder of their wait duration has passed.
+func (sp *groupByTraceProcessor) restoreTraces() {
+	restored := sp.st.restored()
+	if len(restored) == 0 {
+		return
+	}
+	sp.logger.Info("restoring traces from the storage", zap.Int("traces", len(restored)))
+
+	for traceID, received := range restored {
+		worker := sp.eventMachine.workerForTraceID(traceID)
+
+		evicted := worker.buffer.put(traceID)
+		if !evicted.IsEmpty() {
+			if _, err := sp.st.delete(evicted); err != nil {
+				sp.logger.Warn("couldn't delete evicted trace from the storage", zap.String("traceID", evicted.HexString()), zap.Error(err))
+			}
+			stats.Record(context.Background(), mTracesEvicted.M(1))
+		}
+
+		sp.scheduleRelease(traceID, worker, time.Until(received.Add(sp.config.WaitDuration)))
+	}
+}
+
+func (sp *groupByTraceProcessor) scheduleRelease(traceID pcommon.TraceID, worker *eventMachineWorker, after time.Duration) {
+	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", after))
 
-	time.AfterFunc(sp.config.WaitDuration, func() {
+	time.AfterFunc(after, func() {
 		// if the event machine has stopped, it will just discard the event
 		worker.fire(event{
 			typ:     traceExpired,
 			payload: traceID,
 		})
 	})
-	return nil
 }
 
 func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker *eventMachineWorker) error {
@@ -196,6 +232,17 @@ func (sp *groupByTraceProcessor) markAsReleased(traceID pcommon.TraceID, fire fu
 		return fmt.Errorf("the trace %q couldn't be found at the storage", traceID)
 	}
 
+	if sp.config.DiscardOrphans && !hasRootSpan(trace) {
+		sp.logger.Debug("discarding trace without a root span", zap.String("traceID", traceID.HexString()))
+		stats.Record(context.Background(), mOrphansDiscarded.M(1))
+
+		fire(event{
+			typ:     traceRemoved,
+			payload: traceID,
+		})
+		return nil
+	}
+
 	// signal that the trace is ready to be released
 	sp.logger.Debug("trace marked as released", zap.String("traceID", traceID.HexString()))
 
@@ -248,3 +295,18 @@ func (sp *groupByTraceProcessor) addSpans(traceID pcommon.TraceID, trace ptrace.
 	sp.logger.Debug("creating trace at the storage", zap.String("traceID", traceID.HexString()))
 	return sp.st.createOrAppend(traceID, trace)
 }
+
+// hasRootSpan returns whether any of the spans in the trace lacks a parent span.
+func hasRootSpan(rss []ptrace.ResourceSpans) bool {
+	for _, rs := range rss {
+		for i := 0; i < rs.ScopeSpans().Len(); i++ {
+			spans := rs.ScopeSpans().At(i).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				if spans.At(j).ParentSpanID().IsEmpty() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
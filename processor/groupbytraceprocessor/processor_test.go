@@ -555,6 +555,108 @@ func TestAsyncOnRelease(t *testing.T) {
 	close(blockCh)
 }
 
+func TestDiscardOrphans(t *testing.T) {
+	// prepare
+	config := Config{
+		WaitDuration:   time.Nanosecond,
+		NumTraces:      10,
+		NumWorkers:     1,
+		DiscardOrphans: true,
+	}
+
+	rootID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	orphanID := pcommon.TraceID([16]byte{2, 3, 4, 5})
+	orphan := simpleTracesWithID(orphanID)
+	orphan.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetParentSpanID([8]byte{1, 2, 3, 4})
+
+	var received []pcommon.TraceID
+	mockProcessor := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			received = append(received, td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+			return nil
+		},
+	}
+
+	wgDeleted := &sync.WaitGroup{}
+	backing := newMemoryStorage()
+	st := &mockStorage{
+		onCreateOrAppend: backing.createOrAppend,
+		onGet:            backing.get,
+		onDelete: func(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+			defer wgDeleted.Done()
+			return backing.delete(traceID)
+		},
+	}
+
+	p := newGroupByTraceProcessor(zap.NewNop(), st, mockProcessor, config)
+	ctx := context.Background()
+	assert.NoError(t, p.Start(ctx, nil))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// test
+	wgDeleted.Add(2)
+	assert.NoError(t, p.ConsumeTraces(ctx, simpleTracesWithID(rootID)))
+	assert.NoError(t, p.ConsumeTraces(ctx, orphan))
+
+	// verify
+	wgDeleted.Wait()
+	assert.Eventually(t, func() bool {
+		mockProcessor.mutex.Lock()
+		defer mockProcessor.mutex.Unlock()
+		return len(received) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []pcommon.TraceID{rootID}, received)
+}
+
+func TestRestoredTracesAreReleased(t *testing.T) {
+	// prepare
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    1,
+		NumWorkers:   1,
+	}
+
+	expiredID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	backing := newMemoryStorage()
+	require.NoError(t, backing.createOrAppend(expiredID, simpleTracesWithID(expiredID)))
+
+	wgReceived := &sync.WaitGroup{}
+	mockProcessor := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			assert.Equal(t, simpleTracesWithID(expiredID), td)
+			wgReceived.Done()
+			return nil
+		},
+	}
+
+	st := &mockStorage{
+		onCreateOrAppend: backing.createOrAppend,
+		onGet:            backing.get,
+		onDelete:         backing.delete,
+		onRestored: func() map[pcommon.TraceID]time.Time {
+			// the trace was received long enough ago to be released right away
+			return map[pcommon.TraceID]time.Time{
+				expiredID: time.Now().Add(-2 * time.Hour),
+			}
+		},
+	}
+
+	p := newGroupByTraceProcessor(zap.NewNop(), st, mockProcessor, config)
+	ctx := context.Background()
+
+	// test
+	wgReceived.Add(1)
+	assert.NoError(t, p.Start(ctx, nil))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// verify
+	wgReceived.Wait()
+}
+
 func BenchmarkConsumeTracesCompleteOnFirstBatch(b *testing.B) {
 	// prepare
 	config := Config{
@@ -617,6 +719,7 @@ type mockStorage struct {
 	onGet            func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 	onDelete         func(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 	onStart          func() error
+	onRestored       func() map[pcommon.TraceID]time.Time
 	onShutdown       func() error
 }
 
@@ -640,12 +743,18 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) start(context.Context, component.Host) error {
 	if st.onStart != nil {
 		return st.onStart()
 	}
 	return nil
 }
+func (st *mockStorage) restored() map[pcommon.TraceID]time.Time {
+	if st.onRestored != nil {
+		return st.onRestored()
+	}
+	return nil
+}
 func (st *mockStorage) shutdown() error {
 	if st.onShutdown != nil {
 		return st.onShutdown()
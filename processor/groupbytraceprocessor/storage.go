@@ -15,6 +15,10 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -36,7 +40,11 @@ type storage interface {
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(ctx context.Context, host component.Host) error
+
+	// restored returns the traces that were kept by the storage from a previous run, along with
+	// the time their first spans were received. It's only meaningful after the storage has started.
+	restored() map[pcommon.TraceID]time.Time
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
@@ -0,0 +1,425 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	extstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// indexKey is the storage key holding the IDs of the traces kept by the storage
+	indexKey = "index"
+
+	// indexEntrySize is the size of a serialized index entry: trace ID, received time and number of chunks
+	indexEntrySize = 16 + 8 + 4
+
+	// journalStartKey is the storage key holding the sequence number of the first journal entry
+	// not covered by the index
+	journalStartKey = "journal_start"
+)
+
+var errCorruptedIndex = errors.New("the trace index in the storage is corrupted")
+
+// diskTrace is the in-memory record of a trace whose spans are kept by the storage extension.
+// Every batch appended to the trace is stored under its own key, called a chunk.
+type diskTrace struct {
+	received time.Time
+	chunks   uint32
+}
+
+// diskStorage keeps only the trace IDs and the time the traces were received in memory,
+// delegating the persistence of the spans to a storage extension. The index of traces
+// is periodically written to the storage as well, so that traces survive restarts.
+type diskStorage struct {
+	sync.RWMutex
+	traces map[pcommon.TraceID]*diskTrace
+	dirty  bool
+
+	// the journal records the IDs of the traces created since the index was last written, so that
+	// the chunks of traces that never made it to the index can be found and removed after a crash
+	journalStart uint64
+	journalNext  uint64
+
+	logger      *zap.Logger
+	storageID   config.ComponentID
+	componentID config.ComponentID
+	client      extstorage.Client
+
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+	checkpointInterval        time.Duration
+	lastCheckpoint            time.Time
+	restoredTraces            map[pcommon.TraceID]time.Time
+}
+
+var _ storage = (*diskStorage)(nil)
+
+func newDiskStorage(logger *zap.Logger, storageID config.ComponentID, componentID config.ComponentID) *diskStorage {
+	return &diskStorage{
+		traces:                    make(map[pcommon.TraceID]*diskTrace),
+		logger:                    logger,
+		storageID:                 storageID,
+		componentID:               componentID,
+		marshaler:                 ptrace.NewProtoMarshaler(),
+		unmarshaler:               ptrace.NewProtoUnmarshaler(),
+		metricsCollectionInterval: time.Second,
+		checkpointInterval:        5 * time.Second,
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	content, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+
+	st.Lock()
+	trace, ok := st.traces[traceID]
+	if !ok {
+		trace = &diskTrace{received: time.Now()}
+		st.traces[traceID] = trace
+	}
+	chunk := trace.chunks
+	trace.chunks++
+	st.dirty = true
+	journalSeq := st.journalNext
+	if !ok {
+		st.journalNext++
+	}
+	st.Unlock()
+
+	if ok {
+		return st.client.Set(context.Background(), chunkKey(traceID, chunk), content)
+	}
+	return st.client.Batch(context.Background(),
+		extstorage.SetOperation(chunkKey(traceID, chunk), content),
+		extstorage.SetOperation(journalKey(journalSeq), traceID[:]),
+	)
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	ops := st.chunkOperations(traceID, extstorage.GetOperation)
+	if ops == nil {
+		return nil, nil
+	}
+
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+	return st.unmarshalChunks(ops)
+}
+
+// delete will read the trace's chunks before removing them from the storage, within the same batch.
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	gets := st.chunkOperations(traceID, extstorage.GetOperation)
+	if gets == nil {
+		return nil, nil
+	}
+	deletes := st.chunkOperations(traceID, extstorage.DeleteOperation)
+
+	st.Lock()
+	delete(st.traces, traceID)
+	st.dirty = true
+	st.Unlock()
+
+	if err := st.client.Batch(context.Background(), append(gets, deletes...)...); err != nil {
+		return nil, err
+	}
+	return st.unmarshalChunks(gets)
+}
+
+// chunkOperations builds one operation for each of the chunks of the given trace,
+// returning nil in case the trace isn't known by the storage.
+func (st *diskStorage) chunkOperations(traceID pcommon.TraceID, op func(string) extstorage.Operation) []extstorage.Operation {
+	st.RLock()
+	trace, ok := st.traces[traceID]
+	if !ok {
+		st.RUnlock()
+		return nil
+	}
+	chunks := trace.chunks
+	st.RUnlock()
+
+	ops := make([]extstorage.Operation, chunks)
+	for i := range ops {
+		ops[i] = op(chunkKey(traceID, uint32(i)))
+	}
+	return ops
+}
+
+func (st *diskStorage) unmarshalChunks(ops []extstorage.Operation) ([]ptrace.ResourceSpans, error) {
+	result := []ptrace.ResourceSpans{}
+	for _, op := range ops {
+		if op.Value == nil {
+			// the chunk was written after the last checkpoint of a previous run that didn't shut down properly
+			continue
+		}
+
+		td, err := st.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal the chunk %q: %w", op.Key, err)
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			rs := ptrace.NewResourceSpans()
+			td.ResourceSpans().At(i).MoveTo(rs)
+			result = append(result, rs)
+		}
+	}
+	return result, nil
+}
+
+func (st *diskStorage) start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", st.storageID)
+	}
+	storageExt, ok := ext.(extstorage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", st.storageID)
+	}
+
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, st.componentID, "")
+	if err != nil {
+		return fmt.Errorf("couldn't get a client for the storage extension '%s': %w", st.storageID, err)
+	}
+	st.client = client
+
+	if err := st.loadIndex(ctx); err != nil {
+		return err
+	}
+
+	st.lastCheckpoint = time.Now()
+	go st.periodicMetrics()
+	return nil
+}
+
+func (st *diskStorage) restored() map[pcommon.TraceID]time.Time {
+	return st.restoredTraces
+}
+
+func (st *diskStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	defer st.stoppedLock.Unlock()
+	if st.stopped || st.client == nil {
+		return nil
+	}
+	st.stopped = true
+
+	ctx := context.Background()
+	err := st.checkpoint(ctx)
+	if closeErr := st.client.Close(ctx); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// loadIndex reads the index of traces written by a previous run, probing the storage for
+// chunks that might have been appended to each trace after the index was last written.
+func (st *diskStorage) loadIndex(ctx context.Context) error {
+	content, err := st.client.Get(ctx, indexKey)
+	if err != nil {
+		return fmt.Errorf("couldn't read the trace index from the storage: %w", err)
+	}
+	if len(content)%indexEntrySize != 0 {
+		return errCorruptedIndex
+	}
+
+	st.restoredTraces = make(map[pcommon.TraceID]time.Time, len(content)/indexEntrySize)
+	for i := 0; i < len(content); i += indexEntrySize {
+		entry := content[i : i+indexEntrySize]
+
+		var traceID pcommon.TraceID
+		copy(traceID[:], entry[:16])
+		trace := &diskTrace{
+			received: time.Unix(0, int64(binary.BigEndian.Uint64(entry[16:24]))),
+			chunks:   binary.BigEndian.Uint32(entry[24:]),
+		}
+
+		for {
+			chunk, err := st.client.Get(ctx, chunkKey(traceID, trace.chunks))
+			if err != nil {
+				return fmt.Errorf("couldn't read the trace %q from the storage: %w", traceID.HexString(), err)
+			}
+			if chunk == nil {
+				break
+			}
+			trace.chunks++
+		}
+
+		st.traces[traceID] = trace
+		st.restoredTraces[traceID] = trace.received
+	}
+
+	return st.removeOrphanedChunks(ctx)
+}
+
+// removeOrphanedChunks deletes the chunks of the traces recorded in the journal that aren't part of the
+// index, which are the traces created after the index was last written by a run that didn't shut down properly.
+func (st *diskStorage) removeOrphanedChunks(ctx context.Context) error {
+	content, err := st.client.Get(ctx, journalStartKey)
+	if err != nil {
+		return fmt.Errorf("couldn't read the trace journal from the storage: %w", err)
+	}
+	if content != nil && len(content) != 8 {
+		return errCorruptedIndex
+	}
+	if content != nil {
+		st.journalStart = binary.BigEndian.Uint64(content)
+	}
+	st.journalNext = st.journalStart
+
+	var deletes []extstorage.Operation
+	orphaned := 0
+	for seq := st.journalStart; ; seq++ {
+		entry, err := st.client.Get(ctx, journalKey(seq))
+		if err != nil {
+			return fmt.Errorf("couldn't read the trace journal from the storage: %w", err)
+		}
+		if entry == nil {
+			break
+		}
+		if len(entry) != 16 {
+			return errCorruptedIndex
+		}
+		deletes = append(deletes, extstorage.DeleteOperation(journalKey(seq)))
+
+		var traceID pcommon.TraceID
+		copy(traceID[:], entry)
+		if _, ok := st.traces[traceID]; ok {
+			continue
+		}
+
+		orphaned++
+		for chunk := uint32(0); ; chunk++ {
+			value, err := st.client.Get(ctx, chunkKey(traceID, chunk))
+			if err != nil {
+				return fmt.Errorf("couldn't read the trace %q from the storage: %w", traceID.HexString(), err)
+			}
+			if value == nil {
+				break
+			}
+			deletes = append(deletes, extstorage.DeleteOperation(chunkKey(traceID, chunk)))
+		}
+	}
+
+	if len(deletes) == 0 {
+		return nil
+	}
+	if err := st.client.Batch(ctx, deletes...); err != nil {
+		return fmt.Errorf("couldn't remove the orphaned chunks from the storage: %w", err)
+	}
+	if orphaned > 0 {
+		st.logger.Info("removed the chunks of traces missing from the trace index", zap.Int("traces", orphaned))
+	}
+	return nil
+}
+
+// checkpoint writes the index of traces to the storage, in case it changed since the last checkpoint.
+func (st *diskStorage) checkpoint(ctx context.Context) error {
+	st.Lock()
+	if !st.dirty {
+		st.Unlock()
+		return nil
+	}
+	content := make([]byte, len(st.traces)*indexEntrySize)
+	entry := content
+	for traceID, trace := range st.traces {
+		copy(entry, traceID[:])
+		binary.BigEndian.PutUint64(entry[16:], uint64(trace.received.UnixNano()))
+		binary.BigEndian.PutUint32(entry[24:], trace.chunks)
+		entry = entry[indexEntrySize:]
+	}
+	st.dirty = false
+	journalStart, journalEnd := st.journalStart, st.journalNext
+	st.Unlock()
+
+	// the journal entries of the traces created before this index was built aren't needed anymore
+	journal := make([]byte, 8)
+	binary.BigEndian.PutUint64(journal, journalEnd)
+	ops := []extstorage.Operation{
+		extstorage.SetOperation(indexKey, content),
+		extstorage.SetOperation(journalStartKey, journal),
+	}
+	for seq := journalStart; seq < journalEnd; seq++ {
+		ops = append(ops, extstorage.DeleteOperation(journalKey(seq)))
+	}
+
+	if err := st.client.Batch(ctx, ops...); err != nil {
+		st.Lock()
+		st.dirty = true
+		st.Unlock()
+		return fmt.Errorf("couldn't write the trace index to the storage: %w", err)
+	}
+
+	st.Lock()
+	if journalEnd > st.journalStart {
+		st.journalStart = journalEnd
+	}
+	st.Unlock()
+	return nil
+}
+
+func (st *diskStorage) periodicMetrics() {
+	numTraces := st.count()
+	stats.Record(context.Background(), mNumTracesInMemory.M(int64(numTraces)))
+
+	st.stoppedLock.RLock()
+	defer st.stoppedLock.RUnlock()
+	if st.stopped {
+		return
+	}
+
+	if time.Since(st.lastCheckpoint) >= st.checkpointInterval {
+		if err := st.checkpoint(context.Background()); err != nil {
+			st.logger.Warn("failed to checkpoint the trace index", zap.Error(err))
+		}
+		st.lastCheckpoint = time.Now()
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func (st *diskStorage) count() int {
+	st.RLock()
+	defer st.RUnlock()
+	return len(st.traces)
+}
+
+func chunkKey(traceID pcommon.TraceID, chunk uint32) string {
+	return fmt.Sprintf("%s/%d", traceID.HexString(), chunk)
+}
+
+func journalKey(seq uint64) string {
+	return fmt.Sprintf("journal/%d", seq)
+}
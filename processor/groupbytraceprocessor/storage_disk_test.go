@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newStartedDiskStorage(t *testing.T, host *storagetest.StorageHost, storageID config.ComponentID) *diskStorage {
+	st := newDiskStorage(zap.NewNop(), storageID, config.NewComponentID(typeStr))
+	require.NoError(t, st.start(context.Background(), host))
+	return st
+}
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	st := newStartedDiskStorage(t, host, storagetest.NewStorageID("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	// test
+	for _, traceID := range traceIDs {
+		assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		expected := []ptrace.ResourceSpans{simpleTracesWithID(traceID).ResourceSpans().At(0)}
+
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, retrieved)
+	}
+
+	retrieved, err := st.get(pcommon.TraceID([16]byte{9}))
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	st := newStartedDiskStorage(t, host, storagetest.NewStorageID("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+	assert.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, deleted)
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+	assert.Equal(t, 0, st.count())
+}
+
+func TestDiskAppendSpans(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	st := newStartedDiskStorage(t, host, storagetest.NewStorageID("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("second-name")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{
+		first.ResourceSpans().At(0),
+		second.ResourceSpans().At(0),
+	}, retrieved)
+}
+
+func TestDiskRestoresTracesAfterRestart(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	removedID := pcommon.TraceID([16]byte{2, 3, 4, 5})
+
+	st := newStartedDiskStorage(t, host, storageID)
+	assert.Empty(t, st.restored())
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.createOrAppend(removedID, simpleTracesWithID(removedID)))
+	_, err := st.delete(removedID)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newStartedDiskStorage(t, host, storageID)
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	// verify
+	restored := st.restored()
+	require.Len(t, restored, 1)
+	assert.WithinDuration(t, time.Now(), restored[traceID], time.Minute)
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{simpleTracesWithID(traceID).ResourceSpans().At(0)}, retrieved)
+
+	// appending to a restored trace continues after its existing chunks
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	retrieved, err = st.get(traceID)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 2)
+}
+
+func TestDiskRestoresChunksWrittenAfterCheckpoint(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	st := newStartedDiskStorage(t, host, storageID)
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.checkpoint(context.Background()))
+
+	// simulate a chunk written after the last checkpoint, followed by an unclean shutdown
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	st.dirty = false
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newStartedDiskStorage(t, host, storageID)
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 2)
+}
+
+func TestDiskRemovesOrphanedChunks(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+	indexedID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	orphanedID := pcommon.TraceID([16]byte{2, 3, 4, 5})
+
+	st := newStartedDiskStorage(t, host, storageID)
+	require.NoError(t, st.createOrAppend(indexedID, simpleTracesWithID(indexedID)))
+	require.NoError(t, st.checkpoint(context.Background()))
+
+	// simulate a trace created after the last checkpoint, followed by an unclean shutdown
+	require.NoError(t, st.createOrAppend(orphanedID, simpleTracesWithID(orphanedID)))
+	require.NoError(t, st.createOrAppend(orphanedID, simpleTracesWithID(orphanedID)))
+	st.dirty = false
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newStartedDiskStorage(t, host, storageID)
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	// verify
+	restored := st.restored()
+	require.Len(t, restored, 1)
+	assert.Contains(t, restored, indexedID)
+
+	for _, key := range []string{chunkKey(orphanedID, 0), chunkKey(orphanedID, 1), journalKey(1)} {
+		value, err := st.client.Get(context.Background(), key)
+		require.NoError(t, err)
+		assert.Nil(t, value, key)
+	}
+
+	retrieved, err := st.get(indexedID)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 1)
+}
+
+func TestDiskStorageExtensionErrors(t *testing.T) {
+	host := storagetest.NewStorageHost().WithNonStorageExtension("non")
+
+	st := newDiskStorage(zap.NewNop(), storagetest.NewStorageID("missing"), config.NewComponentID(typeStr))
+	assert.Error(t, st.start(context.Background(), host))
+
+	st = newDiskStorage(zap.NewNop(), storagetest.NewNonStorageID("non"), config.NewComponentID(typeStr))
+	assert.Error(t, st.start(context.Background(), host))
+	assert.NoError(t, st.shutdown())
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -85,11 +86,16 @@ func (st *memoryStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans
 	return st.content[traceID], nil
 }
 
-func (st *memoryStorage) start() error {
+func (st *memoryStorage) start(context.Context, component.Host) error {
 	go st.periodicMetrics()
 	return nil
 }
 
+// restored returns nil, as traces kept in memory don't survive restarts.
+func (st *memoryStorage) restored() map[pcommon.TraceID]time.Time {
+	return nil
+}
+
 func (st *memoryStorage) shutdown() error {
 	st.stoppedLock.Lock()
 	defer st.stoppedLock.Unlock()
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalaggregationprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/histogramutils"
+)
+
+// copyPoint returns a copy of the values and timestamps of a point, without its attributes and exemplars.
+func copyPoint(dp interface{}) interface{} {
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		acc := pmetric.NewNumberDataPoint()
+		acc.SetStartTimestamp(dp.StartTimestamp())
+		acc.SetTimestamp(dp.Timestamp())
+		setNumber(acc, dp)
+		return acc
+	case pmetric.HistogramDataPoint:
+		acc := pmetric.NewHistogramDataPoint()
+		dp.CopyTo(acc)
+		acc.Attributes().Clear()
+		acc.Exemplars().RemoveIf(func(pmetric.Exemplar) bool { return true })
+		return acc
+	case pmetric.ExponentialHistogramDataPoint:
+		acc := pmetric.NewExponentialHistogramDataPoint()
+		dp.CopyTo(acc)
+		acc.Attributes().Clear()
+		acc.Exemplars().RemoveIf(func(pmetric.Exemplar) bool { return true })
+		return acc
+	}
+	return nil
+}
+
+// appendPoint appends a copy of a buffered point to the data points of m, and returns it.
+func appendPoint(m pmetric.Metric, point interface{}) interface{} {
+	switch point := point.(type) {
+	case pmetric.NumberDataPoint:
+		var dp pmetric.NumberDataPoint
+		if m.Type() == pmetric.MetricTypeGauge {
+			dp = m.Gauge().DataPoints().AppendEmpty()
+		} else {
+			dp = m.Sum().DataPoints().AppendEmpty()
+		}
+		point.CopyTo(dp)
+		return dp
+	case pmetric.HistogramDataPoint:
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		point.CopyTo(dp)
+		return dp
+	case pmetric.ExponentialHistogramDataPoint:
+		dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		point.CopyTo(dp)
+		return dp
+	}
+	return nil
+}
+
+// reduceOverTime reduces a new point of a stream into its buffered point acc. Delta points are added
+// together, while only the latest cumulative point is kept. Gauges keep their minimum or maximum value
+// for the min and max aggregations, and their latest value otherwise. It returns false if dp could not
+// be reduced into acc.
+func reduceOverTime(metricType pmetric.MetricType, isDelta bool, aggregationType AggregationType, acc, dp interface{}) bool {
+	if metricType == pmetric.MetricTypeGauge && (aggregationType == Min || aggregationType == Max) {
+		return reduceNumbers(aggregationType, acc.(pmetric.NumberDataPoint), dp.(pmetric.NumberDataPoint))
+	}
+	if metricType == pmetric.MetricTypeGauge || !isDelta {
+		keepLatest(acc, dp)
+		return true
+	}
+	return merge(acc, dp)
+}
+
+// reduceAcrossStreams reduces the point of a stream into the point of its series acc. The values of
+// sums and gauges are reduced according to the aggregation type, while histograms are merged.
+// It returns false if dp could not be reduced into acc.
+func reduceAcrossStreams(metricType pmetric.MetricType, aggregationType AggregationType, acc, dp interface{}) bool {
+	if metricType == pmetric.MetricTypeGauge || metricType == pmetric.MetricTypeSum {
+		return reduceNumbers(aggregationType, acc.(pmetric.NumberDataPoint), dp.(pmetric.NumberDataPoint))
+	}
+	return merge(acc, dp)
+}
+
+// setPointIdentity sets the attributes and timestamps of an aggregated point. Delta points cover the
+// interval, cumulative points keep the earliest start time of their streams, and gauges have none.
+func setPointIdentity(dp interface{}, attributes pcommon.Map, metricType pmetric.MetricType, isDelta bool, start, end pcommon.Timestamp) {
+	if p, ok := dp.(interface {
+		Attributes() pcommon.Map
+		SetStartTimestamp(pcommon.Timestamp)
+		SetTimestamp(pcommon.Timestamp)
+	}); ok {
+		attributes.CopyTo(p.Attributes())
+		p.SetTimestamp(end)
+		switch {
+		case metricType == pmetric.MetricTypeGauge:
+			p.SetStartTimestamp(0)
+		case isDelta:
+			p.SetStartTimestamp(start)
+		}
+	}
+}
+
+func reduceNumbers(aggregationType AggregationType, acc, dp pmetric.NumberDataPoint) bool {
+	switch aggregationType {
+	case Sum:
+		if acc.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			acc.SetIntValue(acc.IntValue() + dp.IntValue())
+		} else {
+			acc.SetDoubleValue(doubleValue(acc) + doubleValue(dp))
+		}
+		mergeTimestamps(acc, dp)
+	case Min:
+		if doubleValue(dp) < doubleValue(acc) {
+			setNumber(acc, dp)
+		}
+		mergeTimestamps(acc, dp)
+	case Max:
+		if doubleValue(dp) > doubleValue(acc) {
+			setNumber(acc, dp)
+		}
+		mergeTimestamps(acc, dp)
+	case Last:
+		keepLatest(acc, dp)
+	}
+	return true
+}
+
+// keepLatest replaces acc with dp if dp is more recent.
+func keepLatest(acc, dp interface{}) {
+	switch acc := acc.(type) {
+	case pmetric.NumberDataPoint:
+		dp := dp.(pmetric.NumberDataPoint)
+		if dp.Timestamp() >= acc.Timestamp() {
+			acc.SetStartTimestamp(dp.StartTimestamp())
+			acc.SetTimestamp(dp.Timestamp())
+			setNumber(acc, dp)
+		}
+	case pmetric.HistogramDataPoint:
+		dp := dp.(pmetric.HistogramDataPoint)
+		if dp.Timestamp() >= acc.Timestamp() {
+			copyPoint(dp).(pmetric.HistogramDataPoint).MoveTo(acc)
+		}
+	case pmetric.ExponentialHistogramDataPoint:
+		dp := dp.(pmetric.ExponentialHistogramDataPoint)
+		if dp.Timestamp() >= acc.Timestamp() {
+			copyPoint(dp).(pmetric.ExponentialHistogramDataPoint).MoveTo(acc)
+		}
+	}
+}
+
+// merge adds the counts of the histogram dp to acc. It returns false if their bucket boundaries differ.
+func merge(acc, dp interface{}) bool {
+	switch acc := acc.(type) {
+	case pmetric.NumberDataPoint:
+		return reduceNumbers(Sum, acc, dp.(pmetric.NumberDataPoint))
+	case pmetric.HistogramDataPoint:
+		return mergeHistograms(acc, dp.(pmetric.HistogramDataPoint))
+	case pmetric.ExponentialHistogramDataPoint:
+		dp := dp.(pmetric.ExponentialHistogramDataPoint)
+		histogramutils.MergeExponentialHistograms(acc, dp)
+		mergeTimestamps(acc, dp)
+	}
+	return true
+}
+
+func mergeHistograms(acc, dp pmetric.HistogramDataPoint) bool {
+	if !histogramutils.EqualBounds(acc.ExplicitBounds(), dp.ExplicitBounds()) || acc.BucketCounts().Len() != dp.BucketCounts().Len() {
+		return false
+	}
+
+	histogramutils.MergeStats(acc, dp)
+	for i := 0; i < dp.BucketCounts().Len(); i++ {
+		acc.BucketCounts().SetAt(i, acc.BucketCounts().At(i)+dp.BucketCounts().At(i))
+	}
+	mergeTimestamps(acc, dp)
+	return true
+}
+
+// mergeTimestamps extends the time range of acc to cover the one of dp.
+func mergeTimestamps(acc, dp interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}) {
+	if dp.StartTimestamp() < acc.StartTimestamp() {
+		acc.SetStartTimestamp(dp.StartTimestamp())
+	}
+	if dp.Timestamp() > acc.Timestamp() {
+		acc.SetTimestamp(dp.Timestamp())
+	}
+}
+
+func setNumber(dest, src pmetric.NumberDataPoint) {
+	switch src.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		dest.SetIntValue(src.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		dest.SetDoubleValue(src.DoubleValue())
+	}
+}
+
+func doubleValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
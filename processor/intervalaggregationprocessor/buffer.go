@@ -0,0 +1,329 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalaggregationprocessor"
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// sep separates the fields of the keys identifying resources, scopes, metrics and streams.
+const sep = byte(0x1E)
+
+// buffer holds the points received during an interval, reduced to one point per stream.
+type buffer struct {
+	logger *zap.Logger
+	// md holds the resources, scopes and metrics of the buffered points, to which the aggregated points are added.
+	md        pmetric.Metrics
+	resources map[string]*resourceEntry
+	metrics   []*metricEntry
+	b         bytes.Buffer
+}
+
+type resourceEntry struct {
+	rm     pmetric.ResourceMetrics
+	scopes map[string]*scopeEntry
+}
+
+type scopeEntry struct {
+	sm      pmetric.ScopeMetrics
+	metrics map[string]*metricEntry
+}
+
+// metricEntry holds the series of a metric.
+type metricEntry struct {
+	resource    pcommon.Resource
+	scope       pcommon.InstrumentationScope
+	metric      pmetric.Metric
+	aggregation *aggregation
+	series      map[string]*series
+	seriesOrder []*series
+	isDelta     bool
+}
+
+// series holds the points of the streams aggregated into a single series, reduced to one point per stream.
+type series struct {
+	key        string
+	attributes pcommon.Map
+	// streams holds the aggregated streams, by the key of their attributes.
+	streams map[string]*stream
+}
+
+// stream holds the point of a stream reduced over the interval.
+type stream struct {
+	// point is a pmetric.NumberDataPoint, pmetric.HistogramDataPoint or pmetric.ExponentialHistogramDataPoint.
+	point interface{}
+	// seen is whether a point of the stream was received during the current interval.
+	seen bool
+	// lastSeen is the end of the last interval during which a point of the stream was received.
+	lastSeen time.Time
+}
+
+func newBuffer(logger *zap.Logger) *buffer {
+	return &buffer{
+		logger:    logger,
+		md:        pmetric.NewMetrics(),
+		resources: make(map[string]*resourceEntry),
+	}
+}
+
+// metricEntry returns the entry holding the series of a metric, creating it if needed.
+func (buf *buffer) metricEntry(resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, agg *aggregation) *metricEntry {
+	buf.b.Reset()
+	writeAttributes(&buf.b, resource.Attributes())
+	re, ok := buf.resources[buf.b.String()]
+	if !ok {
+		re = &resourceEntry{
+			rm:     buf.md.ResourceMetrics().AppendEmpty(),
+			scopes: make(map[string]*scopeEntry),
+		}
+		resource.CopyTo(re.rm.Resource())
+		buf.resources[buf.b.String()] = re
+	}
+
+	buf.b.Reset()
+	buf.b.WriteString(scope.Name())
+	buf.b.WriteByte(sep)
+	buf.b.WriteString(scope.Version())
+	se, ok := re.scopes[buf.b.String()]
+	if !ok {
+		se = &scopeEntry{
+			sm:      re.rm.ScopeMetrics().AppendEmpty(),
+			metrics: make(map[string]*metricEntry),
+		}
+		scope.CopyTo(se.sm.Scope())
+		re.scopes[buf.b.String()] = se
+	}
+
+	buf.b.Reset()
+	buf.b.WriteString(strconv.Itoa(int(m.Type())))
+	buf.b.WriteByte(sep)
+	buf.b.WriteString(m.Name())
+	buf.b.WriteByte(sep)
+	buf.b.WriteString(m.Unit())
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		buf.b.WriteByte(sep)
+		buf.b.WriteString(strconv.Itoa(int(m.Sum().AggregationTemporality())))
+		buf.b.WriteString(strconv.FormatBool(m.Sum().IsMonotonic()))
+	case pmetric.MetricTypeHistogram:
+		buf.b.WriteByte(sep)
+		buf.b.WriteString(strconv.Itoa(int(m.Histogram().AggregationTemporality())))
+	case pmetric.MetricTypeExponentialHistogram:
+		buf.b.WriteByte(sep)
+		buf.b.WriteString(strconv.Itoa(int(m.ExponentialHistogram().AggregationTemporality())))
+	}
+	me, ok := se.metrics[buf.b.String()]
+	if !ok {
+		me = &metricEntry{
+			resource:    re.rm.Resource(),
+			scope:       se.sm.Scope(),
+			metric:      se.sm.Metrics().AppendEmpty(),
+			aggregation: agg,
+			series:      make(map[string]*series),
+		}
+		copyMetricMetadata(m, me.metric)
+		switch m.Type() {
+		case pmetric.MetricTypeSum:
+			me.isDelta = m.Sum().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		case pmetric.MetricTypeHistogram:
+			me.isDelta = m.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		case pmetric.MetricTypeExponentialHistogram:
+			me.isDelta = m.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+		}
+		se.metrics[buf.b.String()] = me
+		buf.metrics = append(buf.metrics, me)
+	}
+	return me
+}
+
+// add reduces the points of m into the buffer, aggregated by the label set of agg.
+func (buf *buffer) add(resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, agg *aggregation) {
+	me := buf.metricEntry(resource, scope, m, agg)
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			buf.addPoint(me, dps.At(i), dps.At(i).Attributes(), dps.At(i).Flags())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			buf.addPoint(me, dps.At(i), dps.At(i).Attributes(), dps.At(i).Flags())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			buf.addPoint(me, dps.At(i), dps.At(i).Attributes(), dps.At(i).Flags())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			buf.addPoint(me, dps.At(i), dps.At(i).Attributes(), dps.At(i).Flags())
+		}
+	}
+}
+
+// addPoint reduces a point into the point buffered for its stream. Points without recorded value are ignored.
+func (buf *buffer) addPoint(me *metricEntry, dp interface{}, attributes pcommon.Map, flags pmetric.DataPointFlags) {
+	if flags.NoRecordedValue() {
+		return
+	}
+
+	buf.b.Reset()
+	me.aggregation.writeLabelSet(&buf.b, attributes)
+	s := me.seriesOf(buf.b.String(), attributes)
+
+	buf.b.Reset()
+	writeAttributes(&buf.b, attributes)
+	st, ok := s.streams[buf.b.String()]
+	if !ok {
+		s.streams[buf.b.String()] = &stream{point: copyPoint(dp), seen: true}
+		return
+	}
+	st.seen = true
+	if !reduceOverTime(me.metric.Type(), me.isDelta, me.aggregation.aggregationType, st.point, dp) {
+		buf.logger.Debug("dropping histogram point with different bucket boundaries than its stream",
+			zap.String("metric", me.metric.Name()))
+	}
+}
+
+// seriesOf returns the series identified by key, creating it with the kept attributes if needed.
+func (me *metricEntry) seriesOf(key string, attributes pcommon.Map) *series {
+	s, ok := me.series[key]
+	if !ok {
+		s = &series{
+			key:        key,
+			attributes: pcommon.NewMap(),
+			streams:    make(map[string]*stream),
+		}
+		me.aggregation.copyLabelSet(attributes, s.attributes)
+		me.series[key] = s
+		me.seriesOrder = append(me.seriesOrder, s)
+	}
+	return s
+}
+
+// retain returns the buffer of the interval following the one ending at end. It holds the latest points of the
+// cumulative streams of buf, so that a stream which skips an interval is still part of the value of its series.
+// The streams without points since staleBefore are removed from buf, so that they are not emitted anymore.
+// Delta points and gauges are not retained, as they only cover the interval during which they were received.
+func (buf *buffer) retain(end, staleBefore time.Time) *buffer {
+	next := newBuffer(buf.logger)
+	for _, me := range buf.metrics {
+		if me.isDelta || me.metric.Type() == pmetric.MetricTypeGauge {
+			continue
+		}
+		var nextMe *metricEntry
+		for _, s := range me.seriesOrder {
+			var nextSeries *series
+			for key, st := range s.streams {
+				if st.seen {
+					st.seen = false
+					st.lastSeen = end
+				}
+				if st.lastSeen.Before(staleBefore) {
+					delete(s.streams, key)
+					continue
+				}
+				if nextMe == nil {
+					nextMe = next.metricEntry(me.resource, me.scope, me.metric, me.aggregation)
+				}
+				if nextSeries == nil {
+					nextSeries = nextMe.seriesOf(s.key, s.attributes)
+				}
+				nextSeries.streams[key] = st
+			}
+		}
+	}
+	return next
+}
+
+// emit returns the aggregated points of the interval that started at start, with one point per series
+// timestamped at end.
+func (buf *buffer) emit(start, end pcommon.Timestamp) pmetric.Metrics {
+	for _, me := range buf.metrics {
+		for _, s := range me.seriesOrder {
+			var dp interface{}
+			for _, st := range s.streams {
+				if dp == nil {
+					dp = appendPoint(me.metric, st.point)
+				} else if !reduceAcrossStreams(me.metric.Type(), me.aggregation.aggregationType, dp, st.point) {
+					buf.logger.Debug("dropping histogram stream with different bucket boundaries than its series",
+						zap.String("metric", me.metric.Name()))
+				}
+			}
+			setPointIdentity(dp, s.attributes, me.metric.Type(), me.isDelta, start, end)
+		}
+	}
+
+	// metrics whose points all lacked a recorded value have no series
+	buf.md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return dataPointCount(m) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return buf.md
+}
+
+func copyMetricMetadata(src, dest pmetric.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		dest.SetEmptySum().SetAggregationTemporality(src.Sum().AggregationTemporality())
+		dest.Sum().SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	}
+}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	}
+	return 0
+}
+
+func writeAttributes(b *bytes.Buffer, attributes pcommon.Map) {
+	attributes.Sort().Range(func(k string, v pcommon.Value) bool {
+		b.WriteByte(sep)
+		b.WriteString(k)
+		b.WriteByte(':')
+		b.WriteString(v.AsString())
+		return true
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalaggregationprocessor"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Interval is how often the aggregated points are emitted.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MaxStaleness is how long the latest point of a cumulative stream keeps being aggregated into its series
+	// past the end of the last interval during which it was received. Set to 0 to keep the streams indefinitely.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+
+	// Aggregations lists the metrics to aggregate, and how to aggregate them. A metric is aggregated
+	// according to the first matching entry. Metrics matching no entry are forwarded unchanged.
+	Aggregations []AggregationConfig `mapstructure:"aggregations"`
+}
+
+// AggregationConfig defines how matching metrics are aggregated.
+type AggregationConfig struct {
+	filterset.Config `mapstructure:",squash"`
+
+	// Metrics is the list of metric names or patterns to aggregate.
+	Metrics []string `mapstructure:"metrics"`
+
+	// LabelSet is the list of attributes to keep. The points of all the streams which only differ by
+	// other attributes are aggregated into a single series.
+	LabelSet []string `mapstructure:"label_set"`
+
+	// AggregationType is how the values of sums and gauges are aggregated. Histograms are always merged.
+	AggregationType AggregationType `mapstructure:"aggregation_type"`
+}
+
+// AggregationType is the enum to capture the types of aggregation of the values of sums and gauges.
+type AggregationType string
+
+const (
+	// Sum indicates taking the sum of the aggregated data.
+	Sum AggregationType = "sum"
+
+	// Min indicates taking the minimum of the aggregated data.
+	Min AggregationType = "min"
+
+	// Max indicates taking the max of the aggregated data.
+	Max AggregationType = "max"
+
+	// Last indicates taking the most recent of the aggregated data.
+	Last AggregationType = "last"
+)
+
+var aggregationTypes = []AggregationType{Sum, Min, Max, Last}
+
+func (at AggregationType) isValid() bool {
+	for _, aggregationType := range aggregationTypes {
+		if at == aggregationType {
+			return true
+		}
+	}
+
+	return false
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+func (config *Config) Validate() error {
+	if config.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if config.MaxStaleness < 0 {
+		return fmt.Errorf("max_staleness must not be negative")
+	}
+	for i, aggregation := range config.Aggregations {
+		if len(aggregation.Metrics) == 0 {
+			return fmt.Errorf("aggregation %v: metrics must be supplied", i+1)
+		}
+		if len(aggregation.MatchType) == 0 {
+			return fmt.Errorf("aggregation %v: match_type must be set", i+1)
+		}
+		if _, err := filterset.CreateFilterSet(aggregation.Metrics, &aggregation.Config); err != nil {
+			return fmt.Errorf("aggregation %v: %w", i+1, err)
+		}
+		if aggregation.AggregationType != "" && !aggregation.AggregationType.isValid() {
+			return fmt.Errorf("aggregation %v: %q must be in %q", i+1, "aggregation_type", aggregationTypes)
+		}
+	}
+	return nil
+}
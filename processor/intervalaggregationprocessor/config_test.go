@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalaggregationprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           config.ComponentID
+		expected     config.Processor
+		errorMessage string
+	}{
+		{
+			id: config.NewComponentIDWithName(typeStr, ""),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Interval:          30 * time.Second,
+				MaxStaleness:      10 * time.Minute,
+				Aggregations: []AggregationConfig{
+					{
+						Config:   filterset.Config{MatchType: "strict"},
+						Metrics:  []string{"http.server.duration"},
+						LabelSet: []string{"http.method", "http.status_code"},
+					},
+					{
+						Config:          filterset.Config{MatchType: "regexp"},
+						Metrics:         []string{`^system\.memory\..*`},
+						LabelSet:        []string{"state"},
+						AggregationType: Max,
+					},
+				},
+			},
+		},
+		{
+			id:       config.NewComponentIDWithName(typeStr, "empty"),
+			expected: createDefaultConfig(),
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "zero_interval"),
+			errorMessage: "interval must be positive",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "negative_max_staleness"),
+			errorMessage: "max_staleness must not be negative",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "missing_metrics"),
+			errorMessage: "aggregation 1: metrics must be supplied",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "missing_match_type"),
+			errorMessage: "aggregation 1: match_type must be set",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "invalid_aggregation_type"),
+			errorMessage: `aggregation 1: "aggregation_type" must be in ["sum" "min" "max" "last"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, cfg.Validate(), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
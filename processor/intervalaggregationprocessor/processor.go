@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalaggregationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalaggregationprocessor"
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+type intervalAggregationProcessor struct {
+	logger       *zap.Logger
+	nextConsumer consumer.Metrics
+	interval     time.Duration
+	maxStaleness time.Duration
+	aggregations []*aggregation
+
+	// mu guards buffer and intervalStart, the points received since the start of the current interval.
+	mu            sync.Mutex
+	buffer        *buffer
+	intervalStart pcommon.Timestamp
+
+	cancelFunc context.CancelFunc
+	done       chan struct{}
+}
+
+// aggregation is the compiled form of an AggregationConfig.
+type aggregation struct {
+	filter          filterset.FilterSet
+	labelSet        map[string]bool
+	aggregationType AggregationType
+}
+
+func newIntervalAggregationProcessor(config *Config, logger *zap.Logger, nextConsumer consumer.Metrics) (*intervalAggregationProcessor, error) {
+	p := &intervalAggregationProcessor{
+		logger:        logger,
+		nextConsumer:  nextConsumer,
+		interval:      config.Interval,
+		maxStaleness:  config.MaxStaleness,
+		buffer:        newBuffer(logger),
+		intervalStart: pcommon.NewTimestampFromTime(time.Now()),
+	}
+	for _, aggregationConfig := range config.Aggregations {
+		aggregationConfig := aggregationConfig
+		filter, err := filterset.CreateFilterSet(aggregationConfig.Metrics, &aggregationConfig.Config)
+		if err != nil {
+			return nil, err
+		}
+		agg := &aggregation{
+			filter:          filter,
+			labelSet:        make(map[string]bool, len(aggregationConfig.LabelSet)),
+			aggregationType: aggregationConfig.AggregationType,
+		}
+		for _, label := range aggregationConfig.LabelSet {
+			agg.labelSet[label] = true
+		}
+		if agg.aggregationType == "" {
+			agg.aggregationType = Sum
+		}
+		p.aggregations = append(p.aggregations, agg)
+	}
+	return p, nil
+}
+
+func (p *intervalAggregationProcessor) start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancelFunc = cancel
+	p.done = make(chan struct{})
+	go p.flushPeriodically(ctx)
+	return nil
+}
+
+// processMetrics implements the ProcessMetricsFunc type. The points of metrics matching an aggregation
+// are moved to the buffer, other metrics are forwarded unchanged.
+func (p *intervalAggregationProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				switch m.Type() {
+				case pmetric.MetricTypeGauge, pmetric.MetricTypeSum, pmetric.MetricTypeHistogram, pmetric.MetricTypeExponentialHistogram:
+				default:
+					// summaries cannot be aggregated
+					return false
+				}
+				agg := p.aggregationOf(m.Name())
+				if agg == nil {
+					return false
+				}
+				p.buffer.add(rm.Resource(), sm.Scope(), m, agg)
+				return true
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// aggregationOf returns the first aggregation matching the metric name, or nil if none does.
+func (p *intervalAggregationProcessor) aggregationOf(metricName string) *aggregation {
+	for _, agg := range p.aggregations {
+		if agg.filter.Matches(metricName) {
+			return agg
+		}
+	}
+	return nil
+}
+
+// flush emits the aggregated points of the current interval, and starts the next interval with the
+// cumulative streams which are not stale.
+func (p *intervalAggregationProcessor) flush(ctx context.Context, now time.Time) error {
+	var staleBefore time.Time
+	if p.maxStaleness > 0 {
+		staleBefore = now.Add(-p.maxStaleness)
+	}
+
+	p.mu.Lock()
+	end := pcommon.NewTimestampFromTime(now)
+	next := p.buffer.retain(now, staleBefore)
+	md := p.buffer.emit(p.intervalStart, end)
+	p.buffer = next
+	p.intervalStart = end
+	p.mu.Unlock()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return p.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+func (p *intervalAggregationProcessor) flushPeriodically(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := p.flush(ctx, now); err != nil {
+				p.logger.Warn("Failed to send the aggregated metrics", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// shutdown stops the periodic flushes, and emits the points received since the last one.
+func (p *intervalAggregationProcessor) shutdown(ctx context.Context) error {
+	if p.cancelFunc == nil {
+		return nil
+	}
+	p.cancelFunc()
+	<-p.done
+	return p.flush(ctx, time.Now())
+}
+
+// writeLabelSet writes the attributes kept by the aggregation.
+func (agg *aggregation) writeLabelSet(b *bytes.Buffer, attributes pcommon.Map) {
+	attributes.Sort().Range(func(k string, v pcommon.Value) bool {
+		if agg.labelSet[k] {
+			b.WriteByte(sep)
+			b.WriteString(k)
+			b.WriteByte(':')
+			b.WriteString(v.AsString())
+		}
+		return true
+	})
+}
+
+// copyLabelSet copies the attributes kept by the aggregation to dest.
+func (agg *aggregation) copyLabelSet(attributes pcommon.Map, dest pcommon.Map) {
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		if agg.labelSet[k] {
+			v.CopyTo(dest.PutEmpty(k))
+		}
+		return true
+	})
+}
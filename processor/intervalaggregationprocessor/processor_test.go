@@ -0,0 +1,411 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalaggregationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+type testPoint struct {
+	attributes map[string]interface{}
+	start      pcommon.Timestamp
+	ts         pcommon.Timestamp
+	value      float64
+}
+
+func generateTestMetrics(name string, setType func(pmetric.Metric), points ...testPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	setType(m)
+	for _, point := range points {
+		var dp pmetric.NumberDataPoint
+		if m.Type() == pmetric.MetricTypeGauge {
+			dp = m.Gauge().DataPoints().AppendEmpty()
+		} else {
+			dp = m.Sum().DataPoints().AppendEmpty()
+		}
+		dp.Attributes().FromRaw(point.attributes)
+		dp.SetStartTimestamp(point.start)
+		dp.SetTimestamp(point.ts)
+		dp.SetDoubleValue(point.value)
+	}
+	return md
+}
+
+func deltaSum(m pmetric.Metric) {
+	m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.Sum().SetIsMonotonic(true)
+}
+
+func cumulativeSum(m pmetric.Metric) {
+	m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.Sum().SetIsMonotonic(true)
+}
+
+func gauge(m pmetric.Metric) {
+	m.SetEmptyGauge()
+}
+
+func newTestProcessor(t *testing.T, next *consumertest.MetricsSink, aggregations ...AggregationConfig) *intervalAggregationProcessor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Aggregations = aggregations
+	require.NoError(t, cfg.Validate())
+	p, err := newIntervalAggregationProcessor(cfg, zap.NewNop(), next)
+	require.NoError(t, err)
+	p.intervalStart = 100
+	return p
+}
+
+func strictAggregation(aggregationType AggregationType, labelSet ...string) AggregationConfig {
+	return AggregationConfig{
+		Config:          filterset.Config{MatchType: filterset.Strict},
+		Metrics:         []string{"metric"},
+		LabelSet:        labelSet,
+		AggregationType: aggregationType,
+	}
+}
+
+// processAndFlush processes the batches, and returns the points emitted for the interval ending at 200.
+func processAndFlush(t *testing.T, p *intervalAggregationProcessor, next *consumertest.MetricsSink, batches ...pmetric.Metrics) pmetric.Metric {
+	for _, md := range batches {
+		_, err := p.processMetrics(context.Background(), md)
+		require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	}
+	require.NoError(t, p.flush(context.Background(), time.Unix(0, 200)))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	rms := got[0].ResourceMetrics()
+	require.Equal(t, 1, rms.Len())
+	service, _ := rms.At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "test", service.Str())
+	require.Equal(t, 1, rms.At(0).ScopeMetrics().Len())
+	ms := rms.At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	return ms.At(0)
+}
+
+// pointsByAttribute returns the value of each point of a sum or gauge, by the value of the given attribute.
+func pointsByAttribute(t *testing.T, dps pmetric.NumberDataPointSlice, key string) map[string]pmetric.NumberDataPoint {
+	points := make(map[string]pmetric.NumberDataPoint)
+	for i := 0; i < dps.Len(); i++ {
+		v, ok := dps.At(i).Attributes().Get(key)
+		require.True(t, ok)
+		points[v.AsString()] = dps.At(i)
+	}
+	require.Len(t, points, dps.Len())
+	return points
+}
+
+func TestAggregateDeltaSums(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, next, strictAggregation("", "method"))
+
+	m := processAndFlush(t, p, next,
+		generateTestMetrics("metric", deltaSum,
+			testPoint{attributes: map[string]interface{}{"method": "GET", "host": "a"}, start: 100, ts: 110, value: 1},
+			testPoint{attributes: map[string]interface{}{"method": "GET", "host": "b"}, start: 100, ts: 110, value: 2},
+			testPoint{attributes: map[string]interface{}{"method": "POST", "host": "a"}, start: 100, ts: 110, value: 4},
+		),
+		generateTestMetrics("metric", deltaSum,
+			testPoint{attributes: map[string]interface{}{"method": "GET", "host": "a"}, start: 110, ts: 120, value: 8},
+		),
+	)
+
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	assert.True(t, m.Sum().IsMonotonic())
+	points := pointsByAttribute(t, m.Sum().DataPoints(), "method")
+	require.Len(t, points, 2)
+	assert.Equal(t, 11.0, points["GET"].DoubleValue())
+	assert.Equal(t, 4.0, points["POST"].DoubleValue())
+	for _, dp := range points {
+		assert.Equal(t, 1, dp.Attributes().Len())
+		assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+		assert.Equal(t, pcommon.Timestamp(200), dp.Timestamp())
+	}
+}
+
+func TestAggregateCumulativeSums(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, next, strictAggregation(Sum))
+
+	m := processAndFlush(t, p, next,
+		generateTestMetrics("metric", cumulativeSum,
+			testPoint{attributes: map[string]interface{}{"host": "a"}, start: 10, ts: 110, value: 10},
+			testPoint{attributes: map[string]interface{}{"host": "b"}, start: 20, ts: 110, value: 5},
+		),
+		generateTestMetrics("metric", cumulativeSum,
+			testPoint{attributes: map[string]interface{}{"host": "a"}, start: 10, ts: 120, value: 15},
+		),
+	)
+
+	// the latest point of each stream is kept, and the streams are added together
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	dp := m.Sum().DataPoints().At(0)
+	assert.Equal(t, 0, dp.Attributes().Len())
+	assert.Equal(t, 20.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(200), dp.Timestamp())
+}
+
+func TestCumulativeStreamSkippingInterval(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, next, strictAggregation(Sum))
+
+	processAndFlush(t, p, next,
+		generateTestMetrics("metric", cumulativeSum,
+			testPoint{attributes: map[string]interface{}{"host": "a"}, start: 10, ts: 110, value: 10},
+			testPoint{attributes: map[string]interface{}{"host": "b"}, start: 20, ts: 110, value: 5},
+		),
+	)
+
+	// flushIntervalAt only processes the points of stream a, and returns the value emitted at the end of the interval
+	flushIntervalAt := func(now time.Time, value float64) pmetric.NumberDataPointSlice {
+		next.Reset()
+		_, err := p.processMetrics(context.Background(), generateTestMetrics("metric", cumulativeSum,
+			testPoint{attributes: map[string]interface{}{"host": "a"}, start: 10, ts: pcommon.NewTimestampFromTime(now), value: value},
+		))
+		require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+		require.NoError(t, p.flush(context.Background(), now))
+		require.Len(t, next.AllMetrics(), 1)
+		return next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	}
+
+	// stream b is still part of the series after skipping an interval
+	dps := flushIntervalAt(time.Unix(0, 300), 12)
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, 17.0, dps.At(0).DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(10), dps.At(0).StartTimestamp())
+
+	// and dropped once stale
+	dps = flushIntervalAt(time.Unix(0, 200).Add(defaultMaxStaleness+time.Second), 14)
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, 14.0, dps.At(0).DoubleValue())
+}
+
+func TestDeltaPointsAreNotRetained(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, next, strictAggregation(Sum))
+
+	for _, md := range []pmetric.Metrics{
+		generateTestMetrics("metric", deltaSum,
+			testPoint{attributes: map[string]interface{}{"host": "a"}, start: 100, ts: 110, value: 1},
+		),
+		generateTestMetrics("metric", gauge,
+			testPoint{attributes: map[string]interface{}{"host": "a"}, ts: 110, value: 1},
+		),
+	} {
+		_, err := p.processMetrics(context.Background(), md)
+		require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	}
+	require.NoError(t, p.flush(context.Background(), time.Unix(0, 200)))
+	require.Len(t, next.AllMetrics(), 1)
+	assert.Equal(t, 2, next.AllMetrics()[0].DataPointCount())
+
+	next.Reset()
+	require.NoError(t, p.flush(context.Background(), time.Unix(0, 300)))
+	assert.Empty(t, next.AllMetrics())
+}
+
+func TestAggregateGauges(t *testing.T) {
+	batches := func() []pmetric.Metrics {
+		return []pmetric.Metrics{
+			generateTestMetrics("metric", gauge,
+				testPoint{attributes: map[string]interface{}{"host": "a"}, ts: 110, value: 5},
+				testPoint{attributes: map[string]interface{}{"host": "b"}, ts: 115, value: 1},
+			),
+			generateTestMetrics("metric", gauge,
+				testPoint{attributes: map[string]interface{}{"host": "a"}, ts: 120, value: 2},
+				testPoint{attributes: map[string]interface{}{"host": "b"}, ts: 105, value: 7},
+			),
+		}
+	}
+
+	tests := []struct {
+		aggregationType AggregationType
+		expected        float64
+	}{
+		// the latest values of a and b
+		{aggregationType: Sum, expected: 3},
+		{aggregationType: Min, expected: 1},
+		{aggregationType: Max, expected: 7},
+		// the latest value of a
+		{aggregationType: Last, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.aggregationType), func(t *testing.T) {
+			next := new(consumertest.MetricsSink)
+			p := newTestProcessor(t, next, strictAggregation(tt.aggregationType))
+
+			m := processAndFlush(t, p, next, batches()...)
+			require.Equal(t, 1, m.Gauge().DataPoints().Len())
+			dp := m.Gauge().DataPoints().At(0)
+			assert.Equal(t, tt.expected, dp.DoubleValue())
+			assert.Equal(t, pcommon.Timestamp(0), dp.StartTimestamp())
+			assert.Equal(t, pcommon.Timestamp(200), dp.Timestamp())
+		})
+	}
+}
+
+func TestAggregateHistograms(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, next, strictAggregation("", "method"))
+
+	generate := func(host string, bounds []float64, counts []uint64, min, max float64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", "test")
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("metric")
+		m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("method", "GET")
+		dp.Attributes().PutStr("host", host)
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(float64(count))
+		dp.SetMin(min)
+		dp.SetMax(max)
+		return md
+	}
+
+	m := processAndFlush(t, p, next,
+		generate("a", []float64{1, 10}, []uint64{1, 2, 3}, 0.5, 20),
+		generate("a", []float64{1, 10}, []uint64{0, 1, 0}, 2, 2),
+		generate("b", []float64{1, 10}, []uint64{4, 0, 0}, 0.1, 0.9),
+		// dropped, as its boundaries differ from the previous points of its stream
+		generate("b", []float64{5}, []uint64{1, 1}, 1, 8),
+	)
+
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	dp := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"method": "GET"}, dp.Attributes().AsRaw())
+	assert.Equal(t, uint64(11), dp.Count())
+	assert.Equal(t, 11.0, dp.Sum())
+	assert.Equal(t, 0.1, dp.Min())
+	assert.Equal(t, 20.0, dp.Max())
+	assert.Equal(t, []float64{1, 10}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{5, 3, 3}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(100), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(200), dp.Timestamp())
+}
+
+func TestAggregateExponentialHistograms(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, next, strictAggregation(""))
+
+	generate := func(host string, scale int32, offset int32, counts []uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", "test")
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("metric")
+		m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("host", host)
+		dp.SetScale(scale)
+		dp.SetZeroCount(1)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(counts)
+		count := dp.ZeroCount()
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		return md
+	}
+
+	m := processAndFlush(t, p, next,
+		generate("a", 2, 4, []uint64{1, 2, 3}),
+		generate("b", 1, 3, []uint64{1}),
+	)
+
+	// the buckets are merged to the lowest scale
+	require.Equal(t, 1, m.ExponentialHistogram().DataPoints().Len())
+	dp := m.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(9), dp.Count())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, int32(2), dp.Positive().Offset())
+	assert.Equal(t, []uint64{3, 4}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestUnmatchedMetricsAreForwarded(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, next, strictAggregation(""))
+
+	md := generateTestMetrics("other", deltaSum,
+		testPoint{attributes: map[string]interface{}{"host": "a"}, start: 100, ts: 110, value: 1},
+	)
+	summary := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty()
+	summary.SetName("metric")
+	summary.SetEmptySummary().DataPoints().AppendEmpty().SetCount(1)
+	generateTestMetrics("metric", deltaSum,
+		testPoint{attributes: map[string]interface{}{"host": "a"}, start: 100, ts: 110, value: 1},
+	).ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+
+	out, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	require.Equal(t, 1, out.ResourceMetrics().Len())
+	ms := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	assert.Equal(t, "other", ms.At(0).Name())
+	assert.Equal(t, pmetric.MetricTypeSummary, ms.At(1).Type())
+}
+
+func TestFlushOnShutdown(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Aggregations = []AggregationConfig{strictAggregation("")}
+
+	p, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), generateTestMetrics("metric", deltaSum,
+		testPoint{attributes: map[string]interface{}{"host": "a"}, start: 100, ts: 110, value: 1},
+		testPoint{attributes: map[string]interface{}{"host": "b"}, start: 100, ts: 110, value: 2},
+	)))
+	assert.Empty(t, next.AllMetrics())
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.Len(t, next.AllMetrics(), 1)
+	dps := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, 3.0, dps.At(0).DoubleValue())
+}
@@ -40,6 +40,12 @@ type Config struct {
 	// block processing of signals. (Optional field)
 	Prefetch []string `mapstructure:"prefetch"`
 
+	// SchemaFiles is a list of local schema translation files
+	// that are loaded at the start of the collector runtime.
+	// Each file is used for the schema URL it defines in place
+	// of fetching it remotely. (Optional field)
+	SchemaFiles []string `mapstructure:"schema_files"`
+
 	// Targets define what schema families should be
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+var errNoProviders = errors.New("no schema providers configured")
+
+// failedFetchBackoff is how long a schema that failed to be fetched
+// is translated with the no-op translation before being fetched again.
+const failedFetchBackoff = time.Minute
+
+// Manager is responsible for ensuring that schemas are kept up to date
+// with the most recent version that are requested.
+type Manager interface {
+	// RequestTranslation will provide either the defined Translation
+	// if it is a known target, or, return a noop variation.
+	// In the event that a matched Translation, on a missed version
+	// there is a potential to block during this process.
+	// Otherwise, the translation will allow concurrent reads.
+	RequestTranslation(ctx context.Context, schemaURL string) Translation
+
+	// SetProviders will update the list of providers used by the manager
+	// to look up schemaURLs
+	SetProviders(providers ...Provider) error
+}
+
+type manager struct {
+	log *zap.Logger
+
+	rw           sync.RWMutex
+	providers    []Provider
+	match        map[string]*Version
+	translations map[string]*translator
+	// failures holds when the fetch of a schema url
+	// can be attempted again after it failed.
+	failures map[string]time.Time
+	now      func() time.Time
+}
+
+var _ Manager = (*manager)(nil)
+
+// NewManager creates a manager that will allow for management
+// of schema, the options allow for additional properties to be
+// added to manager to enable additional locations of where to check
+// for translations file.
+func NewManager(targets []string, log *zap.Logger) (Manager, error) {
+	if log == nil {
+		log = zap.NewNop()
+	}
+
+	match := make(map[string]*Version, len(targets))
+	for _, target := range targets {
+		family, version, err := GetFamilyAndVersion(target)
+		if err != nil {
+			return nil, err
+		}
+		match[family] = version
+	}
+
+	return &manager{
+		log:          log,
+		match:        match,
+		translations: make(map[string]*translator),
+		failures:     make(map[string]time.Time),
+		now:          time.Now,
+	}, nil
+}
+
+func (m *manager) RequestTranslation(ctx context.Context, schemaURL string) Translation {
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		m.log.Debug("No valid schema url was provided, using no-op schema",
+			zap.String("schema-url", schemaURL),
+		)
+		return nopTranslation{}
+	}
+
+	target, match := m.match[family]
+	if !match {
+		m.log.Debug("Not a known target, providing Nop Translation",
+			zap.String("schema-url", schemaURL),
+		)
+		return nopTranslation{}
+	}
+
+	m.rw.RLock()
+	t, exists := m.translations[family]
+	m.rw.RUnlock()
+
+	if exists && t.SupportedVersion(version) {
+		return t
+	}
+
+	// The schema file published for the highest version contains
+	// all the prior versions, so it is able to translate both ways.
+	latest := target
+	if version.GreaterThan(latest) {
+		latest = version
+	}
+	if exists && latest.LessThan(t.latest()) {
+		// The translation is newer than the version being requested
+		// so it is not going to be resolved by fetching another schema file.
+		m.log.Debug("Schema version is not defined within the schema file",
+			zap.String("schema-url", schemaURL),
+		)
+		return nopTranslation{}
+	}
+
+	latestURL := fmt.Sprint(family, "/", latest)
+	m.rw.RLock()
+	retryAt, failed := m.failures[latestURL]
+	m.rw.RUnlock()
+	if failed && m.now().Before(retryAt) {
+		return nopTranslation{}
+	}
+
+	t, err = m.fetch(ctx, family, target, latest)
+	if err != nil {
+		m.log.Error("Failed to retrieve translation for schema",
+			zap.String("schema-url", schemaURL),
+			zap.Duration("retry-after", failedFetchBackoff),
+			zap.Error(err),
+		)
+		m.rw.Lock()
+		m.failures[latestURL] = m.now().Add(failedFetchBackoff)
+		m.rw.Unlock()
+		return nopTranslation{}
+	}
+
+	m.rw.Lock()
+	delete(m.failures, latestURL)
+	if current, ok := m.translations[family]; !ok || current.latest().LessThan(t.latest()) {
+		m.translations[family] = t
+	}
+	m.rw.Unlock()
+
+	return t
+}
+
+// fetch tries each of the configured providers in order until
+// one is able to provide the schema file for the requested version.
+func (m *manager) fetch(ctx context.Context, family string, target, latest *Version) (*translator, error) {
+	m.rw.RLock()
+	providers := m.providers
+	m.rw.RUnlock()
+
+	if len(providers) == 0 {
+		return nil, errNoProviders
+	}
+
+	var (
+		schemaURL = fmt.Sprint(family, "/", latest)
+		targetURL = fmt.Sprint(family, "/", target)
+		errs      error
+	)
+	for _, p := range providers {
+		content, err := p.Retrieve(ctx, schemaURL)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		return newTranslator(targetURL, bytes.NewReader(content))
+	}
+	return nil, errs
+}
+
+func (m *manager) SetProviders(providers ...Provider) error {
+	if len(providers) == 0 {
+		return errNoProviders
+	}
+	m.rw.Lock()
+	m.providers = append(m.providers[:0], providers...)
+	// the new providers may be able to provide the schemas that previously failed
+	m.failures = make(map[string]time.Time)
+	m.rw.Unlock()
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/fixture"
+)
+
+func newSchemaServer(t *testing.T, requests *int32) *httptest.Server {
+	s := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if filepath.Base(r.URL.Path) != "1.1.0" {
+			wr.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := wr.Write(exampleTranslation)
+		assert.NoError(t, err, "Must not error when writing schema content")
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestManagerRequestTranslation(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	s := newSchemaServer(t, &requests)
+
+	m, err := NewManager([]string{fmt.Sprint(s.URL, "/schemas/1.0.0")}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+	require.NoError(t, m.SetProviders(NewHTTPProvider(s.Client())))
+
+	tn := m.RequestTranslation(context.Background(), fmt.Sprint(s.URL, "/schemas/1.1.0"))
+	assert.IsType(t, (*translator)(nil), tn, "Must return a translation for a matched family")
+	assert.True(t, tn.SupportedVersion(&Version{1, 0, 0}))
+
+	tn = m.RequestTranslation(context.Background(), fmt.Sprint(s.URL, "/schemas/1.0.0"))
+	assert.IsType(t, (*translator)(nil), tn, "Must reuse the cached translation")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "Must only request the schema once")
+
+	tn = m.RequestTranslation(context.Background(), "https://example.com/schemas/1.0.0")
+	assert.Equal(t, nopTranslation{}, tn, "Must return a no-op translation for unknown families")
+
+	tn = m.RequestTranslation(context.Background(), fmt.Sprint(s.URL, "/schemas/1.4.0"))
+	assert.Equal(t, nopTranslation{}, tn, "Must return a no-op translation when the schema is missing")
+}
+
+func TestManagerBacksOffFailedFetches(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	s := newSchemaServer(t, &requests)
+
+	mi, err := NewManager([]string{fmt.Sprint(s.URL, "/schemas/1.0.0")}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+	require.NoError(t, mi.SetProviders(NewHTTPProvider(s.Client())))
+	m := mi.(*manager)
+	now := time.Unix(0, 0)
+	m.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		tn := m.RequestTranslation(context.Background(), fmt.Sprint(s.URL, "/schemas/1.4.0"))
+		assert.Equal(t, nopTranslation{}, tn, "Must return a no-op translation when the schema is missing")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "Must not request a failed schema again before the backoff expires")
+
+	now = now.Add(failedFetchBackoff)
+	m.RequestTranslation(context.Background(), fmt.Sprint(s.URL, "/schemas/1.4.0"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "Must request a failed schema again once the backoff expires")
+
+	require.NoError(t, m.SetProviders(NewHTTPProvider(s.Client())))
+	m.RequestTranslation(context.Background(), fmt.Sprint(s.URL, "/schemas/1.4.0"))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests), "Must request a failed schema again once the providers change")
+}
+
+func TestManagerRequiresProviders(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{"https://opentelemetry.io/schemas/1.1.0"}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+	assert.ErrorIs(t, m.SetProviders(), errNoProviders)
+
+	tn := m.RequestTranslation(context.Background(), "https://opentelemetry.io/schemas/1.0.0")
+	assert.Equal(t, nopTranslation{}, tn, "Must return a no-op translation without providers")
+
+	_, err = NewManager([]string{"opentelemetry.io/schemas/1.0.0"}, zaptest.NewLogger(t))
+	assert.ErrorIs(t, err, ErrInvalidFamily)
+}
+
+func TestManagerFileProvider(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "schema.yml")
+	require.NoError(t, os.WriteFile(path, exampleTranslation, 0600))
+
+	p, err := NewFileProvider(path)
+	require.NoError(t, err, "Must not error when reading schema files")
+
+	m, err := NewManager([]string{prevSchemaURL}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+	require.NoError(t, m.SetProviders(p))
+
+	tn := m.RequestTranslation(context.Background(), nextSchemaURL)
+	assert.IsType(t, (*translator)(nil), tn, "Must return a translation from the local file")
+
+	_, err = NewFileProvider(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.Error(t, err, "Must error when the file does not exist")
+}
+
+func TestManagerConcurrentRequests(t *testing.T) {
+	var requests int32
+	s := newSchemaServer(t, &requests)
+
+	m, err := NewManager([]string{fmt.Sprint(s.URL, "/schemas/1.0.0")}, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+	require.NoError(t, m.SetProviders(NewHTTPProvider(s.Client())))
+
+	fixture.ParallelRaceCompute(t, 10, func() error {
+		tn := m.RequestTranslation(context.Background(), fmt.Sprint(s.URL, "/schemas/1.1.0"))
+		if !tn.SupportedVersion(&Version{1, 1, 0}) {
+			return fmt.Errorf("translation does not support the expected version")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	schema "go.opentelemetry.io/otel/schema/v1.0"
+)
+
+// ErrSchemaNotFound is returned by a Provider
+// when it does not have the requested schema file.
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// Provider allows for collector extensions to be used to look up schemaURLs
+type Provider interface {
+	// Retrieve returns the content of the schema file
+	// that is published at the given schema URL.
+	Retrieve(ctx context.Context, schemaURL string) ([]byte, error)
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+var _ Provider = (*httpProvider)(nil)
+
+// NewHTTPProvider fetches schema files using the provided client.
+func NewHTTPProvider(client *http.Client) Provider {
+	return &httpProvider{client: client}
+}
+
+func (hp *httpProvider) Retrieve(ctx context.Context, schemaURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", schemaURL, ErrSchemaNotFound)
+	default:
+		return nil, fmt.Errorf("invalid status code returned: %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+type staticProvider struct {
+	content map[string][]byte
+}
+
+var _ Provider = (*staticProvider)(nil)
+
+// NewFileProvider reads the provided schema files from disk
+// and serves them using the schema URL defined within each file.
+func NewFileProvider(paths ...string) (Provider, error) {
+	sp := &staticProvider{content: make(map[string][]byte, len(paths))}
+	for _, p := range paths {
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		def, err := schema.Parse(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse schema file %q: %w", p, err)
+		}
+		sp.content[def.SchemaURL] = content
+	}
+	return sp, nil
+}
+
+func (sp *staticProvider) Retrieve(_ context.Context, schemaURL string) ([]byte, error) {
+	content, ok := sp.content[schemaURL]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", schemaURL, ErrSchemaNotFound)
+	}
+	return content, nil
+}
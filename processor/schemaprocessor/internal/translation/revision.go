@@ -0,0 +1,359 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/schema/v1.0/ast"
+	"go.opentelemetry.io/otel/schema/v1.0/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+// renames maps the name used by the previous version
+// to the name used starting with the revision version.
+type renames map[string]string
+
+// reverse returns the inverted mapping so that names
+// can be downgraded to the previous version.
+func (r renames) reverse() renames {
+	rev := make(renames, len(r))
+	for from, to := range r {
+		rev[to] = from
+	}
+	return rev
+}
+
+// selected returns the renames for the requested direction.
+func (r renames) selected(upgrade bool) renames {
+	if upgrade {
+		return r
+	}
+	return r.reverse()
+}
+
+// applyToAttributes renames all matching keys within attrs at once
+// so that swapped names within the same change do not collide.
+func (r renames) applyToAttributes(attrs pcommon.Map) {
+	if len(r) == 0 {
+		return
+	}
+	type entry struct {
+		key string
+		val pcommon.Value
+	}
+	var moved []entry
+	attrs.RemoveIf(func(k string, v pcommon.Value) bool {
+		to, ok := r[k]
+		if !ok {
+			return false
+		}
+		val := pcommon.NewValueEmpty()
+		v.CopyTo(val)
+		moved = append(moved, entry{key: to, val: val})
+		return true
+	})
+	for _, e := range moved {
+		e.val.CopyTo(attrs.PutEmpty(e.key))
+	}
+}
+
+// applyToName updates the signal name if it has a defined rename.
+func (r renames) applyToName(signal alias.Signal) {
+	if to, ok := r[signal.Name()]; ok {
+		signal.SetName(to)
+	}
+}
+
+// conditionalRenames are attribute renames that only apply
+// to signals with a name that is contained within names,
+// an empty set of names applies the renames to every signal.
+type conditionalRenames struct {
+	names map[string]struct{}
+	attrs renames
+}
+
+func newConditionalRenames(attrs ast.AttributeMap, names ...string) conditionalRenames {
+	c := conditionalRenames{
+		attrs: renames(attrs),
+		names: make(map[string]struct{}, len(names)),
+	}
+	for _, name := range names {
+		c.names[name] = struct{}{}
+	}
+	return c
+}
+
+func (c conditionalRenames) matches(name string) bool {
+	if len(c.names) == 0 {
+		return true
+	}
+	_, ok := c.names[name]
+	return ok
+}
+
+// spanEventChange is a single entry within the span events changes,
+// only one of the fields is expected to be set.
+type spanEventChange struct {
+	events renames
+	spans  conditionalRenames
+	attrs  conditionalRenames
+}
+
+// metricChange is a single entry within the metrics changes,
+// a change can rename metrics and then rename attributes of the renamed metrics.
+type metricChange struct {
+	metrics renames
+	attrs   conditionalRenames
+}
+
+// Revision contains all the changes that were introduced with the
+// schema version so that signals can be upgraded to this version
+// from the previous version or downgraded from this version to the previous one.
+type Revision struct {
+	ver *Version
+
+	all        []renames
+	resources  []renames
+	spans      []conditionalRenames
+	spanEvents []spanEventChange
+	metrics    []metricChange
+	logs       []renames
+}
+
+// NewRevision converts the schema file definition of a version
+// into a Revision that can be applied to pdata.
+func NewRevision(ver *Version, def ast.VersionDef) *Revision {
+	rev := &Revision{ver: ver}
+	for _, c := range def.All.Changes {
+		if c.RenameAttributes != nil {
+			rev.all = append(rev.all, renames(*c.RenameAttributes))
+		}
+	}
+	for _, c := range def.Resources.Changes {
+		if c.RenameAttributes != nil {
+			rev.resources = append(rev.resources, renames(*c.RenameAttributes))
+		}
+	}
+	for _, c := range def.Spans.Changes {
+		if c.RenameAttributes != nil {
+			rev.spans = append(rev.spans, newConditionalRenames(
+				c.RenameAttributes.AttributeMap,
+				spanNames(c.RenameAttributes.ApplyToSpans)...,
+			))
+		}
+	}
+	for _, c := range def.SpanEvents.Changes {
+		if c.RenameEvents != nil {
+			rev.spanEvents = append(rev.spanEvents, spanEventChange{
+				events: renames(c.RenameEvents.EventNameMap),
+			})
+		}
+		if c.RenameAttributes != nil {
+			rev.spanEvents = append(rev.spanEvents, spanEventChange{
+				spans: newConditionalRenames(nil, spanNames(c.RenameAttributes.ApplyToSpans)...),
+				attrs: newConditionalRenames(c.RenameAttributes.AttributeMap, eventNames(c.RenameAttributes.ApplyToEvents)...),
+			})
+		}
+	}
+	for _, c := range def.Metrics.Changes {
+		change := metricChange{metrics: make(renames, len(c.RenameMetrics))}
+		for from, to := range c.RenameMetrics {
+			change.metrics[string(from)] = string(to)
+		}
+		if c.RenameAttributes != nil {
+			change.attrs = newConditionalRenames(
+				c.RenameAttributes.AttributeMap,
+				metricNames(c.RenameAttributes.ApplyToMetrics)...,
+			)
+		}
+		rev.metrics = append(rev.metrics, change)
+	}
+	for _, c := range def.Logs.Changes {
+		if c.RenameAttributes != nil {
+			rev.logs = append(rev.logs, renames(c.RenameAttributes.AttributeMap))
+		}
+	}
+	return rev
+}
+
+// Version returns the schema version that the revision was introduced.
+func (r *Revision) Version() *Version {
+	return r.ver
+}
+
+// applyAll applies the changes that are shared by all signal types,
+// these are applied before any signal specific changes when upgrading
+// and after them when downgrading.
+func (r *Revision) applyAll(attrs pcommon.Map, upgrade bool) {
+	for _, i := range order(len(r.all), upgrade) {
+		r.all[i].selected(upgrade).applyToAttributes(attrs)
+	}
+}
+
+func (r *Revision) applyResource(res pcommon.Resource, upgrade bool) {
+	if upgrade {
+		r.applyAll(res.Attributes(), upgrade)
+	}
+	for _, i := range order(len(r.resources), upgrade) {
+		r.resources[i].selected(upgrade).applyToAttributes(res.Attributes())
+	}
+	if !upgrade {
+		r.applyAll(res.Attributes(), upgrade)
+	}
+}
+
+func (r *Revision) applySpan(span ptrace.Span, upgrade bool) {
+	if upgrade {
+		r.applyAll(span.Attributes(), upgrade)
+	}
+	for _, i := range order(len(r.spans), upgrade) {
+		if c := r.spans[i]; c.matches(span.Name()) {
+			c.attrs.selected(upgrade).applyToAttributes(span.Attributes())
+		}
+	}
+	if !upgrade {
+		r.applyAll(span.Attributes(), upgrade)
+	}
+	for i := 0; i < span.Events().Len(); i++ {
+		r.applySpanEvent(span.Name(), span.Events().At(i), upgrade)
+	}
+}
+
+func (r *Revision) applySpanEvent(spanName string, event ptrace.SpanEvent, upgrade bool) {
+	if upgrade {
+		r.applyAll(event.Attributes(), upgrade)
+	}
+	for _, i := range order(len(r.spanEvents), upgrade) {
+		c := r.spanEvents[i]
+		if c.events != nil {
+			c.events.selected(upgrade).applyToName(event)
+			continue
+		}
+		if c.spans.matches(spanName) && c.attrs.matches(event.Name()) {
+			c.attrs.attrs.selected(upgrade).applyToAttributes(event.Attributes())
+		}
+	}
+	if !upgrade {
+		r.applyAll(event.Attributes(), upgrade)
+	}
+}
+
+func (r *Revision) applyMetric(metric pmetric.Metric, upgrade bool) {
+	if upgrade {
+		forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+			r.applyAll(attrs, upgrade)
+		})
+	}
+	for _, i := range order(len(r.metrics), upgrade) {
+		c := r.metrics[i]
+		// The rename of the metric is applied prior to the attribute
+		// changes when upgrading so that the conditional names match
+		// the names defined within the same change, and the inverse when downgrading.
+		if upgrade {
+			c.metrics.applyToName(metric)
+		}
+		if c.attrs.matches(metric.Name()) {
+			attrs := c.attrs.attrs.selected(upgrade)
+			forEachDataPointAttributes(metric, attrs.applyToAttributes)
+		}
+		if !upgrade {
+			c.metrics.reverse().applyToName(metric)
+		}
+	}
+	if !upgrade {
+		forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+			r.applyAll(attrs, upgrade)
+		})
+	}
+}
+
+func (r *Revision) applyLogAttributes(attrs pcommon.Map, upgrade bool) {
+	if upgrade {
+		r.applyAll(attrs, upgrade)
+	}
+	for _, i := range order(len(r.logs), upgrade) {
+		r.logs[i].selected(upgrade).applyToAttributes(attrs)
+	}
+	if !upgrade {
+		r.applyAll(attrs, upgrade)
+	}
+}
+
+// order returns the indexes of a change list in the order
+// that they are required to be applied in.
+func order(n int, upgrade bool) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		if upgrade {
+			idx[i] = i
+		} else {
+			idx[i] = n - 1 - i
+		}
+	}
+	return idx
+}
+
+func forEachDataPointAttributes(metric pmetric.Metric, fn func(attrs pcommon.Map)) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			fn(metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			fn(metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			fn(metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			fn(metric.Summary().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+func spanNames(in []types.SpanName) []string {
+	names := make([]string, 0, len(in))
+	for _, n := range in {
+		names = append(names, string(n))
+	}
+	return names
+}
+
+func eventNames(in []types.EventName) []string {
+	names := make([]string, 0, len(in))
+	for _, n := range in {
+		names = append(names, string(n))
+	}
+	return names
+}
+
+func metricNames(in []types.MetricName) []string {
+	names := make([]string, 0, len(in))
+	for _, n := range in {
+		names = append(names, string(n))
+	}
+	return names
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	schema "go.opentelemetry.io/otel/schema/v1.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+var errMissingTargetVersion = errors.New("schema file does not define the target version")
+
+// Translation defines the complete abstraction of schema translation file
+// that is defined as part of the https://opentelemetry.io/docs/reference/specification/schemas/file_format_v1.0.0/
+// Each instance of Translation is "Target Aware", meaning that given a schemaURL as an input
+// it will convert from the given input, to the configured target.
+//
+// Note: as an optimisation, once a Translation is returned from the manager,
+// the incoming signals are not checked to see if the schema family is a match.
+type Translation interface {
+	// SupportedVersion checks to see if the provided version is defined as part
+	// of this translation since it is useful to know if the translation is missing
+	// updates.
+	SupportedVersion(v *Version) bool
+
+	// ApplyAllResourceChanges will modify the resource part of the incoming signals
+	// This applies to all telemetry types and should be applied there
+	ApplyAllResourceChanges(in alias.Resource, inSchemaURL string)
+
+	// ApplyScopeSpanChanges will modify all spans and span events referenced by the scope.
+	// The scope schema url is only updated when it was previously defined.
+	ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string)
+
+	// ApplyScopeLogChanges will modify all log records referenced by the scope.
+	ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string)
+
+	// ApplyScopeMetricChanges will update all metrics including
+	// their data points referenced by the scope.
+	ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string)
+}
+
+type translator struct {
+	targetSchemaURL string
+	target          *Version
+
+	// revisions are sorted in ascending version order
+	revisions []*Revision
+	indexes   map[Version]int
+}
+
+var _ Translation = (*translator)(nil)
+
+func newTranslator(targetSchemaURL string, content io.Reader) (*translator, error) {
+	_, target, err := GetFamilyAndVersion(targetSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	def, err := schema.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	t := &translator{
+		targetSchemaURL: targetSchemaURL,
+		target:          target,
+		indexes:         make(map[Version]int, len(def.Versions)),
+	}
+	for ident, changes := range def.Versions {
+		ver, err := NewVersion(string(ident))
+		if err != nil {
+			return nil, fmt.Errorf("version %q: %w", ident, err)
+		}
+		t.revisions = append(t.revisions, NewRevision(ver, changes))
+	}
+	sort.Slice(t.revisions, func(i, j int) bool {
+		return t.revisions[i].Version().LessThan(t.revisions[j].Version())
+	})
+	for i, rev := range t.revisions {
+		t.indexes[*rev.Version()] = i
+	}
+	if !t.SupportedVersion(target) {
+		return nil, fmt.Errorf("%s: %w", targetSchemaURL, errMissingTargetVersion)
+	}
+	return t, nil
+}
+
+func (t *translator) SupportedVersion(v *Version) bool {
+	if v == nil {
+		return false
+	}
+	_, ok := t.indexes[*v]
+	return ok
+}
+
+// latest returns the highest version defined by the schema file.
+func (t *translator) latest() *Version {
+	return t.revisions[len(t.revisions)-1].Version()
+}
+
+// walk calls fn with each revision that is required to convert from the version
+// to the target version in the order they must be applied.
+// The boolean passed to fn is true when the changes are upgrading the signal.
+func (t *translator) walk(schemaURL string, fn func(rev *Revision, upgrade bool)) bool {
+	_, from, err := GetFamilyAndVersion(schemaURL)
+	if err != nil || !t.SupportedVersion(from) {
+		return false
+	}
+	var (
+		start  = t.indexes[*from]
+		target = t.indexes[*t.target]
+	)
+	switch {
+	case start < target:
+		// The revision at start was already applied to the signal.
+		for i := start + 1; i <= target; i++ {
+			fn(t.revisions[i], true)
+		}
+	case start > target:
+		// The target revision must not be reverted since
+		// the signal needs to match that version.
+		for i := start; i > target; i-- {
+			fn(t.revisions[i], false)
+		}
+	}
+	return true
+}
+
+func (t *translator) ApplyAllResourceChanges(in alias.Resource, inSchemaURL string) {
+	applied := t.walk(inSchemaURL, func(rev *Revision, upgrade bool) {
+		rev.applyResource(in.Resource(), upgrade)
+	})
+	if applied {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+}
+
+func (t *translator) ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string) {
+	applied := t.walk(inSchemaURL, func(rev *Revision, upgrade bool) {
+		for i := 0; i < in.Spans().Len(); i++ {
+			rev.applySpan(in.Spans().At(i), upgrade)
+		}
+	})
+	if applied && in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+}
+
+func (t *translator) ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string) {
+	applied := t.walk(inSchemaURL, func(rev *Revision, upgrade bool) {
+		for i := 0; i < in.LogRecords().Len(); i++ {
+			rev.applyLogAttributes(in.LogRecords().At(i).Attributes(), upgrade)
+		}
+	})
+	if applied && in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+}
+
+func (t *translator) ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string) {
+	applied := t.walk(inSchemaURL, func(rev *Revision, upgrade bool) {
+		for i := 0; i < in.Metrics().Len(); i++ {
+			rev.applyMetric(in.Metrics().At(i), upgrade)
+		}
+	})
+	if applied && in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+}
+
+// nopTranslation is used when the schema family is not
+// matched by any of the configured targets, or when the
+// schema file could not be retrieved.
+type nopTranslation struct{}
+
+var _ Translation = (*nopTranslation)(nil)
+
+func (nopTranslation) SupportedVersion(_ *Version) bool { return false }
+
+func (nopTranslation) ApplyAllResourceChanges(_ alias.Resource, _ string) {}
+
+func (nopTranslation) ApplyScopeSpanChanges(_ ptrace.ScopeSpans, _ string) {}
+
+func (nopTranslation) ApplyScopeLogChanges(_ plog.ScopeLogs, _ string) {}
+
+func (nopTranslation) ApplyScopeMetricChanges(_ pmetric.ScopeMetrics, _ string) {}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"bytes"
+	_ "embed"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+//go:embed testdata/schema.yml
+var exampleTranslation []byte
+
+const (
+	prevSchemaURL = "https://opentelemetry.io/schemas/1.0.0"
+	nextSchemaURL = "https://opentelemetry.io/schemas/1.1.0"
+)
+
+func newTestTranslator(t *testing.T, target string) *translator {
+	tn, err := newTranslator(target, bytes.NewReader(exampleTranslation))
+	require.NoError(t, err, "Must not error when parsing the example translation")
+	return tn
+}
+
+func TestNewTranslator(t *testing.T) {
+	t.Parallel()
+
+	tn := newTestTranslator(t, nextSchemaURL)
+	assert.True(t, tn.SupportedVersion(&Version{1, 0, 0}), "Must support the previous version")
+	assert.True(t, tn.SupportedVersion(&Version{1, 1, 0}), "Must support the latest version")
+	assert.False(t, tn.SupportedVersion(&Version{1, 2, 0}), "Must not support undefined versions")
+	assert.Equal(t, &Version{1, 1, 0}, tn.latest())
+
+	_, err := newTranslator("https://opentelemetry.io/schemas/1.4.0", bytes.NewReader(exampleTranslation))
+	assert.ErrorIs(t, err, errMissingTargetVersion, "Must error when the target is not defined")
+
+	_, err = newTranslator(nextSchemaURL, bytes.NewReader([]byte("file_format: 2.0.0")))
+	assert.Error(t, err, "Must error with an unsupported file format")
+}
+
+func TestTranslatorResource(t *testing.T) {
+	t.Parallel()
+
+	in := ptrace.NewResourceSpans()
+	in.SetSchemaUrl(prevSchemaURL)
+	in.Resource().Attributes().PutStr("k8s.pod.name", "pod")
+	in.Resource().Attributes().PutStr("telemetry.auto.version", "1.0")
+	in.Resource().Attributes().PutStr("service.name", "svc")
+
+	up := newTestTranslator(t, nextSchemaURL)
+	up.ApplyAllResourceChanges(in, in.SchemaUrl())
+
+	assert.Equal(t, nextSchemaURL, in.SchemaUrl(), "Must update the schema url")
+	assert.Equal(t, map[string]interface{}{
+		"kubernetes.pod.name":          "pod",
+		"telemetry.auto_instr.version": "1.0",
+		"service.name":                 "svc",
+	}, in.Resource().Attributes().AsRaw())
+
+	down := newTestTranslator(t, prevSchemaURL)
+	down.ApplyAllResourceChanges(in, in.SchemaUrl())
+
+	assert.Equal(t, prevSchemaURL, in.SchemaUrl(), "Must update the schema url")
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod.name":           "pod",
+		"telemetry.auto.version": "1.0",
+		"service.name":           "svc",
+	}, in.Resource().Attributes().AsRaw())
+}
+
+func TestTranslatorSpans(t *testing.T) {
+	t.Parallel()
+
+	newSpans := func() ptrace.ScopeSpans {
+		ss := ptrace.NewScopeSpans()
+		ss.SetSchemaUrl(prevSchemaURL)
+		get := ss.Spans().AppendEmpty()
+		get.SetName("HTTP GET")
+		get.Attributes().PutStr("peer.service", "backend")
+		get.Attributes().PutStr("k8s.node.name", "node")
+		ev := get.Events().AppendEmpty()
+		ev.SetName("stacktrace")
+		ev.Attributes().PutStr("peer.service", "backend")
+
+		post := ss.Spans().AppendEmpty()
+		post.SetName("HTTP POST")
+		post.Attributes().PutStr("peer.service", "backend")
+		return ss
+	}
+
+	in := newSpans()
+	newTestTranslator(t, nextSchemaURL).ApplyScopeSpanChanges(in, in.SchemaUrl())
+
+	assert.Equal(t, nextSchemaURL, in.SchemaUrl())
+	get := in.Spans().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"peer.service.name":    "backend",
+		"kubernetes.node.name": "node",
+	}, get.Attributes().AsRaw())
+	assert.Equal(t, "stack_trace", get.Events().At(0).Name(), "Must rename the span event")
+	assert.Equal(t, map[string]interface{}{
+		"peer.service": "backend",
+	}, get.Events().At(0).Attributes().AsRaw(), "Must only rename attributes of matching events")
+	assert.Equal(t, map[string]interface{}{
+		"peer.service": "backend",
+	}, in.Spans().At(1).Attributes().AsRaw(), "Must only rename attributes of matching spans")
+
+	newTestTranslator(t, prevSchemaURL).ApplyScopeSpanChanges(in, in.SchemaUrl())
+	assert.Equal(t, newSpans(), in, "Must be able to revert the changes")
+}
+
+func TestTranslatorMetrics(t *testing.T) {
+	t.Parallel()
+
+	newMetrics := func() pmetric.ScopeMetrics {
+		sm := pmetric.NewScopeMetrics()
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("container.cpu.usage.total")
+		m.SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("k8s.pod.uid", "uid")
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("system.cpu.utilization")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("status", "idle")
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("system.disk.io")
+		m.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("status", "busy")
+		return sm
+	}
+
+	in := newMetrics()
+	newTestTranslator(t, nextSchemaURL).ApplyScopeMetricChanges(in, prevSchemaURL)
+
+	assert.Empty(t, in.SchemaUrl(), "Must not set the scope schema url if it was not defined")
+	assert.Equal(t, "cpu.usage.total", in.Metrics().At(0).Name())
+	assert.Equal(t, map[string]interface{}{
+		"kubernetes.pod.uid": "uid",
+	}, in.Metrics().At(0).Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{
+		"state": "idle",
+	}, in.Metrics().At(1).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{
+		"status": "busy",
+	}, in.Metrics().At(2).Histogram().DataPoints().At(0).Attributes().AsRaw())
+
+	newTestTranslator(t, prevSchemaURL).ApplyScopeMetricChanges(in, nextSchemaURL)
+	assert.Equal(t, newMetrics(), in, "Must be able to revert the changes")
+}
+
+func TestTranslatorLogs(t *testing.T) {
+	t.Parallel()
+
+	in := plog.NewScopeLogs()
+	in.SetSchemaUrl(prevSchemaURL)
+	in.LogRecords().AppendEmpty().Attributes().PutStr("process.executable_name", "otelcol")
+
+	newTestTranslator(t, nextSchemaURL).ApplyScopeLogChanges(in, in.SchemaUrl())
+	assert.Equal(t, nextSchemaURL, in.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{
+		"process.executable.name": "otelcol",
+	}, in.LogRecords().At(0).Attributes().AsRaw())
+}
+
+func TestTranslatorUnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	in := plog.NewResourceLogs()
+	in.SetSchemaUrl("https://opentelemetry.io/schemas/0.9.0")
+	in.Resource().Attributes().PutStr("k8s.pod.name", "pod")
+
+	expect := plog.NewResourceLogs()
+	in.CopyTo(expect)
+
+	newTestTranslator(t, nextSchemaURL).ApplyAllResourceChanges(in, in.SchemaUrl())
+	assert.Equal(t, expect, in, "Must not modify signals with an unknown version")
+}
+
+func TestRenamesSwapAttributes(t *testing.T) {
+	t.Parallel()
+
+	in := plog.NewLogRecord()
+	in.Attributes().PutStr("a", "first")
+	in.Attributes().PutStr("b", "second")
+
+	renames{"a": "b", "b": "a"}.applyToAttributes(in.Attributes())
+	assert.Equal(t, map[string]interface{}{
+		"a": "second",
+		"b": "first",
+	}, in.Attributes().AsRaw())
+}
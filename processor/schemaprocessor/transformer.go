@@ -20,15 +20,24 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	targets     []string
+	prefetch    []string
+	schemaFiles []string
+	client      confighttp.HTTPClientSettings
+	log         *zap.Logger
+	telemetry   component.TelemetrySettings
+
+	manager translation.Manager
 }
 
 func newTransformer(
@@ -40,29 +49,133 @@ func newTransformer(
 	if !ok {
 		return nil, errors.New("invalid configuration provided")
 	}
+	m, err := translation.NewManager(cfg.Targets, set.Logger)
+	if err != nil {
+		return nil, err
+	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		log:         set.Logger,
+		telemetry:   set.TelemetrySettings,
+		targets:     cfg.Targets,
+		prefetch:    cfg.Prefetch,
+		schemaFiles: cfg.SchemaFiles,
+		client:      cfg.HTTPClientSettings,
+		manager:     m,
 	}, nil
 }
 
 func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for rl := 0; rl < ld.ResourceLogs().Len(); rl++ {
+		rLog := ld.ResourceLogs().At(rl)
+		resourceSchemaURL := rLog.SchemaUrl()
+		if resourceSchemaURL != "" {
+			t.manager.
+				RequestTranslation(ctx, resourceSchemaURL).
+				ApplyAllResourceChanges(rLog, resourceSchemaURL)
+		}
+		for sl := 0; sl < rLog.ScopeLogs().Len(); sl++ {
+			log := rLog.ScopeLogs().At(sl)
+			logSchemaURL := log.SchemaUrl()
+			if logSchemaURL == "" {
+				logSchemaURL = resourceSchemaURL
+			}
+			if logSchemaURL == "" {
+				continue
+			}
+			t.manager.
+				RequestTranslation(ctx, logSchemaURL).
+				ApplyScopeLogChanges(log, logSchemaURL)
+		}
+	}
 	return ld, nil
 }
 
 func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for rm := 0; rm < md.ResourceMetrics().Len(); rm++ {
+		rMetric := md.ResourceMetrics().At(rm)
+		resourceSchemaURL := rMetric.SchemaUrl()
+		if resourceSchemaURL != "" {
+			t.manager.
+				RequestTranslation(ctx, resourceSchemaURL).
+				ApplyAllResourceChanges(rMetric, resourceSchemaURL)
+		}
+		for sm := 0; sm < rMetric.ScopeMetrics().Len(); sm++ {
+			metric := rMetric.ScopeMetrics().At(sm)
+			metricSchemaURL := metric.SchemaUrl()
+			if metricSchemaURL == "" {
+				metricSchemaURL = resourceSchemaURL
+			}
+			if metricSchemaURL == "" {
+				continue
+			}
+			t.manager.
+				RequestTranslation(ctx, metricSchemaURL).
+				ApplyScopeMetricChanges(metric, metricSchemaURL)
+		}
+	}
 	return md, nil
 }
 
 func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for rt := 0; rt < td.ResourceSpans().Len(); rt++ {
+		rTrace := td.ResourceSpans().At(rt)
+		// The resource schema URL is used as the default
+		// when the scope does not define its own.
+		resourceSchemaURL := rTrace.SchemaUrl()
+		if resourceSchemaURL != "" {
+			t.manager.
+				RequestTranslation(ctx, resourceSchemaURL).
+				ApplyAllResourceChanges(rTrace, resourceSchemaURL)
+		}
+		for ss := 0; ss < rTrace.ScopeSpans().Len(); ss++ {
+			span := rTrace.ScopeSpans().At(ss)
+			spanSchemaURL := span.SchemaUrl()
+			if spanSchemaURL == "" {
+				spanSchemaURL = resourceSchemaURL
+			}
+			if spanSchemaURL == "" {
+				continue
+			}
+			t.manager.
+				RequestTranslation(ctx, spanSchemaURL).
+				ApplyScopeSpanChanges(span, spanSchemaURL)
+		}
+	}
 	return td, nil
 }
 
 // start will load the remote file definition if it isn't already cached
 // and resolve the schema translation file
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	var providers []translation.Provider
+	if len(t.schemaFiles) > 0 {
+		p, err := translation.NewFileProvider(t.schemaFiles...)
+		if err != nil {
+			return err
+		}
+		providers = append(providers, p)
+	}
+	client, err := t.client.ToClient(host, t.telemetry)
+	if err != nil {
+		return err
+	}
+	providers = append(providers, translation.NewHTTPProvider(client))
+	if err := t.manager.SetProviders(providers...); err != nil {
+		return err
+	}
+
+	schemaURLs := make([]string, 0, len(t.targets)+len(t.prefetch))
+	schemaURLs = append(schemaURLs, t.targets...)
+	schemaURLs = append(schemaURLs, t.prefetch...)
+	for _, schemaURL := range schemaURLs {
+		_, version, err := translation.GetFamilyAndVersion(schemaURL)
+		if err != nil {
+			return err
+		}
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		if !t.manager.RequestTranslation(ctx, schemaURL).SupportedVersion(version) {
+			t.log.Warn("Unable to prefetch schema url", zap.String("schema-url", schemaURL))
+		}
 	}
 	return nil
 }
@@ -17,12 +17,15 @@ package schemaprocessor
 import (
 	"context"
 	_ "embed"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -108,3 +111,57 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func TestTransformerSchemaTranslation(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(SchemaHandler(t)))
+	t.Cleanup(s.Close)
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{fmt.Sprint(s.URL, "/schemas/1.1.0")}
+
+	trans, err := newTransformer(context.Background(), cfg, component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err, "Must not error when creating transformer")
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+
+	t.Run("traces", func(t *testing.T) {
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(fmt.Sprint(s.URL, "/schemas/1.0.0"))
+		rs.Resource().Attributes().PutStr("k8s.pod.name", "pod")
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName("HTTP GET")
+		span.Attributes().PutStr("peer.service", "backend")
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing traces")
+
+		rs = out.ResourceSpans().At(0)
+		assert.Equal(t, fmt.Sprint(s.URL, "/schemas/1.1.0"), rs.SchemaUrl(), "Must update the schema url")
+		assert.Equal(t, map[string]interface{}{
+			"kubernetes.pod.name": "pod",
+		}, rs.Resource().Attributes().AsRaw())
+		assert.Equal(t, map[string]interface{}{
+			"peer.service.name": "backend",
+		}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("unknown family", func(t *testing.T) {
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl("https://example.com/schemas/1.0.0")
+		rl.Resource().Attributes().PutStr("k8s.pod.name", "pod")
+
+		expect := plog.NewLogs()
+		in.CopyTo(expect)
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing logs")
+		assert.Equal(t, expect, out, "Must not modify unmatched schema families")
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+// pendingTracesKey is the storage key holding the traces waiting for a sampling decision.
+const pendingTracesKey = "pending_traces"
+
+// pendingTrace is the persisted form of a trace waiting for a sampling decision.
+type pendingTrace struct {
+	TraceID     string    `json:"trace_id"`
+	ArrivalTime time.Time `json:"arrival_time"`
+	Spans       []byte    `json:"spans"`
+}
+
+func (tsp *tailSamplingSpanProcessor) setStorageClient(ctx context.Context, host component.Host) error {
+	extension, ok := host.GetExtensions()[*tsp.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", tsp.storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", tsp.storageID)
+	}
+
+	client, err := storageExtension.GetClient(ctx, component.KindProcessor, tsp.componentID, "")
+	if err != nil {
+		return err
+	}
+	tsp.storageClient = client
+	return nil
+}
+
+// checkpoint writes the traces that are still waiting for a sampling decision to the storage.
+func (tsp *tailSamplingSpanProcessor) checkpoint(ctx context.Context) error {
+	var (
+		pending   []pendingTrace
+		marshaler = ptrace.NewProtoMarshaler()
+		errs      error
+	)
+	tsp.idToTrace.Range(func(key, value interface{}) bool {
+		id := key.(pcommon.TraceID)
+		trace := value.(*sampling.TraceData)
+
+		trace.Lock()
+		defer trace.Unlock()
+		// the received batches are moved out of the trace once a decision is made
+		if trace.ReceivedBatches.ResourceSpans().Len() == 0 {
+			return true
+		}
+		spans, err := marshaler.MarshalTraces(trace.ReceivedBatches)
+		if err != nil {
+			errs = err
+			return false
+		}
+		pending = append(pending, pendingTrace{
+			TraceID:     id.HexString(),
+			ArrivalTime: trace.ArrivalTime,
+			Spans:       spans,
+		})
+		return true
+	})
+	if errs != nil {
+		return fmt.Errorf("failed to marshal pending traces: %w", errs)
+	}
+
+	content, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	return tsp.storageClient.Set(ctx, pendingTracesKey, content)
+}
+
+// restore loads the traces that were waiting for a sampling decision when the storage was last
+// checkpointed, scheduling their decisions for when their decision wait would have expired.
+func (tsp *tailSamplingSpanProcessor) restore(ctx context.Context) error {
+	content, err := tsp.storageClient.Get(ctx, pendingTracesKey)
+	if err != nil || content == nil {
+		return err
+	}
+
+	var pending []pendingTrace
+	if err = json.Unmarshal(content, &pending); err != nil {
+		return fmt.Errorf("failed to read pending traces from the storage: %w", err)
+	}
+
+	numBatches := int(tsp.decisionWait / tsp.tickerFrequency)
+	tsp.restoredBatches = make([]idbatcher.Batch, numBatches+1)
+
+	unmarshaler := ptrace.NewProtoUnmarshaler()
+	now := time.Now()
+	for _, p := range pending {
+		var id pcommon.TraceID
+		if _, err = hex.Decode(id[:], []byte(p.TraceID)); err != nil {
+			tsp.logger.Warn("Skipping restored trace with invalid ID", zap.String("traceID", p.TraceID), zap.Error(err))
+			continue
+		}
+		td, err := unmarshaler.UnmarshalTraces(p.Spans)
+		if err != nil {
+			tsp.logger.Warn("Skipping restored trace with invalid spans", zap.String("traceID", p.TraceID), zap.Error(err))
+			continue
+		}
+
+		decisions := make([]sampling.Decision, len(tsp.policies))
+		for i := range decisions {
+			decisions[i] = sampling.Pending
+		}
+		tsp.idToTrace.Store(id, &sampling.TraceData{
+			Decisions:       decisions,
+			ArrivalTime:     p.ArrivalTime,
+			SpanCount:       atomic.NewInt64(int64(td.SpanCount())),
+			ReceivedBatches: td,
+		})
+		tsp.numTracesOnMap.Add(1)
+		tsp.enqueueForDeletion(id, now)
+
+		// traces whose decision wait already expired are evaluated on the first tick
+		idx := int(p.ArrivalTime.Add(tsp.decisionWait).Sub(now) / tsp.tickerFrequency)
+		if idx < 0 {
+			idx = 0
+		} else if idx > numBatches {
+			idx = numBatches
+		}
+		tsp.restoredBatches[idx] = append(tsp.restoredBatches[idx], id)
+	}
+
+	tsp.logger.Info("Restored pending traces from the storage", zap.Int("traces", len(pending)))
+	return nil
+}
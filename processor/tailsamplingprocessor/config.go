@@ -48,6 +48,8 @@ const (
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
 	TraceState PolicyType = "trace_state"
+	// OTTLCondition sample traces with a span matching the given OTTL conditions
+	OTTLCondition PolicyType = "ottl_condition"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -73,6 +75,8 @@ type sharedPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for OTTL condition filter sampling policy evaluator
+	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -95,6 +99,24 @@ type TraceStateCfg struct {
 	Values []string `mapstructure:"values"`
 }
 
+// OTTLConditionCfg holds the configurable settings to create an OTTL condition filter
+// sampling policy evaluator.
+type OTTLConditionCfg struct {
+	// ErrorMode determines how errors evaluating the conditions are handled, either "propagate" or "ignore".
+	// Defaults to "propagate", which makes the policy return an error and not sample the trace.
+	ErrorMode string `mapstructure:"error_mode"`
+	// Match determines how the conditions are combined, either "any" or "all". Defaults to "any",
+	// which makes a span or span event match when any of the conditions is true.
+	Match string `mapstructure:"match"`
+	// TraceMatch determines how the spans of the trace are combined, either "any" or "all". Defaults to "any",
+	// which samples the trace when any of its spans matches.
+	TraceMatch string `mapstructure:"trace_match"`
+	// SpanConditions are evaluated against every span of the trace.
+	SpanConditions []string `mapstructure:"span"`
+	// SpanEventConditions are evaluated against every span event of the trace.
+	SpanEventConditions []string `mapstructure:"spanevent"`
+}
+
 type AndCfg struct {
 	SubPolicyCfg []AndSubPolicyCfg `mapstructure:"and_sub_policy"`
 }
@@ -209,4 +231,7 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// StorageID is the ID of the storage extension used to checkpoint the traces waiting for a decision,
+	// so that they can be restored when the collector restarts. Traces are kept only in memory when not set.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
@@ -108,6 +108,24 @@ func TestLoadConfig(t *testing.T) {
 						TraceStateCfg: TraceStateCfg{Key: "key3", Values: []string{"value1", "value2"}},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-10",
+						Type: OTTLCondition,
+						OTTLConditionCfg: OTTLConditionCfg{
+							ErrorMode:  "ignore",
+							Match:      "all",
+							TraceMatch: "all",
+							SpanConditions: []string{
+								`attributes["http.status_code"] >= 500`,
+								`resource.attributes["service.name"] == "checkout"`,
+							},
+							SpanEventConditions: []string{
+								`name == "exception"`,
+							},
+						},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// OTTLErrorMode determines how errors returned while evaluating the conditions are handled.
+type OTTLErrorMode string
+
+const (
+	// OTTLPropagateError makes the policy return an error as soon as a condition fails to be evaluated.
+	OTTLPropagateError OTTLErrorMode = "propagate"
+	// OTTLIgnoreError logs the error and continues evaluating the remaining spans.
+	OTTLIgnoreError OTTLErrorMode = "ignore"
+)
+
+// OTTLMatchMode determines how the conditions are combined when evaluated against a span or span event,
+// and how the spans are combined when evaluating a trace.
+type OTTLMatchMode string
+
+const (
+	// OTTLMatchAny matches when any of the conditions is true, or samples a trace when any of its spans matches.
+	OTTLMatchAny OTTLMatchMode = "any"
+	// OTTLMatchAll matches when all the conditions are true, or samples a trace when all of its spans match.
+	OTTLMatchAll OTTLMatchMode = "all"
+)
+
+type ottlConditionFilter struct {
+	logger              *zap.Logger
+	spanConditions      []*ottl.Condition[ottltraces.TransformContext]
+	spanEventConditions []*ottl.Condition[ottlspanevent.TransformContext]
+	matchMode           OTTLMatchMode
+	traceMatchMode      OTTLMatchMode
+	errorMode           OTTLErrorMode
+}
+
+var _ PolicyEvaluator = (*ottlConditionFilter)(nil)
+
+// NewOTTLConditionFilter creates a policy evaluator that samples the traces with any or all of their spans
+// matching the given OTTL conditions, depending on traceMatchMode. A span matches when it matches the span
+// conditions, or when one of its span events matches the span event conditions.
+func NewOTTLConditionFilter(logger *zap.Logger, spanConditions, spanEventConditions []string, matchMode, traceMatchMode OTTLMatchMode, errorMode OTTLErrorMode) (PolicyEvaluator, error) {
+	return newOTTLConditionFilter(logger, ottlFunctions[ottltraces.TransformContext](), ottlFunctions[ottlspanevent.TransformContext](),
+		spanConditions, spanEventConditions, matchMode, traceMatchMode, errorMode)
+}
+
+func newOTTLConditionFilter(logger *zap.Logger, spanFunctions, spanEventFunctions map[string]interface{},
+	spanConditions, spanEventConditions []string, matchMode, traceMatchMode OTTLMatchMode, errorMode OTTLErrorMode) (PolicyEvaluator, error) {
+	if len(spanConditions) == 0 && len(spanEventConditions) == 0 {
+		return nil, errors.New("expected at least one OTTL condition to filter on")
+	}
+	switch matchMode {
+	case "":
+		matchMode = OTTLMatchAny
+	case OTTLMatchAny, OTTLMatchAll:
+	default:
+		return nil, fmt.Errorf("unknown match mode %q, supported: %s, %s", matchMode, OTTLMatchAny, OTTLMatchAll)
+	}
+	switch traceMatchMode {
+	case "":
+		traceMatchMode = OTTLMatchAny
+	case OTTLMatchAny, OTTLMatchAll:
+	default:
+		return nil, fmt.Errorf("unknown trace match mode %q, supported: %s, %s", traceMatchMode, OTTLMatchAny, OTTLMatchAll)
+	}
+	switch errorMode {
+	case "":
+		errorMode = OTTLPropagateError
+	case OTTLPropagateError, OTTLIgnoreError:
+	default:
+		return nil, fmt.Errorf("unknown error mode %q, supported: %s, %s", errorMode, OTTLPropagateError, OTTLIgnoreError)
+	}
+
+	filter := &ottlConditionFilter{
+		logger:         logger,
+		matchMode:      matchMode,
+		traceMatchMode: traceMatchMode,
+		errorMode:      errorMode,
+	}
+
+	var err error
+	if len(spanConditions) > 0 {
+		parser := ottltraces.NewParser(spanFunctions, component.TelemetrySettings{Logger: logger})
+		if filter.spanConditions, err = parser.ParseConditions(spanConditions); err != nil {
+			return nil, err
+		}
+	}
+	if len(spanEventConditions) > 0 {
+		parser := ottlspanevent.NewParser(spanEventFunctions, component.TelemetrySettings{Logger: logger})
+		if filter.spanEventConditions, err = parser.ParseConditions(spanEventConditions); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (ocf *ottlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	ocf.logger.Debug("Evaluating spans with OTTL conditions filter")
+
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	spanCount := 0
+	for i := 0; i < batches.ResourceSpans().Len(); i++ {
+		rs := batches.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				matched, err := ocf.matchSpan(ss.Spans().At(k), ss.Scope(), rs.Resource())
+				if err != nil {
+					return Error, err
+				}
+				if matched && ocf.traceMatchMode == OTTLMatchAny {
+					return Sampled, nil
+				}
+				if !matched && ocf.traceMatchMode == OTTLMatchAll {
+					return NotSampled, nil
+				}
+				spanCount++
+			}
+		}
+	}
+	if ocf.traceMatchMode == OTTLMatchAll && spanCount > 0 {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+// matchSpan returns whether the span matches the span conditions, or one of its span events matches the span event conditions.
+func (ocf *ottlConditionFilter) matchSpan(span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) (bool, error) {
+	matched, err := matchConditions(ocf, ocf.spanConditions, ottltraces.NewTransformContext(span, scope, resource))
+	if err != nil || matched {
+		return matched, err
+	}
+	for i := 0; i < span.Events().Len(); i++ {
+		tCtx := ottlspanevent.NewTransformContext(span.Events().At(i), span, scope, resource)
+		if matched, err = matchConditions(ocf, ocf.spanEventConditions, tCtx); err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
+
+// matchConditions evaluates the conditions against the given context according to the match mode of the filter.
+// A condition that fails to be evaluated is considered not to match when errors are ignored.
+func matchConditions[K any](ocf *ottlConditionFilter, conditions []*ottl.Condition[K], tCtx K) (bool, error) {
+	if len(conditions) == 0 {
+		return false, nil
+	}
+	for _, condition := range conditions {
+		matched, err := condition.Eval(tCtx)
+		if err != nil {
+			if ocf.errorMode == OTTLPropagateError {
+				return false, err
+			}
+			ocf.logger.Warn("failed evaluating OTTL condition", zap.Error(err))
+			matched = false
+		}
+		if matched && ocf.matchMode == OTTLMatchAny {
+			return true, nil
+		}
+		if !matched && ocf.matchMode == OTTLMatchAll {
+			return false, nil
+		}
+	}
+	return ocf.matchMode == OTTLMatchAll, nil
+}
+
+func ottlFunctions[K any]() map[string]interface{} {
+	return map[string]interface{}{
+		"TraceID": ottlfuncs.TraceID[K],
+		"SpanID":  ottlfuncs.SpanID[K],
+		"IsMatch": ottlfuncs.IsMatch[K],
+		"Concat":  ottlfuncs.Concat[K],
+		"Split":   ottlfuncs.Split[K],
+		"Int":     ottlfuncs.Int[K],
+	}
+}
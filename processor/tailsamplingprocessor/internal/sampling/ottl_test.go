@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
+)
+
+func TestNewOTTLConditionFilter_errorHandling(t *testing.T) {
+	_, err := NewOTTLConditionFilter(zap.NewNop(), nil, nil, OTTLMatchAny, OTTLMatchAny, OTTLPropagateError)
+	assert.EqualError(t, err, "expected at least one OTTL condition to filter on")
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), []string{`name == "a"`}, nil, OTTLMatchAny, OTTLMatchAny, "fail")
+	assert.EqualError(t, err, "unknown error mode \"fail\", supported: propagate, ignore")
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), []string{`name == "a"`}, nil, "some", OTTLMatchAny, OTTLPropagateError)
+	assert.EqualError(t, err, "unknown match mode \"some\", supported: any, all")
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), []string{`name == "a"`}, nil, OTTLMatchAny, "some", OTTLPropagateError)
+	assert.EqualError(t, err, "unknown trace match mode \"some\", supported: any, all")
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), []string{`name ==`}, nil, OTTLMatchAny, OTTLMatchAny, OTTLPropagateError)
+	assert.Error(t, err)
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), nil, []string{`name ==`}, OTTLMatchAny, OTTLMatchAny, OTTLPropagateError)
+	assert.Error(t, err)
+}
+
+func TestOTTLConditionSampling(t *testing.T) {
+	// a converter that always fails is used to exercise the error modes
+	functions := ottlFunctions[ottltraces.TransformContext]()
+	functions["Fail"] = func() (ottl.ExprFunc[ottltraces.TransformContext], error) {
+		return func(ottltraces.TransformContext) (interface{}, error) {
+			return nil, errors.New("failed")
+		}, nil
+	}
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	cases := []struct {
+		Desc                string
+		Conditions          []string
+		SpanEventConditions []string
+		MatchMode           OTTLMatchMode
+		ErrorMode           OTTLErrorMode
+		Decision            Decision
+		Error               bool
+	}{
+		{
+			Desc:       "span attribute matches",
+			Conditions: []string{`attributes["http.status_code"] >= 500`},
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "span attribute does not match",
+			Conditions: []string{`attributes["http.status_code"] >= 600`},
+			Decision:   NotSampled,
+		},
+		{
+			Desc:       "resource and span match",
+			Conditions: []string{`resource.attributes["service.name"] == "checkout" and name == "GET /cart"`},
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "any condition matches",
+			Conditions: []string{`name == "unknown"`, `status.code == STATUS_CODE_ERROR`},
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "negated converter matches",
+			Conditions: []string{`IsMatch(name, "^GET ") and not IsMatch(name, "/health$")`},
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "all conditions match",
+			Conditions: []string{`name == "GET /cart"`, `status.code == STATUS_CODE_ERROR`},
+			MatchMode:  OTTLMatchAll,
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "not all conditions match",
+			Conditions: []string{`name == "GET /cart"`, `name == "unknown"`},
+			MatchMode:  OTTLMatchAll,
+			Decision:   NotSampled,
+		},
+		{
+			Desc:                "span event matches",
+			SpanEventConditions: []string{`name == "exception"`},
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "span event does not match",
+			SpanEventConditions: []string{`attributes["exception.type"] == "TimeoutError"`},
+			Decision:            NotSampled,
+		},
+		{
+			Desc:                "span event and its span match",
+			SpanEventConditions: []string{`name == "exception"`, `span.name == "GET /cart"`},
+			MatchMode:           OTTLMatchAll,
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "span does not match but span event does",
+			Conditions:          []string{`name == "unknown"`},
+			SpanEventConditions: []string{`attributes["exception.type"] == "NullPointerException"`},
+			Decision:            Sampled,
+		},
+		{
+			Desc:       "error is propagated",
+			Conditions: []string{`Fail()`, `name == "GET /cart"`},
+			ErrorMode:  OTTLPropagateError,
+			Decision:   Error,
+			Error:      true,
+		},
+		{
+			Desc:       "error is ignored",
+			Conditions: []string{`Fail()`, `name == "GET /cart"`},
+			ErrorMode:  OTTLIgnoreError,
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "ignored error does not match all",
+			Conditions: []string{`Fail()`, `name == "GET /cart"`},
+			MatchMode:  OTTLMatchAll,
+			ErrorMode:  OTTLIgnoreError,
+			Decision:   NotSampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			traces := ptrace.NewTraces()
+			rs := traces.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().PutStr("service.name", "checkout")
+			span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID(traceID)
+			span.SetName("GET /cart")
+			span.Status().SetCode(ptrace.StatusCodeError)
+			span.Attributes().PutInt("http.status_code", 503)
+			event := span.Events().AppendEmpty()
+			event.SetName("exception")
+			event.Attributes().PutStr("exception.type", "NullPointerException")
+
+			trace := &TraceData{
+				ReceivedBatches: traces,
+			}
+
+			filter, err := newOTTLConditionFilter(zap.NewNop(), functions, ottlFunctions[ottlspanevent.TransformContext](),
+				c.Conditions, c.SpanEventConditions, c.MatchMode, OTTLMatchAny, c.ErrorMode)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(traceID, trace)
+			if c.Error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestOTTLConditionTraceMatch(t *testing.T) {
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	cases := []struct {
+		Desc                string
+		Conditions          []string
+		SpanEventConditions []string
+		TraceMatchMode      OTTLMatchMode
+		Decision            Decision
+	}{
+		{
+			Desc:           "any span matches",
+			Conditions:     []string{`attributes["http.status_code"] >= 500`},
+			TraceMatchMode: OTTLMatchAny,
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "not all spans match",
+			Conditions:     []string{`attributes["http.status_code"] >= 500`},
+			TraceMatchMode: OTTLMatchAll,
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "all spans match",
+			Conditions:     []string{`attributes["http.status_code"] >= 200`},
+			TraceMatchMode: OTTLMatchAll,
+			Decision:       Sampled,
+		},
+		{
+			Desc:                "all spans match a condition or have a matching span event",
+			Conditions:          []string{`attributes["http.status_code"] >= 500`},
+			SpanEventConditions: []string{`name == "retry"`},
+			TraceMatchMode:      OTTLMatchAll,
+			Decision:            Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			traces := ptrace.NewTraces()
+			spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+			failed := spans.AppendEmpty()
+			failed.SetTraceID(traceID)
+			failed.Attributes().PutInt("http.status_code", 503)
+			retried := spans.AppendEmpty()
+			retried.SetTraceID(traceID)
+			retried.Attributes().PutInt("http.status_code", 200)
+			retried.Events().AppendEmpty().SetName("retry")
+
+			filter, err := NewOTTLConditionFilter(zap.NewNop(), c.Conditions, c.SpanEventConditions, OTTLMatchAny, c.TraceMatchMode, OTTLPropagateError)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(traceID, &TraceData{ReceivedBatches: traces})
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestOTTLConditionTraceMatchAllWithoutSpans(t *testing.T) {
+	filter, err := NewOTTLConditionFilter(zap.NewNop(), []string{`name == "a"`}, nil, OTTLMatchAny, OTTLMatchAll, OTTLPropagateError)
+	require.NoError(t, err)
+
+	decision, err := filter.Evaluate(pcommon.TraceID{}, &TraceData{ReceivedBatches: ptrace.NewTraces()})
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
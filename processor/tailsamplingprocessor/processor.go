@@ -24,10 +24,13 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
@@ -60,6 +63,16 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+	decisionWait    time.Duration
+
+	// storageID and componentID identify the storage client used to persist pending traces, if any.
+	storageID     *config.ComponentID
+	componentID   config.ComponentID
+	storageClient storage.Client
+	// restoredBatches holds the IDs of the traces restored from the storage, in the order their decisions are due.
+	restoredBatches      []idbatcher.Batch
+	checkpointTicks      int
+	ticksSinceCheckpoint int
 }
 
 const (
@@ -108,6 +121,11 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		policies:        policies,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  atomic.NewUint64(0),
+		decisionWait:    cfg.DecisionWait,
+		storageID:       cfg.StorageID,
+		componentID:     cfg.ID(),
+		// checkpoint the pending traces once per decision period
+		checkpointTicks: int(numDecisionBatches),
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -155,6 +173,10 @@ func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (samplin
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case OTTLCondition:
+		ocfCfg := cfg.OTTLConditionCfg
+		return sampling.NewOTTLConditionFilter(logger, ocfCfg.SpanConditions, ocfCfg.SpanEventConditions,
+			sampling.OTTLMatchMode(ocfCfg.Match), sampling.OTTLMatchMode(ocfCfg.TraceMatch), sampling.OTTLErrorMode(ocfCfg.ErrorMode))
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -169,6 +191,10 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 
 	startTime := time.Now()
 	batch, _ := tsp.decisionBatcher.CloseCurrentAndTakeFirstBatch()
+	if len(tsp.restoredBatches) > 0 {
+		batch = append(batch, tsp.restoredBatches[0]...)
+		tsp.restoredBatches = tsp.restoredBatches[1:]
+	}
 	batchLen := len(batch)
 	tsp.logger.Debug("Sampling Policy Evaluation ticked")
 	for _, id := range batch {
@@ -206,6 +232,16 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		zap.Int64("droppedPriorToEvaluation", metrics.idNotFoundOnMapCount),
 		zap.Int64("policyEvaluationErrors", metrics.evaluateErrorCount),
 	)
+
+	if tsp.storageClient != nil {
+		tsp.ticksSinceCheckpoint++
+		if tsp.ticksSinceCheckpoint >= tsp.checkpointTicks {
+			tsp.ticksSinceCheckpoint = 0
+			if err := tsp.checkpoint(tsp.ctx); err != nil {
+				tsp.logger.Warn("Failed to checkpoint pending traces", zap.Error(err))
+			}
+		}
+	}
 }
 
 func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sampling.TraceData, metrics *policyMetrics) (sampling.Decision, *policy) {
@@ -343,17 +379,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			newTraceIDs++
 			tsp.decisionBatcher.AddToCurrentBatch(id)
 			tsp.numTracesOnMap.Add(1)
-			postDeletion := false
-			currTime := time.Now()
-			for !postDeletion {
-				select {
-				case tsp.deleteChan <- id:
-					postDeletion = true
-				default:
-					traceKeyToDrop := <-tsp.deleteChan
-					tsp.dropTrace(traceKeyToDrop, currTime)
-				}
-			}
+			tsp.enqueueForDeletion(id, time.Now())
 		}
 
 		for i, p := range tsp.policies {
@@ -405,16 +431,41 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		if err := tsp.setStorageClient(ctx, host); err != nil {
+			return err
+		}
+		if err := tsp.restore(ctx); err != nil {
+			return err
+		}
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
-	return nil
+	if tsp.storageClient == nil {
+		return nil
+	}
+	return multierr.Combine(tsp.checkpoint(ctx), tsp.storageClient.Close(ctx))
+}
+
+// enqueueForDeletion registers the trace to be dropped once the maximum number of traces is exceeded,
+// dropping the oldest traces to make room for it if needed.
+func (tsp *tailSamplingSpanProcessor) enqueueForDeletion(id pcommon.TraceID, currTime time.Time) {
+	for {
+		select {
+		case tsp.deleteChan <- id:
+			return
+		default:
+			traceKeyToDrop := <-tsp.deleteChan
+			tsp.dropTrace(traceKeyToDrop, currTime)
+		}
+	}
 }
 
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
@@ -25,12 +25,15 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
@@ -569,6 +572,68 @@ func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	}
 }
 
+func TestPendingTracesAreRestored(t *testing.T) {
+	const maxSize = 100
+	const decisionWaitSeconds = 5
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+
+	newProcessor := func(next consumer.Traces, mpe *mockPolicyEvaluator) *tailSamplingSpanProcessor {
+		return &tailSamplingSpanProcessor{
+			ctx:             context.Background(),
+			nextConsumer:    next,
+			maxNumTraces:    maxSize,
+			logger:          zap.NewNop(),
+			decisionBatcher: newSyncIDBatcher(decisionWaitSeconds),
+			policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+			deleteChan:      make(chan pcommon.TraceID, maxSize),
+			policyTicker:    &manualTTicker{},
+			tickerFrequency: time.Second,
+			numTracesOnMap:  atomic.NewUint64(0),
+			decisionWait:    decisionWaitSeconds * time.Second,
+			storageID:       &storageID,
+			componentID:     config.NewComponentID(typeStr),
+			checkpointTicks: decisionWaitSeconds,
+		}
+	}
+
+	// the first instance receives the traces, but is shut down before making a decision
+	tsp := newProcessor(consumertest.NewNop(), &mockPolicyEvaluator{})
+	require.NoError(t, tsp.Start(context.Background(), host))
+	traceIds, batches := generateIdsAndBatches(10)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+	tsp.samplingPolicyOnTick()
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	// the second instance restores the traces and decides on them once the decision wait expires
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp = newProcessor(msp, mpe)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	require.EqualValues(t, len(traceIds), tsp.numTracesOnMap.Load())
+
+	for i := 0; i < decisionWaitSeconds-2; i++ {
+		tsp.samplingPolicyOnTick()
+		require.Zero(t, mpe.EvaluationCount, "restored traces were evaluated before their decision wait expired")
+	}
+	for i := 0; i < 2; i++ {
+		tsp.samplingPolicyOnTick()
+	}
+	require.Equal(t, len(traceIds), mpe.EvaluationCount)
+	require.Equal(t, len(traceIds), len(msp.AllTraces()))
+
+	var sampledIds []pcommon.TraceID
+	for _, td := range msp.AllTraces() {
+		sampledIds = append(sampledIds, td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+	}
+	require.ElementsMatch(t, traceIds, sampledIds)
+}
+
 func collectSpanIds(trace ptrace.Traces) []pcommon.SpanID {
 	var spanIDs []pcommon.SpanID
 
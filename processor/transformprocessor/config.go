@@ -21,7 +21,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
@@ -35,9 +37,11 @@ type Config struct {
 }
 
 type OTTLConfig struct {
-	Traces  SignalConfig `mapstructure:"traces"`
-	Metrics SignalConfig `mapstructure:"metrics"`
-	Logs    SignalConfig `mapstructure:"logs"`
+	Traces     SignalConfig `mapstructure:"traces"`
+	SpanEvents SignalConfig `mapstructure:"span_events"`
+	Metrics    SignalConfig `mapstructure:"metrics"`
+	Exemplars  SignalConfig `mapstructure:"exemplars"`
+	Logs       SignalConfig `mapstructure:"logs"`
 }
 
 type SignalConfig struct {
@@ -55,12 +59,24 @@ func (c *Config) Validate() error {
 		errors = multierr.Append(errors, err)
 	}
 
+	ottlspaneventsp := ottlspanevent.NewParser(traces.SpanEventFunctions(), component.TelemetrySettings{Logger: zap.NewNop()})
+	_, err = ottlspaneventsp.ParseStatements(c.SpanEvents.Statements)
+	if err != nil {
+		errors = multierr.Append(errors, err)
+	}
+
 	ottlmetricsp := ottldatapoints.NewParser(metrics.Functions(), component.TelemetrySettings{Logger: zap.NewNop()})
 	_, err = ottlmetricsp.ParseStatements(c.Metrics.Statements)
 	if err != nil {
 		errors = multierr.Append(errors, err)
 	}
 
+	ottlexemplarsp := ottlexemplar.NewParser(metrics.ExemplarFunctions(), component.TelemetrySettings{Logger: zap.NewNop()})
+	_, err = ottlexemplarsp.ParseStatements(c.Exemplars.Statements)
+	if err != nil {
+		errors = multierr.Append(errors, err)
+	}
+
 	ottllogsp := ottllogs.NewParser(logs.Functions(), component.TelemetrySettings{Logger: zap.NewNop()})
 	_, err = ottllogsp.ParseStatements(c.Logs.Statements)
 	if err != nil {
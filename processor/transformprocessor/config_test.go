@@ -43,12 +43,23 @@ func TestLoadConfig(t *testing.T) {
 							`keep_keys(attributes, ["http.method", "http.path"])`,
 						},
 					},
+					SpanEvents: SignalConfig{
+						Statements: []string{
+							`replace_pattern(attributes["exception.message"], "password=[^ ]+", "password=****") where name == "exception"`,
+							`drop() where name == "cache.miss"`,
+						},
+					},
 					Metrics: SignalConfig{
 						Statements: []string{
 							`set(metric.name, "bear") where attributes["http.path"] == "/animal"`,
 							`keep_keys(attributes, ["http.method", "http.path"])`,
 						},
 					},
+					Exemplars: SignalConfig{
+						Statements: []string{
+							`delete_key(filtered_attributes, "user.id")`,
+						},
+					},
 					Logs: SignalConfig{
 						Statements: []string{
 							`set(body, "bear") where attributes["http.path"] == "/animal"`,
@@ -75,6 +86,18 @@ func TestLoadConfig(t *testing.T) {
 			id:           config.NewComponentIDWithName(typeStr, "unknown_function_metric"),
 			errorMessage: "undefined function not_a_function",
 		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "unknown_function_span_event"),
+			errorMessage: "undefined function not_a_function",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "drop_in_traces"),
+			errorMessage: "undefined function drop",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "unknown_function_exemplar"),
+			errorMessage: "undefined function not_a_function",
+		},
 		{
 			id:           config.NewComponentIDWithName(typeStr, "bad_syntax_log"),
 			errorMessage: "1:18: unexpected token \"where\" (expected \")\")",
@@ -21,6 +21,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
@@ -55,9 +57,15 @@ func createDefaultConfig() config.Processor {
 			Traces: SignalConfig{
 				Statements: []string{},
 			},
+			SpanEvents: SignalConfig{
+				Statements: []string{},
+			},
 			Metrics: SignalConfig{
 				Statements: []string{},
 			},
+			Exemplars: SignalConfig{
+				Statements: []string{},
+			},
 		},
 	}
 }
@@ -95,12 +103,22 @@ func createTracesProcessor(
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
+	spanEventProc, err := traces.NewSpanEventProcessor(oCfg.SpanEvents.Statements, traces.SpanEventFunctions(), set.TelemetrySettings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
 	return processorhelper.NewTracesProcessor(
 		ctx,
 		set,
 		cfg,
 		nextConsumer,
-		proc.ProcessTraces,
+		func(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+			td, err := proc.ProcessTraces(ctx, td)
+			if err != nil {
+				return td, err
+			}
+			return spanEventProc.ProcessTraces(ctx, td)
+		},
 		processorhelper.WithCapabilities(processorCapabilities))
 }
 
@@ -116,11 +134,21 @@ func createMetricsProcessor(
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
+	exemplarProc, err := metrics.NewExemplarProcessor(oCfg.Exemplars.Statements, metrics.ExemplarFunctions(), set.TelemetrySettings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
+	}
 	return processorhelper.NewMetricsProcessor(
 		ctx,
 		set,
 		cfg,
 		nextConsumer,
-		proc.ProcessMetrics,
+		func(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+			md, err := proc.ProcessMetrics(ctx, md)
+			if err != nil {
+				return md, err
+			}
+			return exemplarProc.ProcessMetrics(ctx, md)
+		},
 		processorhelper.WithCapabilities(processorCapabilities))
 }
@@ -42,9 +42,15 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 			Traces: SignalConfig{
 				Statements: []string{},
 			},
+			SpanEvents: SignalConfig{
+				Statements: []string{},
+			},
 			Metrics: SignalConfig{
 				Statements: []string{},
 			},
+			Exemplars: SignalConfig{
+				Statements: []string{},
+			},
 			Logs: SignalConfig{
 				Statements: []string{},
 			},
@@ -95,6 +101,40 @@ func TestFactoryCreateTracesProcessor(t *testing.T) {
 	assert.Equal(t, "pass", val.Str())
 }
 
+func TestFactoryCreateTracesProcessor_SpanEvents(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Traces.Statements = []string{`set(attributes["test"], "pass") where name == "operationA"`}
+	oCfg.SpanEvents.Statements = []string{`drop() where name == "cache.miss" and span.attributes["test"] == "pass"`}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.NotNil(t, tp)
+	assert.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("operationA")
+	span.Events().AppendEmpty().SetName("cache.miss")
+	span.Events().AppendEmpty().SetName("exception")
+
+	err = tp.ConsumeTraces(context.Background(), td)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, span.Events().Len())
+	assert.Equal(t, "exception", span.Events().At(0).Name())
+}
+
+func TestFactoryCreateTracesProcessor_InvalidSpanEventActions(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.SpanEvents.Statements = []string{`set(123`}
+	ap, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, ap)
+}
+
 func TestFactoryCreateMetricsProcessor_InvalidActions(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
@@ -130,6 +170,29 @@ func TestFactoryCreateMetricsProcessor(t *testing.T) {
 	assert.Equal(t, "pass", val.Str())
 }
 
+func TestFactoryCreateMetricsProcessor_Exemplars(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Exemplars.Statements = []string{`delete_key(filtered_attributes, "user.id") where metric.name == "operationA"`}
+
+	metricsProcessor, err := factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.NotNil(t, metricsProcessor)
+	assert.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("operationA")
+	exemplar := metric.SetEmptySum().DataPoints().AppendEmpty().Exemplars().AppendEmpty()
+	exemplar.FilteredAttributes().PutStr("user.id", "1234")
+
+	err = metricsProcessor.ConsumeMetrics(context.Background(), metrics)
+	assert.NoError(t, err)
+
+	_, ok := exemplar.FilteredAttributes().Get("user.id")
+	assert.False(t, ok)
+}
+
 func TestFactoryCreateLogsProcessor(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// dropped is the value returned by the drop function. Processors that support dropping
+// check for it with IsDropped and remove the item being processed.
+type dropped struct{}
+
+func Drop[K any]() (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		return dropped{}, nil
+	}, nil
+}
+
+// IsDropped reports whether a statement result was produced by the drop function.
+func IsDropped(val interface{}) bool {
+	_, ok := val.(dropped)
+	return ok
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Drop(t *testing.T) {
+	exprFunc, err := Drop[interface{}]()
+	assert.NoError(t, err)
+
+	result, err := exprFunc(nil)
+	assert.NoError(t, err)
+	assert.True(t, IsDropped(result))
+}
+
+func Test_IsDropped_false(t *testing.T) {
+	assert.False(t, IsDropped(nil))
+	assert.False(t, IsDropped("dropped"))
+}
@@ -26,6 +26,17 @@ func Functions[K any]() map[string]interface{} {
 		"Concat":               ottlfuncs.Concat[K],
 		"Split":                ottlfuncs.Split[K],
 		"Int":                  ottlfuncs.Int[K],
+		"Double":               ottlfuncs.Double[K],
+		"Round":                ottlfuncs.Round[K],
+		"Log":                  ottlfuncs.Log[K],
+		"Duration":             ottlfuncs.Duration[K],
+		"ParseJSON":            ottlfuncs.ParseJSON[K],
+		"ParseKeyValue":        ottlfuncs.ParseKeyValue[K],
+		"ParseURL":             ottlfuncs.ParseURL[K],
+		"ParseUserAgent":       ottlfuncs.ParseUserAgent[K],
+		"ExtractPatterns":      ottlfuncs.ExtractPatterns[K],
+		"SHA256":               ottlfuncs.SHA256[K],
+		"FNV":                  ottlfuncs.FNV[K],
 		"keep_keys":            ottlfuncs.KeepKeys[K],
 		"set":                  ottlfuncs.Set[K],
 		"truncate_all":         ottlfuncs.TruncateAll[K],
@@ -36,5 +47,9 @@ func Functions[K any]() map[string]interface{} {
 		"replace_all_patterns": ottlfuncs.ReplaceAllPatterns[K],
 		"delete_key":           ottlfuncs.DeleteKey[K],
 		"delete_matching_keys": ottlfuncs.DeleteMatchingKeys[K],
+		"merge_maps":           ottlfuncs.MergeMaps[K],
+		"flatten":              ottlfuncs.Flatten[K],
+		"rename_key":           ottlfuncs.RenameKey[K],
+		"copy_key":             ottlfuncs.CopyKey[K],
 	}
 }
@@ -193,6 +193,68 @@ func TestProcess(t *testing.T) {
 			statement: `set(attributes["test"], Split(attributes["not_exist"], "|"))`,
 			want:      func(td plog.Logs) {},
 		},
+		{
+			statement: `set(attributes["url"], ParseURL(attributes["http.url"])) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				url := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutEmptyMap("url")
+				url.PutStr("url.original", "http://localhost/health")
+				url.PutStr("url.scheme", "http")
+				url.PutStr("url.domain", "localhost")
+				url.PutStr("url.path", "/health")
+			},
+		},
+		{
+			statement: `set(attributes["user_agent"], ParseUserAgent("curl/7.85.0")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				userAgent := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutEmptyMap("user_agent")
+				userAgent.PutStr("user_agent.original", "curl/7.85.0")
+				userAgent.PutStr("user_agent.name", "curl")
+				userAgent.PutStr("user_agent.version", "7.85.0")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ExtractPatterns(body, "operation(?P<operation>[A-Z])"))`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutEmptyMap("test").PutStr("operation", "A")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutEmptyMap("test").PutStr("operation", "B")
+			},
+		},
+		{
+			statement: `set(body, ParseKeyValue(Concat(["method", attributes["http.method"]], "="), "=", " ")) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Body().SetEmptyMap().PutStr("method", "get")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseJSON(body))`,
+			want:      func(td plog.Logs) {},
+		},
+		{
+			statement: `merge_maps(attributes, ParseKeyValue("http.method=post user=bear", "=", " "), "upsert") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("http.method", "post")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("user", "bear")
+			},
+		},
+		{
+			statement: `rename_key(attributes, "http.url", "url") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("url", "http://localhost/health")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Remove("http.url")
+			},
+		},
+		{
+			statement: `copy_key(attributes, "http.url", "url") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("url", "http://localhost/health")
+			},
+		},
+		{
+			statement: `set(attributes["http.path"], SHA256(attributes["http.path"])) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("http.path", "0587c50e302cd55b995100e6e49c0789939b48cd57b63503b22b8ce34544370f")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+)
+
+type ExemplarProcessor struct {
+	statements []*ottl.Statement[ottlexemplar.TransformContext]
+}
+
+func NewExemplarProcessor(statements []string, functions map[string]interface{}, settings component.TelemetrySettings) (*ExemplarProcessor, error) {
+	ottlp := ottlexemplar.NewParser(functions, settings)
+	parsedStatements, err := ottlp.ParseStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+	return &ExemplarProcessor{
+		statements: parsedStatements,
+	}, nil
+}
+
+func (p *ExemplarProcessor) ProcessMetrics(_ context.Context, td pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < td.ResourceMetrics().Len(); i++ {
+		rmetrics := td.ResourceMetrics().At(i)
+		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
+			smetrics := rmetrics.ScopeMetrics().At(j)
+			metrics := smetrics.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				var err error
+				switch metric.Type() {
+				case pmetric.MetricTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len() && err == nil; l++ {
+						err = p.handleExemplars(dps.At(l).Exemplars(), metric, smetrics.Scope(), rmetrics.Resource())
+					}
+				case pmetric.MetricTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len() && err == nil; l++ {
+						err = p.handleExemplars(dps.At(l).Exemplars(), metric, smetrics.Scope(), rmetrics.Resource())
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len() && err == nil; l++ {
+						err = p.handleExemplars(dps.At(l).Exemplars(), metric, smetrics.Scope(), rmetrics.Resource())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len() && err == nil; l++ {
+						err = p.handleExemplars(dps.At(l).Exemplars(), metric, smetrics.Scope(), rmetrics.Resource())
+					}
+				}
+				if err != nil {
+					return td, err
+				}
+			}
+		}
+	}
+	return td, nil
+}
+
+func (p *ExemplarProcessor) handleExemplars(exemplars pmetric.ExemplarSlice, metric pmetric.Metric, is pcommon.InstrumentationScope, resource pcommon.Resource) error {
+	var err error
+	exemplars.RemoveIf(func(exemplar pmetric.Exemplar) bool {
+		if err != nil {
+			return false
+		}
+		ctx := ottlexemplar.NewTransformContext(exemplar, metric, is, resource)
+		var drop bool
+		drop, err = p.callFunctions(ctx)
+		return drop
+	})
+	return err
+}
+
+// callFunctions executes the statements against the exemplar and reports whether it was dropped.
+// Once an exemplar is dropped the remaining statements are not executed.
+func (p *ExemplarProcessor) callFunctions(ctx ottlexemplar.TransformContext) (bool, error) {
+	for _, statement := range p.statements {
+		result, _, err := statement.Execute(ctx)
+		if err != nil {
+			return false, err
+		}
+		if common.IsDropped(result) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestExemplarProcess(t *testing.T) {
+	tests := []struct {
+		statements []string
+		want       func(pmetric.Metrics)
+	}{
+		{
+			statements: []string{`delete_key(filtered_attributes, "user.id")`},
+			want: func(td pmetric.Metrics) {
+				sumExemplars(td).At(0).FilteredAttributes().Remove("user.id")
+				histogramExemplars(td).At(0).FilteredAttributes().Remove("user.id")
+			},
+		},
+		{
+			statements: []string{`drop() where value_double < 1.0`},
+			want: func(td pmetric.Metrics) {
+				sumExemplars(td).RemoveIf(func(exemplar pmetric.Exemplar) bool {
+					return exemplar.DoubleValue() < 1.0
+				})
+			},
+		},
+		{
+			statements: []string{`drop() where metric.name == "operationB"`},
+			want: func(td pmetric.Metrics) {
+				histogramExemplars(td).RemoveIf(func(pmetric.Exemplar) bool {
+					return true
+				})
+			},
+		},
+		{
+			statements: []string{`set(filtered_attributes["metric"], metric.name) where trace_id.string == "0102030405060708090a0b0c0d0e0f10"`},
+			want: func(td pmetric.Metrics) {
+				sumExemplars(td).At(0).FilteredAttributes().PutStr("metric", "operationA")
+			},
+		},
+		{
+			statements: []string{
+				`drop() where metric.name == "operationB"`,
+				`set(filtered_attributes["test"], "pass") where metric.name == "operationB"`,
+			},
+			want: func(td pmetric.Metrics) {
+				histogramExemplars(td).RemoveIf(func(pmetric.Exemplar) bool {
+					return true
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statements[0], func(t *testing.T) {
+			td := constructExemplarMetrics()
+			processor, err := NewExemplarProcessor(tt.statements, ExemplarFunctions(), componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessMetrics(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructExemplarMetrics()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func TestExemplarProcess_dropIsNotAvailableForDataPoints(t *testing.T) {
+	_, err := NewProcessor([]string{`drop()`}, Functions(), componenttest.NewNopTelemetrySettings())
+	assert.Error(t, err)
+}
+
+func sumExemplars(td pmetric.Metrics) pmetric.ExemplarSlice {
+	return td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Exemplars()
+}
+
+func histogramExemplars(td pmetric.Metrics) pmetric.ExemplarSlice {
+	return td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0).Exemplars()
+}
+
+func constructExemplarMetrics() pmetric.Metrics {
+	td := constructMetrics()
+
+	sumExemplar0 := sumExemplars(td).AppendEmpty()
+	sumExemplar0.SetDoubleValue(2.5)
+	sumExemplar0.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	sumExemplar0.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	sumExemplar0.FilteredAttributes().PutStr("user.id", "1234")
+	sumExemplars(td).AppendEmpty().SetDoubleValue(0.5)
+
+	histogramExemplar := histogramExemplars(td).AppendEmpty()
+	histogramExemplar.SetDoubleValue(3.5)
+	histogramExemplar.FilteredAttributes().PutStr("user.id", "5678")
+	return td
+}
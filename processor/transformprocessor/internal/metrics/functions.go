@@ -16,6 +16,7 @@ package metrics // import "github.com/open-telemetry/opentelemetry-collector-con
 
 import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
@@ -37,3 +38,9 @@ func init() {
 func Functions() map[string]interface{} {
 	return registry
 }
+
+func ExemplarFunctions() map[string]interface{} {
+	functions := common.Functions[ottlexemplar.TransformContext]()
+	functions["drop"] = common.Drop[ottlexemplar.TransformContext]
+	return functions
+}
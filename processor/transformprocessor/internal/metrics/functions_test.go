@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
@@ -38,3 +39,15 @@ func Test_DefaultFunctions(t *testing.T) {
 		assert.Contains(t, expected, k)
 	}
 }
+
+func Test_ExemplarFunctions(t *testing.T) {
+	expected := common.Functions[ottlexemplar.TransformContext]()
+	expected["drop"] = common.Drop[ottlexemplar.TransformContext]
+
+	actual := ExemplarFunctions()
+
+	require.Equal(t, len(expected), len(actual))
+	for k := range actual {
+		assert.Contains(t, expected, k)
+	}
+}
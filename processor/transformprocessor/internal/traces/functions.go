@@ -15,6 +15,7 @@
 package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/traces"
 
 import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
@@ -23,3 +24,9 @@ func Functions() map[string]interface{} {
 	// No trace-only functions yet.
 	return common.Functions[ottltraces.TransformContext]()
 }
+
+func SpanEventFunctions() map[string]interface{} {
+	functions := common.Functions[ottlspanevent.TransformContext]()
+	functions["drop"] = common.Drop[ottlspanevent.TransformContext]
+	return functions
+}
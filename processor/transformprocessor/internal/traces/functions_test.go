@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
@@ -32,3 +33,13 @@ func Test_DefaultFunctions(t *testing.T) {
 		assert.Contains(t, expected, k)
 	}
 }
+
+func Test_SpanEventFunctions(t *testing.T) {
+	expected := common.Functions[ottlspanevent.TransformContext]()
+	expected["drop"] = common.Drop[ottlspanevent.TransformContext]
+	actual := SpanEventFunctions()
+	require.Equal(t, len(expected), len(actual))
+	for k := range actual {
+		assert.Contains(t, expected, k)
+	}
+}
@@ -234,6 +234,24 @@ func TestProcess(t *testing.T) {
 			statement: `set(attributes["test"], Split(attributes["not_exist"], "|"))`,
 			want:      func(td ptrace.Traces) {},
 		},
+		{
+			statement: `set(attributes["duration"], Duration(end_time_unix_nano - start_time_unix_nano)) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutInt("duration", 1000000468)
+			},
+		},
+		{
+			statement: `set(attributes["slow"], true) where name == "operationA" and Duration(end_time_unix_nano - start_time_unix_nano) > Duration("1s")`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutBool("slow", true)
+			},
+		},
+		{
+			statement: `set(attributes["duration_ms"], Round(Double(end_time_unix_nano - start_time_unix_nano) / 1000000, 1)) where name == "operationA"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutDouble("duration_ms", 1000)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/traces"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+)
+
+type SpanEventProcessor struct {
+	statements []*ottl.Statement[ottlspanevent.TransformContext]
+}
+
+func NewSpanEventProcessor(statements []string, functions map[string]interface{}, settings component.TelemetrySettings) (*SpanEventProcessor, error) {
+	ottlp := ottlspanevent.NewParser(functions, settings)
+	parsedStatements, err := ottlp.ParseStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+	return &SpanEventProcessor{
+		statements: parsedStatements,
+	}, nil
+}
+
+func (p *SpanEventProcessor) ProcessTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	var err error
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rspans := td.ResourceSpans().At(i)
+		for j := 0; j < rspans.ScopeSpans().Len(); j++ {
+			sspan := rspans.ScopeSpans().At(j)
+			spans := sspan.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				span.Events().RemoveIf(func(spanEvent ptrace.SpanEvent) bool {
+					if err != nil {
+						return false
+					}
+					ctx := ottlspanevent.NewTransformContext(spanEvent, span, sspan.Scope(), rspans.Resource())
+					var drop bool
+					drop, err = p.callFunctions(ctx)
+					return drop
+				})
+				if err != nil {
+					return td, err
+				}
+			}
+		}
+	}
+	return td, nil
+}
+
+// callFunctions executes the statements against the span event and reports whether it was dropped.
+// Once an event is dropped the remaining statements are not executed.
+func (p *SpanEventProcessor) callFunctions(ctx ottlspanevent.TransformContext) (bool, error) {
+	for _, statement := range p.statements {
+		result, _, err := statement.Execute(ctx)
+		if err != nil {
+			return false, err
+		}
+		if common.IsDropped(result) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
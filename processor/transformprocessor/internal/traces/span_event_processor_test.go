@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestSpanEventProcess(t *testing.T) {
+	tests := []struct {
+		statements []string
+		want       func(td ptrace.Traces)
+	}{
+		{
+			statements: []string{`replace_pattern(attributes["exception.message"], "user=[^ ]+", "user=****") where name == "exception"`},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().At(0).Attributes().PutStr("exception.message", "login failed for user=****")
+			},
+		},
+		{
+			statements: []string{`delete_key(attributes, "exception.stacktrace")`},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().At(0).Attributes().Remove("exception.stacktrace")
+			},
+		},
+		{
+			statements: []string{`drop() where name == "cache.miss"`},
+			want: func(td ptrace.Traces) {
+				removeSpanEvents(td, "cache.miss")
+			},
+		},
+		{
+			statements: []string{`drop() where span.name == "operationB"`},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Events().RemoveIf(func(ptrace.SpanEvent) bool {
+					return true
+				})
+			},
+		},
+		{
+			statements: []string{`set(attributes["service"], resource.attributes["service.name"]) where name == "exception"`},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().At(0).Attributes().PutStr("service", "checkout")
+			},
+		},
+		{
+			statements: []string{
+				`drop() where name == "cache.miss"`,
+				`set(attributes["test"], "pass") where name == "cache.miss"`,
+			},
+			want: func(td ptrace.Traces) {
+				removeSpanEvents(td, "cache.miss")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statements[0], func(t *testing.T) {
+			td := constructSpanEventTraces()
+			processor, err := NewSpanEventProcessor(tt.statements, SpanEventFunctions(), componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessTraces(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructSpanEventTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func TestSpanEventProcess_dropIsNotAvailableForSpans(t *testing.T) {
+	_, err := NewProcessor([]string{`drop()`}, Functions(), componenttest.NewNopTelemetrySettings())
+	assert.Error(t, err)
+}
+
+func removeSpanEvents(td ptrace.Traces, name string) {
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		spans.At(i).Events().RemoveIf(func(spanEvent ptrace.SpanEvent) bool {
+			return spanEvent.Name() == name
+		})
+	}
+}
+
+func constructSpanEventTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs0 := td.ResourceSpans().AppendEmpty()
+	rs0.Resource().Attributes().PutStr("service.name", "checkout")
+	rs0ils0 := rs0.ScopeSpans().AppendEmpty()
+
+	span0 := rs0ils0.Spans().AppendEmpty()
+	fillSpanOne(span0)
+	exception := span0.Events().AppendEmpty()
+	exception.SetName("exception")
+	exception.Attributes().PutStr("exception.message", "login failed for user=jane.doe@example.com")
+	exception.Attributes().PutStr("exception.stacktrace", "at com.example.Login(Login.java:42)")
+	span0.Events().AppendEmpty().SetName("cache.miss")
+	span0.Events().AppendEmpty().SetName("retry")
+
+	span1 := rs0ils0.Spans().AppendEmpty()
+	fillSpanTwo(span1)
+	span1.Events().AppendEmpty().SetName("cache.miss")
+	return td
+}
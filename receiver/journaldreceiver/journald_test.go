@@ -78,6 +78,7 @@ func testdataConfigYaml() *JournaldConfig {
 			c.Priority = "info"
 			dir := "/run/log/journal"
 			c.Directory = &dir
+			c.Reader = "native"
 			return *c
 		}(),
 	}
@@ -136,6 +136,9 @@ func testdataConfigYamlAsMap() *Config {
 			FingerprintSize:         1000,
 			MaxLogSize:              1024 * 1024,
 			MaxConcurrentFiles:      1024,
+			OnComplete: fileconsumer.OnCompleteConfig{
+				IdlePeriod: time.Minute,
+			},
 			Finder: fileconsumer.Finder{
 				Include: []string{"/var/log/*.log"},
 				Exclude: []string{"/var/log/example.log"},
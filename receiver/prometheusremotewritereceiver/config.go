@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for the Prometheus remote write receiver.
+type Config struct {
+	config.ReceiverSettings       `mapstructure:",squash"`
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Path is the URL path remote write requests are accepted on.
+	Path string `mapstructure:"path"`
+
+	// MaxDecodedBodySize is the maximum size in bytes of the body of a request once decompressed.
+	MaxDecodedBodySize int64 `mapstructure:"max_decoded_body_size"`
+}
+
+func (cfg *Config) Validate() error {
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return errors.New("\"path\" must start with /")
+	}
+	// the size of the request body is limited by the HTTP server
+	if cfg.MaxRequestBodySize <= 0 {
+		return errors.New("\"max_request_body_size\" must be positive")
+	}
+	if cfg.MaxDecodedBodySize <= 0 {
+		return errors.New("\"max_decoded_body_size\" must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,578 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	targetInfoName = "target_info"
+	traceIDKey     = "trace_id"
+	spanIDKey      = "span_id"
+
+	bucketSuffix = "_bucket"
+	countSuffix  = "_count"
+	sumSuffix    = "_sum"
+	totalSuffix  = "_total"
+)
+
+type metricKind int
+
+const (
+	kindGauge metricKind = iota
+	kindSum
+	kindHistogram
+	kindSummary
+)
+
+// seriesPart is the role of a series within its metric family.
+type seriesPart int
+
+const (
+	partValue seriesPart = iota
+	partBucket
+	partCount
+	partSum
+	partQuantile
+)
+
+// writeRequestToMetrics converts the series of a remote write request into
+// metrics. Series are grouped into a resource per job and instance, and
+// the type of each metric family is taken from the request metadata or
+// inferred from the series names and labels.
+func writeRequestToMetrics(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	c := newConverter(req)
+	for i := range req.Timeseries {
+		if err := c.addSeries(&req.Timeseries[i]); err != nil {
+			return pmetric.NewMetrics(), err
+		}
+	}
+	return c.finish(), nil
+}
+
+type resourceKey struct {
+	job      string
+	instance string
+}
+
+type converter struct {
+	metadata map[string]prompb.MetricMetadata
+	// histograms holds the families with _bucket series carrying an le label.
+	histograms map[string]bool
+	// summaries holds the families with series carrying a quantile label or
+	// with both _count and _sum series.
+	summaries map[string]bool
+
+	md        pmetric.Metrics
+	resources map[resourceKey]*resourceBuilder
+}
+
+type resourceBuilder struct {
+	scopeMetrics pmetric.ScopeMetrics
+	attributes   pcommon.Map
+	metrics      map[metricKey]*metricBuilder
+	order        []*metricBuilder
+}
+
+type metricKey struct {
+	name string
+	kind metricKind
+}
+
+type metricBuilder struct {
+	metric pmetric.Metric
+	kind   metricKind
+	// points holds the histogram or summary points, which are only complete
+	// once all the series of the family have been read.
+	points     map[string]*compoundPoint
+	pointOrder []*compoundPoint
+}
+
+type bucket struct {
+	bound float64
+	count float64
+}
+
+// compoundPoint collects the series of a histogram or summary point.
+type compoundPoint struct {
+	attributes []prompb.Label
+	timestamp  int64
+	count      *float64
+	sum        *float64
+	buckets    []bucket
+	quantiles  []bucket
+	stale      bool
+	exemplars  []prompb.Exemplar
+}
+
+func newConverter(req *prompb.WriteRequest) *converter {
+	c := &converter{
+		metadata:   make(map[string]prompb.MetricMetadata, len(req.Metadata)),
+		histograms: map[string]bool{},
+		summaries:  map[string]bool{},
+		md:         pmetric.NewMetrics(),
+		resources:  map[resourceKey]*resourceBuilder{},
+	}
+	for _, m := range req.Metadata {
+		c.metadata[m.MetricFamilyName] = m
+	}
+
+	names := map[string]bool{}
+	for i := range req.Timeseries {
+		lbls := req.Timeseries[i].Labels
+		name := labelValue(lbls, model.MetricNameLabel)
+		names[name] = true
+		if base, ok := trimSuffix(name, bucketSuffix); ok && hasLabel(lbls, model.BucketLabel) {
+			c.histograms[base] = true
+		}
+		if hasLabel(lbls, model.QuantileLabel) {
+			c.summaries[name] = true
+		}
+	}
+	for name := range names {
+		if base, ok := trimSuffix(name, countSuffix); ok && names[base+sumSuffix] && !c.histograms[base] {
+			c.summaries[base] = true
+		}
+	}
+	return c
+}
+
+func (c *converter) addSeries(ts *prompb.TimeSeries) error {
+	name := labelValue(ts.Labels, model.MetricNameLabel)
+	if name == "" {
+		return fmt.Errorf("series without %s label", model.MetricNameLabel)
+	}
+	key := resourceKey{
+		job:      labelValue(ts.Labels, model.JobLabel),
+		instance: labelValue(ts.Labels, model.InstanceLabel),
+	}
+	rb := c.resource(key)
+
+	if name == targetInfoName {
+		for _, l := range ts.Labels {
+			if isResourceLabel(l.Name) {
+				continue
+			}
+			if _, ok := rb.attributes.Get(l.Name); !ok {
+				rb.attributes.PutStr(l.Name, l.Value)
+			}
+		}
+		return nil
+	}
+
+	family, kind, part := c.classify(name, ts.Labels)
+	mb := c.metric(rb, family, kind)
+
+	var attrs []prompb.Label
+	var partLabel string
+	for _, l := range ts.Labels {
+		switch {
+		case isResourceLabel(l.Name):
+		case part == partBucket && l.Name == model.BucketLabel,
+			part == partQuantile && l.Name == model.QuantileLabel:
+			partLabel = l.Value
+		default:
+			attrs = append(attrs, l)
+		}
+	}
+
+	if kind == kindGauge || kind == kindSum {
+		var dps pmetric.NumberDataPointSlice
+		if kind == kindGauge {
+			dps = mb.metric.Gauge().DataPoints()
+		} else {
+			dps = mb.metric.Sum().DataPoints()
+		}
+		for _, s := range ts.Samples {
+			dp := dps.AppendEmpty()
+			dp.SetTimestamp(fromMillis(s.Timestamp))
+			putAttributes(dp.Attributes(), attrs)
+			if value.IsStaleNaN(s.Value) {
+				dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			} else {
+				dp.SetDoubleValue(s.Value)
+			}
+		}
+		if len(ts.Samples) > 0 {
+			exemplars := dps.At(dps.Len() - 1).Exemplars()
+			for _, e := range ts.Exemplars {
+				convertExemplar(e, exemplars.AppendEmpty())
+			}
+		}
+		return nil
+	}
+
+	var bound float64
+	if part == partBucket || part == partQuantile {
+		var err error
+		if bound, err = strconv.ParseFloat(partLabel, 64); err != nil {
+			return fmt.Errorf("invalid label value %q for series %s: %w", partLabel, name, err)
+		}
+	}
+
+	signature := labelsSignature(attrs)
+	var last *compoundPoint
+	for _, s := range ts.Samples {
+		p := mb.point(signature, attrs, s.Timestamp)
+		last = p
+		if value.IsStaleNaN(s.Value) {
+			p.stale = true
+			continue
+		}
+		v := s.Value
+		switch part {
+		case partBucket:
+			p.buckets = append(p.buckets, bucket{bound: bound, count: v})
+		case partQuantile:
+			p.quantiles = append(p.quantiles, bucket{bound: bound, count: v})
+		case partCount:
+			p.count = &v
+		case partSum:
+			p.sum = &v
+		}
+	}
+	if last != nil {
+		last.exemplars = append(last.exemplars, ts.Exemplars...)
+	}
+	return nil
+}
+
+// classify returns the family, the type and the role of a series.
+func (c *converter) classify(name string, lbls []prompb.Label) (string, metricKind, seriesPart) {
+	if md, ok := c.metadata[name]; ok {
+		switch md.Type {
+		case prompb.MetricMetadata_COUNTER:
+			return name, kindSum, partValue
+		case prompb.MetricMetadata_SUMMARY:
+			if hasLabel(lbls, model.QuantileLabel) {
+				return name, kindSummary, partQuantile
+			}
+		case prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_GAUGEHISTOGRAM:
+		default:
+			return name, kindGauge, partValue
+		}
+	}
+
+	suffixes := []struct {
+		suffix string
+		part   seriesPart
+	}{
+		{bucketSuffix, partBucket},
+		{countSuffix, partCount},
+		{sumSuffix, partSum},
+	}
+	for _, s := range suffixes {
+		base, ok := trimSuffix(name, s.suffix)
+		if !ok || (s.part == partBucket && !hasLabel(lbls, model.BucketLabel)) {
+			continue
+		}
+		md, hasMetadata := c.metadata[base]
+		switch {
+		case hasMetadata && (md.Type == prompb.MetricMetadata_HISTOGRAM || md.Type == prompb.MetricMetadata_GAUGEHISTOGRAM),
+			!hasMetadata && c.histograms[base]:
+			return base, kindHistogram, s.part
+		case s.part != partBucket && ((hasMetadata && md.Type == prompb.MetricMetadata_SUMMARY) || (!hasMetadata && c.summaries[base])):
+			return base, kindSummary, s.part
+		}
+	}
+
+	if hasLabel(lbls, model.QuantileLabel) {
+		return name, kindSummary, partQuantile
+	}
+	if base, ok := trimSuffix(name, totalSuffix); ok {
+		if md, hasMetadata := c.metadata[base]; !hasMetadata || md.Type == prompb.MetricMetadata_COUNTER {
+			return name, kindSum, partValue
+		}
+	}
+	return name, kindGauge, partValue
+}
+
+func (c *converter) resource(key resourceKey) *resourceBuilder {
+	if rb, ok := c.resources[key]; ok {
+		return rb
+	}
+	rm := c.md.ResourceMetrics().AppendEmpty()
+	rb := &resourceBuilder{
+		scopeMetrics: rm.ScopeMetrics().AppendEmpty(),
+		attributes:   rm.Resource().Attributes(),
+		metrics:      map[metricKey]*metricBuilder{},
+	}
+	populateResource(rb.attributes, key)
+	c.resources[key] = rb
+	return rb
+}
+
+func (c *converter) metric(rb *resourceBuilder, name string, kind metricKind) *metricBuilder {
+	key := metricKey{name: name, kind: kind}
+	if mb, ok := rb.metrics[key]; ok {
+		return mb
+	}
+
+	m := rb.scopeMetrics.Metrics().AppendEmpty()
+	m.SetName(name)
+	md, ok := c.metadata[name]
+	if !ok {
+		if base, isTotal := trimSuffix(name, totalSuffix); isTotal {
+			md = c.metadata[base]
+		}
+	}
+	m.SetDescription(md.Help)
+	m.SetUnit(md.Unit)
+
+	switch kind {
+	case kindGauge:
+		m.SetEmptyGauge()
+	case kindSum:
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case kindHistogram:
+		m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case kindSummary:
+		m.SetEmptySummary()
+	}
+
+	mb := &metricBuilder{metric: m, kind: kind, points: map[string]*compoundPoint{}}
+	rb.metrics[key] = mb
+	rb.order = append(rb.order, mb)
+	return mb
+}
+
+func (mb *metricBuilder) point(signature string, attrs []prompb.Label, timestamp int64) *compoundPoint {
+	key := signature + "\xff" + strconv.FormatInt(timestamp, 10)
+	if p, ok := mb.points[key]; ok {
+		return p
+	}
+	p := &compoundPoint{attributes: attrs, timestamp: timestamp}
+	mb.points[key] = p
+	mb.pointOrder = append(mb.pointOrder, p)
+	return p
+}
+
+// finish builds the histogram and summary points and drops the resources
+// that only had a target_info series.
+func (c *converter) finish() pmetric.Metrics {
+	for _, rb := range c.resources {
+		for _, mb := range rb.order {
+			for _, p := range mb.pointOrder {
+				switch mb.kind {
+				case kindHistogram:
+					p.toHistogram(mb.metric.Histogram().DataPoints().AppendEmpty())
+				case kindSummary:
+					p.toSummary(mb.metric.Summary().DataPoints().AppendEmpty())
+				}
+			}
+		}
+	}
+	c.md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		return rm.ScopeMetrics().At(0).Metrics().Len() == 0
+	})
+	return c.md
+}
+
+func (p *compoundPoint) toHistogram(dp pmetric.HistogramDataPoint) {
+	dp.SetTimestamp(fromMillis(p.timestamp))
+	putAttributes(dp.Attributes(), p.attributes)
+	for _, e := range p.exemplars {
+		convertExemplar(e, dp.Exemplars().AppendEmpty())
+	}
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	// Prometheus buckets are cumulative, OTLP ones are not.
+	sort.Slice(p.buckets, func(i, j int) bool { return p.buckets[i].bound < p.buckets[j].bound })
+	var bounds []float64
+	var counts []uint64
+	var prev float64
+	infCount, hasInf := 0.0, false
+	for _, b := range p.buckets {
+		if math.IsInf(b.bound, 1) {
+			infCount, hasInf = b.count, true
+			continue
+		}
+		bounds = append(bounds, b.bound)
+		counts = append(counts, nonNegative(b.count-prev))
+		prev = b.count
+	}
+
+	count := prev
+	switch {
+	case p.count != nil:
+		count = *p.count
+	case hasInf:
+		count = infCount
+	}
+	if len(p.buckets) > 0 {
+		counts = append(counts, nonNegative(count-prev))
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(counts)
+	}
+	dp.SetCount(nonNegative(count))
+	if p.sum != nil {
+		dp.SetSum(*p.sum)
+	}
+}
+
+func (p *compoundPoint) toSummary(dp pmetric.SummaryDataPoint) {
+	dp.SetTimestamp(fromMillis(p.timestamp))
+	putAttributes(dp.Attributes(), p.attributes)
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	sort.Slice(p.quantiles, func(i, j int) bool { return p.quantiles[i].bound < p.quantiles[j].bound })
+	for _, q := range p.quantiles {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q.bound)
+		qv.SetValue(q.count)
+	}
+	if p.count != nil {
+		dp.SetCount(nonNegative(*p.count))
+	}
+	if p.sum != nil {
+		dp.SetSum(*p.sum)
+	}
+}
+
+// populateResource maps the job and instance labels to resource attributes
+// the same way the prometheus receiver does.
+func populateResource(attrs pcommon.Map, key resourceKey) {
+	if key.job != "" {
+		attrs.PutStr(conventions.AttributeServiceName, key.job)
+	}
+	if key.instance == "" {
+		return
+	}
+	attrs.PutStr(conventions.AttributeServiceInstanceID, key.instance)
+	host, port, err := net.SplitHostPort(key.instance)
+	if err != nil {
+		host = key.instance
+	}
+	if isDiscernibleHost(host) {
+		attrs.PutStr(conventions.AttributeNetHostName, host)
+	}
+	if port != "" {
+		attrs.PutStr(conventions.AttributeNetHostPort, port)
+	}
+}
+
+// isDiscernibleHost checks if a host can be used as a value for the 'host.name' key.
+// localhost-like hosts and unspecified (0.0.0.0) hosts are not discernible.
+func isDiscernibleHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return !ip.IsLoopback() && !ip.IsUnspecified()
+	}
+	return host != "localhost"
+}
+
+func convertExemplar(e prompb.Exemplar, dest pmetric.Exemplar) {
+	dest.SetTimestamp(fromMillis(e.Timestamp))
+	dest.SetDoubleValue(e.Value)
+	for _, l := range e.Labels {
+		switch l.Name {
+		case traceIDKey:
+			var traceID [16]byte
+			if b, err := hex.DecodeString(l.Value); err == nil && len(b) == len(traceID) {
+				copy(traceID[:], b)
+				dest.SetTraceID(traceID)
+				continue
+			}
+		case spanIDKey:
+			var spanID [8]byte
+			if b, err := hex.DecodeString(l.Value); err == nil && len(b) == len(spanID) {
+				copy(spanID[:], b)
+				dest.SetSpanID(spanID)
+				continue
+			}
+		}
+		dest.FilteredAttributes().PutStr(l.Name, l.Value)
+	}
+}
+
+func isResourceLabel(name string) bool {
+	return name == model.MetricNameLabel || name == model.JobLabel || name == model.InstanceLabel
+}
+
+func putAttributes(dest pcommon.Map, lbls []prompb.Label) {
+	dest.EnsureCapacity(len(lbls))
+	for _, l := range lbls {
+		dest.PutStr(l.Name, l.Value)
+	}
+}
+
+func labelsSignature(lbls []prompb.Label) string {
+	var sb strings.Builder
+	for _, l := range lbls {
+		sb.WriteString(l.Name)
+		sb.WriteByte('\xff')
+		sb.WriteString(l.Value)
+		sb.WriteByte('\xff')
+	}
+	return sb.String()
+}
+
+func labelValue(lbls []prompb.Label, name string) string {
+	for _, l := range lbls {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(lbls []prompb.Label, name string) bool {
+	for _, l := range lbls {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func trimSuffix(name, suffix string) (string, bool) {
+	if len(name) > len(suffix) && strings.HasSuffix(name, suffix) {
+		return name[:len(name)-len(suffix)], true
+	}
+	return name, false
+}
+
+func nonNegative(v float64) uint64 {
+	if v < 0 || math.IsNaN(v) {
+		return 0
+	}
+	return uint64(v)
+}
+
+func fromMillis(ms int64) pcommon.Timestamp {
+	return pcommon.Timestamp(ms * int64(time.Millisecond))
+}
@@ -0,0 +1,402 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// series creates a series from name/value label pairs and a value per
+// timestamp in milliseconds.
+func series(labels []string, samples ...prompb.Sample) prompb.TimeSeries {
+	ts := prompb.TimeSeries{Samples: samples}
+	for i := 0; i < len(labels); i += 2 {
+		ts.Labels = append(ts.Labels, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return ts
+}
+
+func sample(ts int64, v float64) prompb.Sample {
+	return prompb.Sample{Timestamp: ts, Value: v}
+}
+
+// newResourceMetrics appends a resource with the given attributes and
+// returns its metric slice.
+func newResourceMetrics(md pmetric.Metrics, attrs map[string]interface{}) pmetric.MetricSlice {
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().FromRaw(attrs)
+	return rm.ScopeMetrics().AppendEmpty().Metrics()
+}
+
+func appendNumberPoint(dps pmetric.NumberDataPointSlice, ts int64, v float64, attrs map[string]interface{}) pmetric.NumberDataPoint {
+	dp := dps.AppendEmpty()
+	dp.SetTimestamp(fromMillis(ts))
+	dp.SetDoubleValue(v)
+	dp.Attributes().FromRaw(attrs)
+	return dp
+}
+
+func newSum(ms pmetric.MetricSlice, name string) pmetric.Sum {
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	return sum
+}
+
+func newGauge(ms pmetric.MetricSlice, name string) pmetric.Gauge {
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	return m.SetEmptyGauge()
+}
+
+func newHistogram(ms pmetric.MetricSlice, name string) pmetric.Histogram {
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	h := m.SetEmptyHistogram()
+	h.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	return h
+}
+
+func newSummary(ms pmetric.MetricSlice, name string) pmetric.Summary {
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	return m.SetEmptySummary()
+}
+
+// sortAttributes sorts the resource and data point attributes, so metrics
+// built with FromRaw can be compared.
+func sortAttributes(md pmetric.Metrics) pmetric.Metrics {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		rm.Resource().Attributes().Sort()
+		ms := rm.ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			m := ms.At(j)
+			switch m.Type() {
+			case pmetric.MetricTypeGauge:
+				sortNumberAttributes(m.Gauge().DataPoints())
+			case pmetric.MetricTypeSum:
+				sortNumberAttributes(m.Sum().DataPoints())
+			case pmetric.MetricTypeHistogram:
+				for k := 0; k < m.Histogram().DataPoints().Len(); k++ {
+					m.Histogram().DataPoints().At(k).Attributes().Sort()
+				}
+			case pmetric.MetricTypeSummary:
+				for k := 0; k < m.Summary().DataPoints().Len(); k++ {
+					m.Summary().DataPoints().At(k).Attributes().Sort()
+				}
+			}
+		}
+	}
+	return md
+}
+
+func sortNumberAttributes(dps pmetric.NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).Attributes().Sort()
+	}
+}
+
+func TestWriteRequestToMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *prompb.WriteRequest
+		expected func() pmetric.Metrics
+	}{
+		{
+			name: "counter and gauge",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series([]string{"__name__", "http_requests_total", "code", "200", "instance", "10.0.0.1:8080", "job", "api"}, sample(1000, 5), sample(2000, 7)),
+					series([]string{"__name__", "temperature", "instance", "localhost:9100", "job", "node"}, sample(1000, 21.5)),
+					series([]string{"__name__", "http_requests_total", "code", "500", "instance", "10.0.0.1:8080", "job", "api"}, sample(1000, 1)),
+					series([]string{"__name__", "up"}, sample(1000, 1)),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md := pmetric.NewMetrics()
+				ms := newResourceMetrics(md, map[string]interface{}{
+					"service.name":        "api",
+					"service.instance.id": "10.0.0.1:8080",
+					"net.host.name":       "10.0.0.1",
+					"net.host.port":       "8080",
+				})
+				sum := newSum(ms, "http_requests_total")
+				appendNumberPoint(sum.DataPoints(), 1000, 5, map[string]interface{}{"code": "200"})
+				appendNumberPoint(sum.DataPoints(), 2000, 7, map[string]interface{}{"code": "200"})
+				appendNumberPoint(sum.DataPoints(), 1000, 1, map[string]interface{}{"code": "500"})
+
+				ms = newResourceMetrics(md, map[string]interface{}{
+					"service.name":        "node",
+					"service.instance.id": "localhost:9100",
+					"net.host.port":       "9100",
+				})
+				appendNumberPoint(newGauge(ms, "temperature").DataPoints(), 1000, 21.5, nil)
+
+				ms = newResourceMetrics(md, nil)
+				appendNumberPoint(newGauge(ms, "up").DataPoints(), 1000, 1, nil)
+				return md
+			},
+		},
+		{
+			name: "histogram without metadata",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series([]string{"__name__", "latency_bucket", "le", "0.1", "path", "/"}, sample(1000, 2)),
+					series([]string{"__name__", "latency_bucket", "le", "1", "path", "/"}, sample(1000, 5)),
+					series([]string{"__name__", "latency_bucket", "le", "+Inf", "path", "/"}, sample(1000, 6)),
+					series([]string{"__name__", "latency_count", "path", "/"}, sample(1000, 6)),
+					series([]string{"__name__", "latency_sum", "path", "/"}, sample(1000, 3.5)),
+					{
+						Labels: []prompb.Label{
+							{Name: "__name__", Value: "latency_bucket"},
+							{Name: "le", Value: "0.5"},
+							{Name: "path", Value: "/"},
+						},
+						Samples: []prompb.Sample{sample(1000, 4)},
+						Exemplars: []prompb.Exemplar{{
+							Labels: []prompb.Label{
+								{Name: "trace_id", Value: "0102030405060708090a0b0c0d0e0f10"},
+								{Name: "span_id", Value: "0102030405060708"},
+								{Name: "user", Value: "alice"},
+							},
+							Value:     0.3,
+							Timestamp: 900,
+						}},
+					},
+					series([]string{"__name__", "latency_bucket", "le", "1", "path", "/other"}, sample(1000, 1)),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md := pmetric.NewMetrics()
+				ms := newResourceMetrics(md, nil)
+				h := newHistogram(ms, "latency")
+				dp := h.DataPoints().AppendEmpty()
+				dp.SetTimestamp(fromMillis(1000))
+				dp.Attributes().PutStr("path", "/")
+				dp.SetCount(6)
+				dp.SetSum(3.5)
+				dp.ExplicitBounds().FromRaw([]float64{0.1, 0.5, 1})
+				dp.BucketCounts().FromRaw([]uint64{2, 2, 1, 1})
+				ex := dp.Exemplars().AppendEmpty()
+				ex.SetTimestamp(fromMillis(900))
+				ex.SetDoubleValue(0.3)
+				ex.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+				ex.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+				ex.FilteredAttributes().PutStr("user", "alice")
+
+				dp = h.DataPoints().AppendEmpty()
+				dp.SetTimestamp(fromMillis(1000))
+				dp.Attributes().PutStr("path", "/other")
+				dp.SetCount(1)
+				dp.ExplicitBounds().FromRaw([]float64{1})
+				dp.BucketCounts().FromRaw([]uint64{1, 0})
+				return md
+			},
+		},
+		{
+			name: "summaries without metadata",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series([]string{"__name__", "rpc_duration", "quantile", "0.99"}, sample(1000, 0.9)),
+					series([]string{"__name__", "rpc_duration", "quantile", "0.5"}, sample(1000, 0.2)),
+					series([]string{"__name__", "rpc_duration_count"}, sample(1000, 10)),
+					series([]string{"__name__", "rpc_duration_sum"}, sample(1000, 4)),
+					series([]string{"__name__", "gc_pause_count"}, sample(1000, 3)),
+					series([]string{"__name__", "gc_pause_sum"}, sample(1000, 0.5)),
+					series([]string{"__name__", "queue_count"}, sample(1000, 8)),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md := pmetric.NewMetrics()
+				ms := newResourceMetrics(md, nil)
+				dp := newSummary(ms, "rpc_duration").DataPoints().AppendEmpty()
+				dp.SetTimestamp(fromMillis(1000))
+				dp.SetCount(10)
+				dp.SetSum(4)
+				q := dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.5)
+				q.SetValue(0.2)
+				q = dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.99)
+				q.SetValue(0.9)
+
+				dp = newSummary(ms, "gc_pause").DataPoints().AppendEmpty()
+				dp.SetTimestamp(fromMillis(1000))
+				dp.SetCount(3)
+				dp.SetSum(0.5)
+
+				appendNumberPoint(newGauge(ms, "queue_count").DataPoints(), 1000, 8, nil)
+				return md
+			},
+		},
+		{
+			name: "metadata",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series([]string{"__name__", "processed"}, sample(1000, 12)),
+					series([]string{"__name__", "items_count"}, sample(1000, 3)),
+					series([]string{"__name__", "size_count"}, sample(1000, 2)),
+					series([]string{"__name__", "size_sum"}, sample(1000, 30)),
+					series([]string{"__name__", "errors_total"}, sample(1000, 1)),
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "processed", Help: "Processed items.", Unit: "items"},
+					{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "items_count", Help: "Queued items."},
+					{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "size", Help: "Request size.", Unit: "bytes"},
+					{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "errors", Help: "Errors."},
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md := pmetric.NewMetrics()
+				ms := newResourceMetrics(md, nil)
+				appendNumberPoint(newSum(ms, "processed").DataPoints(), 1000, 12, nil)
+				ms.At(0).SetDescription("Processed items.")
+				ms.At(0).SetUnit("items")
+
+				appendNumberPoint(newGauge(ms, "items_count").DataPoints(), 1000, 3, nil)
+				ms.At(1).SetDescription("Queued items.")
+
+				dp := newHistogram(ms, "size").DataPoints().AppendEmpty()
+				dp.SetTimestamp(fromMillis(1000))
+				dp.SetCount(2)
+				dp.SetSum(30)
+				ms.At(2).SetDescription("Request size.")
+				ms.At(2).SetUnit("bytes")
+
+				appendNumberPoint(newSum(ms, "errors_total").DataPoints(), 1000, 1, nil)
+				ms.At(3).SetDescription("Errors.")
+				return md
+			},
+		},
+		{
+			name: "target info",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series([]string{"__name__", "up", "instance", "host:80", "job", "web"}, sample(1000, 1)),
+					series([]string{"__name__", "target_info", "instance", "host:80", "job", "web", "region", "eu", "service_name", "ignored"}, sample(1000, 1)),
+					series([]string{"__name__", "target_info", "instance", "other:80", "job", "web", "region", "us"}, sample(1000, 1)),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md := pmetric.NewMetrics()
+				ms := newResourceMetrics(md, map[string]interface{}{
+					"service.name":        "web",
+					"service.instance.id": "host:80",
+					"net.host.name":       "host",
+					"net.host.port":       "80",
+					"region":              "eu",
+					"service_name":        "ignored",
+				})
+				appendNumberPoint(newGauge(ms, "up").DataPoints(), 1000, 1, nil)
+				return md
+			},
+		},
+		{
+			name: "stale markers",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series([]string{"__name__", "up"}, sample(1000, math.Float64frombits(value.StaleNaN))),
+					series([]string{"__name__", "latency_bucket", "le", "+Inf"}, sample(1000, math.Float64frombits(value.StaleNaN))),
+					series([]string{"__name__", "latency_count"}, sample(1000, math.Float64frombits(value.StaleNaN))),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md := pmetric.NewMetrics()
+				ms := newResourceMetrics(md, nil)
+				dp := newGauge(ms, "up").DataPoints().AppendEmpty()
+				dp.SetTimestamp(fromMillis(1000))
+				dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+
+				hdp := newHistogram(ms, "latency").DataPoints().AppendEmpty()
+				hdp.SetTimestamp(fromMillis(1000))
+				hdp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+				return md
+			},
+		},
+		{
+			name:     "empty",
+			req:      &prompb.WriteRequest{},
+			expected: pmetric.NewMetrics,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := writeRequestToMetrics(tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, sortAttributes(tt.expected()), sortAttributes(md))
+		})
+	}
+}
+
+func TestWriteRequestToMetricsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ts   prompb.TimeSeries
+		err  string
+	}{
+		{
+			name: "missing name",
+			ts:   series([]string{"job", "api"}, sample(1000, 1)),
+			err:  "series without __name__ label",
+		},
+		{
+			name: "invalid le",
+			ts:   series([]string{"__name__", "latency_bucket", "le", "high"}, sample(1000, 1)),
+			err:  `invalid label value "high" for series latency_bucket: strconv.ParseFloat: parsing "high": invalid syntax`,
+		},
+		{
+			name: "invalid quantile",
+			ts:   series([]string{"__name__", "latency", "quantile", ""}, sample(1000, 1)),
+			err:  `invalid label value "" for series latency: strconv.ParseFloat: parsing "": invalid syntax`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := writeRequestToMetrics(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{tt.ts}})
+			assert.EqualError(t, err, tt.err)
+			assert.Equal(t, 0, md.DataPointCount())
+		})
+	}
+}
+
+func TestResourceAttributes(t *testing.T) {
+	attrs := pcommon.NewMap()
+	populateResource(attrs, resourceKey{job: "db", instance: "[::1]:5432"})
+	assert.Equal(t, map[string]interface{}{
+		"service.name":        "db",
+		"service.instance.id": "[::1]:5432",
+		"net.host.port":       "5432",
+	}, attrs.AsRaw())
+
+	attrs = pcommon.NewMap()
+	populateResource(attrs, resourceKey{instance: "db.example.com"})
+	assert.Equal(t, map[string]interface{}{
+		"service.instance.id": "db.example.com",
+		"net.host.name":       "db.example.com",
+	}, attrs.AsRaw())
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "prometheusremotewrite"
+	// The stability level of the receiver.
+	stability = component.StabilityLevelAlpha
+
+	defaultEndpoint           = "0.0.0.0:19291"
+	defaultPath               = "/api/v1/write"
+	defaultMaxRequestBodySize = 10 << 20
+	defaultMaxDecodedBodySize = 100 << 20
+)
+
+// NewFactory creates a factory for the Prometheus remote write receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithMetricsReceiver(createMetricsReceiver, stability))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint:           defaultEndpoint,
+			MaxRequestBodySize: defaultMaxRequestBodySize,
+		},
+		Path:               defaultPath,
+		MaxDecodedBodySize: defaultMaxDecodedBodySize,
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	return newMetricsReceiver(cfg.(*Config), params, nextConsumer)
+}
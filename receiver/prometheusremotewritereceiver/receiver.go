@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	dataFormat = "prometheus_remote_write"
+
+	// histogramsFieldNumber is the field number of the native histograms of a time series,
+	// which aren't known by the version of the remote write protocol used by the receiver.
+	histogramsFieldNumber = 4
+)
+
+var errRequestTooLarge = errors.New("request too large")
+
+type metricsReceiver struct {
+	config       *Config
+	settings     component.ReceiverCreateSettings
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+
+	server *http.Server
+	wg     sync.WaitGroup
+}
+
+func newMetricsReceiver(config *Config, settings component.ReceiverCreateSettings, nextConsumer consumer.Metrics) (*metricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	transport := "http"
+	if config.TLSSetting != nil {
+		transport = "https"
+	}
+
+	return &metricsReceiver{
+		config:       config,
+		settings:     settings,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             config.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: settings,
+		}),
+	}, nil
+}
+
+func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(r.config.Path, r.handleWrite)
+
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, router)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (r *metricsReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	if err := r.server.Close(); err != nil {
+		return err
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+
+	// the body is limited to MaxRequestBodySize bytes by the HTTP server
+	writeRequest, err := decodeWriteRequest(req.Body, r.config.MaxRequestBodySize, r.config.MaxDecodedBodySize)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, err)
+		status := http.StatusBadRequest
+		if errors.Is(err, errRequestTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if dropped := countNativeHistograms(writeRequest); dropped > 0 {
+		r.settings.Logger.Warn("Dropped native histograms, which are not supported", zap.Int("histograms", dropped))
+	}
+
+	md, err := writeRequestToMetrics(writeRequest)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	numPoints := md.DataPointCount()
+	if numPoints == 0 {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, numPoints, err)
+	if err != nil {
+		r.settings.Logger.Debug("Failed to pass metrics to next consumer", zap.Error(err))
+		// Prometheus retries requests failing with a 5xx status code, but
+		// drops the ones failing with a 4xx status code.
+		status := http.StatusInternalServerError
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeWriteRequest reads a snappy compressed, protobuf encoded remote
+// write request. The body is expected to be limited to maxBodySize bytes.
+func decodeWriteRequest(body io.Reader, maxBodySize, maxDecodedSize int64) (*prompb.WriteRequest, error) {
+	compressed, err := io.ReadAll(body)
+	if err != nil {
+		if int64(len(compressed)) >= maxBodySize {
+			return nil, fmt.Errorf("%w: the request body exceeds %d bytes", errRequestTooLarge, maxBodySize)
+		}
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	decodedLen, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	if int64(decodedLen) > maxDecodedSize {
+		return nil, fmt.Errorf("%w: the decompressed request body exceeds %d bytes", errRequestTooLarge, maxDecodedSize)
+	}
+	buf, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	var writeRequest prompb.WriteRequest
+	if err = writeRequest.Unmarshal(buf); err != nil {
+		return nil, fmt.Errorf("failed to decode write request: %w", err)
+	}
+	return &writeRequest, nil
+}
+
+// countNativeHistograms returns the number of native histograms sent with
+// the series of the request, which are left as unrecognized fields.
+func countNativeHistograms(req *prompb.WriteRequest) int {
+	count := 0
+	for i := range req.Timeseries {
+		b := req.Timeseries[i].XXX_unrecognized
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			if n < 0 {
+				break
+			}
+			b = b[n:]
+			if num == histogramsFieldNumber && typ == protowire.BytesType {
+				count++
+			}
+			if n = protowire.ConsumeFieldValue(num, typ, b); n < 0 {
+				break
+			}
+			b = b[n:]
+		}
+	}
+	return count
+}
@@ -0,0 +1,313 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+)
+
+// startReceiver starts a receiver passing the metrics to next and returns
+// the URL of its write endpoint.
+func startReceiver(t *testing.T, next consumer.Metrics) string {
+	return startReceiverWithSettings(t, createDefaultConfig().(*Config), componenttest.NewNopReceiverCreateSettings(), next)
+}
+
+func startReceiverWithSettings(t *testing.T, cfg *Config, settings component.ReceiverCreateSettings, next consumer.Metrics) string {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	rcv, err := newMetricsReceiver(cfg, settings, next)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, rcv.Shutdown(context.Background()))
+	})
+	return fmt.Sprintf("http://%s%s", cfg.Endpoint, cfg.Path)
+}
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	b, err := req.Marshal()
+	require.NoError(t, err)
+	return snappy.Encode(nil, b)
+}
+
+func post(t *testing.T, url string, body []byte) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestReceiveWriteRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	resp := post(t, url, encodeWriteRequest(t, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series([]string{"__name__", "up", "instance", "host:80", "job", "web"}, sample(1000, 1)),
+			series([]string{"__name__", "requests_total", "instance", "host:80", "job", "web"}, sample(1000, 3), sample(2000, 4)),
+		},
+	}))
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	assert.Equal(t, 3, md.DataPointCount())
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+
+	resp = post(t, url, encodeWriteRequest(t, &prompb.WriteRequest{}))
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestReceiveInvalidRequests(t *testing.T) {
+	url := startReceiver(t, consumertest.NewNop())
+
+	tests := []struct {
+		name   string
+		method string
+		body   []byte
+		status int
+	}{
+		{
+			name:   "GET",
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "not snappy",
+			method: http.MethodPost,
+			body:   []byte("not snappy"),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "not protobuf",
+			method: http.MethodPost,
+			body:   snappy.Encode(nil, []byte("not protobuf")),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "series without name",
+			method: http.MethodPost,
+			body: encodeWriteRequest(t, &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series([]string{"job", "web"}, sample(1000, 1))},
+			}),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, url, bytes.NewReader(tt.body))
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestReceiveTooLargeRequests(t *testing.T) {
+	body := encodeWriteRequest(t, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series([]string{"__name__", "up", "instance", "host:80", "job", "web"}, sample(1000, 1)),
+		},
+	})
+	decoded, err := snappy.DecodedLen(body)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		maxRequestBodySize int64
+		maxDecodedBodySize int64
+		status             int
+	}{
+		{
+			name:               "within limits",
+			maxRequestBodySize: int64(len(body)),
+			maxDecodedBodySize: int64(decoded),
+			status:             http.StatusNoContent,
+		},
+		{
+			name:               "body too large",
+			maxRequestBodySize: int64(len(body)) - 1,
+			maxDecodedBodySize: defaultMaxDecodedBodySize,
+			status:             http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:               "decoded body too large",
+			maxRequestBodySize: defaultMaxRequestBodySize,
+			maxDecodedBodySize: int64(decoded) - 1,
+			status:             http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MaxRequestBodySize = tt.maxRequestBodySize
+			cfg.MaxDecodedBodySize = tt.maxDecodedBodySize
+			url := startReceiverWithSettings(t, cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+
+			resp := post(t, url, body)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestReceiveNativeHistograms(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	settings := componenttest.NewNopReceiverCreateSettings()
+	settings.Logger = zap.New(core)
+	sink := new(consumertest.MetricsSink)
+	url := startReceiverWithSettings(t, createDefaultConfig().(*Config), settings, sink)
+
+	// native histograms are sent in a field unknown to the version of the protocol used by the receiver
+	histogram := protowire.AppendTag(nil, 4, protowire.BytesType)
+	histogram = protowire.AppendBytes(histogram, nil)
+	withHistograms := series([]string{"__name__", "latency", "job", "web"})
+	withHistograms.XXX_unrecognized = append(histogram, histogram...)
+
+	resp := post(t, url, encodeWriteRequest(t, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series([]string{"__name__", "up", "job", "web"}, sample(1000, 1)),
+			withHistograms,
+		},
+	}))
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 1, sink.AllMetrics()[0].DataPointCount())
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, int64(2), logs.All()[0].ContextMap()["histograms"])
+}
+
+func TestReceiveConsumerError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name:   "retryable",
+			err:    errors.New("busy"),
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "permanent",
+			err:    consumererror.NewPermanent(errors.New("invalid")),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startReceiver(t, consumertest.NewErr(tt.err))
+			resp := post(t, url, encodeWriteRequest(t, &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series([]string{"__name__", "up"}, sample(1000, 1))},
+			}))
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+// TestRoundTrip checks that metrics converted by the remote write
+// translator used by the prometheusremotewrite exporter are converted back
+// to the original metrics.
+func TestRoundTrip(t *testing.T) {
+	ts := pcommon.NewTimestampFromTime(time.Unix(1667000000, 0))
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "api")
+	rm.Resource().Attributes().PutStr("service.instance.id", "api-1")
+	rm.Resource().Attributes().PutStr("region", "eu")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	sum := newSum(ms, "requests_total")
+	appendNumberPoint(sum.DataPoints(), 0, 10, map[string]interface{}{"code": "200"}).SetTimestamp(ts)
+
+	appendNumberPoint(newGauge(ms, "temperature").DataPoints(), 0, 21.5, nil).SetTimestamp(ts)
+
+	hdp := newHistogram(ms, "latency").DataPoints().AppendEmpty()
+	hdp.SetTimestamp(ts)
+	hdp.SetCount(6)
+	hdp.SetSum(3.5)
+	hdp.ExplicitBounds().FromRaw([]float64{0.1, 1})
+	hdp.BucketCounts().FromRaw([]uint64{2, 3, 1})
+
+	sdp := newSummary(ms, "rpc_duration").DataPoints().AppendEmpty()
+	sdp.SetTimestamp(ts)
+	sdp.SetCount(10)
+	sdp.SetSum(4)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.5)
+	q.SetValue(0.2)
+
+	tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{})
+	require.NoError(t, err)
+	req := &prompb.WriteRequest{}
+	for _, series := range tsMap {
+		req.Timeseries = append(req.Timeseries, *series)
+	}
+
+	sink := new(consumertest.MetricsSink)
+	resp := post(t, startReceiver(t, sink), encodeWriteRequest(t, req))
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	require.Len(t, sink.AllMetrics(), 1)
+
+	got := sink.AllMetrics()[0]
+	require.Equal(t, 1, got.ResourceMetrics().Len())
+	gotRm := got.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"service.name":        "api",
+		"service.instance.id": "api-1",
+		"net.host.name":       "api-1",
+		"region":              "eu",
+	}, gotRm.Resource().Attributes().AsRaw())
+
+	gotMs := gotRm.ScopeMetrics().At(0).Metrics()
+	gotMs.Sort(func(a, b pmetric.Metric) bool { return a.Name() < b.Name() })
+	ms.Sort(func(a, b pmetric.Metric) bool { return a.Name() < b.Name() })
+	assert.Equal(t, sortAttributes(md).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics(), gotMs)
+}